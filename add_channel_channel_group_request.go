@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/pubnub/go/utils"
 )
@@ -73,6 +74,18 @@ func (b *addChannelToChannelGroupBuilder) QueryParam(queryParam map[string]strin
 	return b
 }
 
+// Timeout sets the timeout for the AddChannelToChannelGroup request.
+func (b *addChannelToChannelGroupBuilder) Timeout(timeout time.Duration) *addChannelToChannelGroupBuilder {
+	b.opts.Timeout = timeout
+
+	return b
+}
+
+// SignedURL returns the URL of the AddChannelToChannelGroup request without executing it.
+func (b *addChannelToChannelGroupBuilder) SignedURL() (string, error) {
+	return signedURL(b.opts)
+}
+
 // Execute runs AddChannelToChannelGroup request
 func (b *addChannelToChannelGroupBuilder) Execute() (
 	*AddChannelToChannelGroupResponse, StatusResponse, error) {
@@ -89,6 +102,7 @@ type addChannelOpts struct {
 	Channels     []string
 	ChannelGroup string
 	QueryParam   map[string]string
+	Timeout      time.Duration
 	Transport    http.RoundTripper
 	ctx          Context
 }
@@ -128,7 +142,7 @@ func (o *addChannelOpts) buildPath() (string, error) {
 }
 
 func (o *addChannelOpts) buildQuery() (*url.Values, error) {
-	q := defaultQuery(o.pubnub.Config.UUID, o.pubnub.telemetryManager)
+	q := defaultQuery(o.pubnub.Config, o.pubnub.telemetryManager)
 
 	var channels []string
 
@@ -137,6 +151,7 @@ func (o *addChannelOpts) buildQuery() (*url.Values, error) {
 	}
 
 	q.Set("add", strings.Join(channels, ","))
+	o.pubnub.tokenManager.SetAuthParan(q, o.ChannelGroup, PNGroups)
 	SetQueryParam(q, o.QueryParam)
 
 	return q, nil
@@ -174,6 +189,18 @@ func (o *addChannelOpts) telemetryManager() *TelemetryManager {
 	return o.pubnub.telemetryManager
 }
 
+func (o *addChannelOpts) tokenManager() *TokenManager {
+	return o.pubnub.tokenManager
+}
+
+func (o *addChannelOpts) timeout() time.Duration {
+	return o.Timeout
+}
+
+func (o *addChannelOpts) transport() http.RoundTripper {
+	return o.Transport
+}
+
 // AddChannelToChannelGroupResponse is the struct returned when the Execute function of AddChannelToChannelGroup is called.
 type AddChannelToChannelGroupResponse struct {
 }
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 )
 
 const addChannelsToPushPath = "/v1/push/sub-key/%s/devices/%s"
@@ -61,6 +62,12 @@ func (b *addPushNotificationsOnChannelsBuilder) DeviceIDForPush(
 	return b
 }
 
+// Transport sets the Transport for the AddPushNotificationsOnChannels request.
+func (b *addPushNotificationsOnChannelsBuilder) Transport(tr http.RoundTripper) *addPushNotificationsOnChannelsBuilder {
+	b.opts.Transport = tr
+	return b
+}
+
 // QueryParam accepts a map, the keys and values of the map are passed as the query string parameters of the URL called by the API.
 func (b *addPushNotificationsOnChannelsBuilder) QueryParam(queryParam map[string]string) *addPushNotificationsOnChannelsBuilder {
 	b.opts.QueryParam = queryParam
@@ -68,6 +75,18 @@ func (b *addPushNotificationsOnChannelsBuilder) QueryParam(queryParam map[string
 	return b
 }
 
+// Timeout sets the timeout for the AddPushNotificationsOnChannels request.
+func (b *addPushNotificationsOnChannelsBuilder) Timeout(timeout time.Duration) *addPushNotificationsOnChannelsBuilder {
+	b.opts.Timeout = timeout
+
+	return b
+}
+
+// SignedURL returns the URL of the AddPushNotificationsOnChannels request without executing it.
+func (b *addPushNotificationsOnChannelsBuilder) SignedURL() (string, error) {
+	return signedURL(b.opts)
+}
+
 // Execute runs add Push Notifications on channels request
 func (b *addPushNotificationsOnChannelsBuilder) Execute() (
 	*AddPushNotificationsOnChannelsResponse, StatusResponse, error) {
@@ -85,6 +104,7 @@ type addChannelsToPushOpts struct {
 	PushType        PNPushType
 	DeviceIDForPush string
 	QueryParam      map[string]string
+	Timeout         time.Duration
 	Transport       http.RoundTripper
 	ctx             Context
 }
@@ -131,7 +151,7 @@ func (o *addChannelsToPushOpts) buildPath() (string, error) {
 }
 
 func (o *addChannelsToPushOpts) buildQuery() (*url.Values, error) {
-	q := defaultQuery(o.pubnub.Config.UUID, o.pubnub.telemetryManager)
+	q := defaultQuery(o.pubnub.Config, o.pubnub.telemetryManager)
 
 	var channels []string
 
@@ -171,9 +191,21 @@ func (o *addChannelsToPushOpts) connectTimeout() int {
 }
 
 func (o *addChannelsToPushOpts) operationType() OperationType {
-	return PNRemoveGroupOperation
+	return PNAddPushNotificationsOnChannelsOperation
 }
 
 func (o *addChannelsToPushOpts) telemetryManager() *TelemetryManager {
 	return o.pubnub.telemetryManager
 }
+
+func (o *addChannelsToPushOpts) tokenManager() *TokenManager {
+	return o.pubnub.tokenManager
+}
+
+func (o *addChannelsToPushOpts) timeout() time.Duration {
+	return o.Timeout
+}
+
+func (o *addChannelsToPushOpts) transport() http.RoundTripper {
+	return o.Transport
+}
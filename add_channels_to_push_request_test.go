@@ -156,5 +156,5 @@ func TestAddChannelsToPushValidateSubscribeKey(t *testing.T) {
 		pubnub:          pn,
 	}
 
-	assert.Equal("pubnub/validation: pubnub: \x0e: Missing Subscribe Key", opts.validate().Error())
+	assert.Equal("pubnub/validation: pubnub: Add Push From Channel: Missing Subscribe Key", opts.validate().Error())
 }
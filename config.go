@@ -2,8 +2,11 @@ package pubnub
 
 import (
 	"fmt"
+	"github.com/pubnub/go/pnerr"
 	"github.com/pubnub/go/utils"
 	"log"
+	"strings"
+	"time"
 )
 
 const (
@@ -14,32 +17,50 @@ const (
 // PubNub client behaviour. Configuration instance contain additional set of
 // properties which allow to perform precise PubNub client configuration.
 type Config struct {
-	PublishKey                 string             // PublishKey you can get it from admin panel (only required if publishing).
-	SubscribeKey               string             // SubscribeKey you can get it from admin panel.
-	SecretKey                  string             // SecretKey (only required for modifying/revealing access permissions).
-	AuthKey                    string             // AuthKey If Access Manager is utilized, client will use this AuthKey in all restricted requests.
-	Origin                     string             // Custom Origin if needed
-	UUID                       string             // UUID to be used as a device identifier, a default uuid is generated if not passed.
-	CipherKey                  string             // If CipherKey is passed, all communications to/from PubNub will be encrypted.
-	Secure                     bool               // True to use TLS
-	ConnectTimeout             int                // net.Dialer.Timeout
-	NonSubscribeRequestTimeout int                // http.Client.Timeout for non-subscribe requests
-	SubscribeRequestTimeout    int                // http.Client.Timeout for subscribe requests only
-	HeartbeatInterval          int                // The frequency of the pings to the server to state that the client is active
-	PresenceTimeout            int                // The time after which the server will send a timeout for the client
-	MaximumReconnectionRetries int                // The config sets how many times to retry to reconnect before giving up.
-	MaximumLatencyDataAge      int                // Max time to store the latency data for telemetry
-	FilterExpression           string             // Feature to subscribe with a custom filter expression.
-	PNReconnectionPolicy       ReconnectionPolicy // Reconnection policy selection
-	Log                        *log.Logger        // Logger instance
-	SuppressLeaveEvents        bool               // When true the SDK doesn't send out the leave requests.
-	DisablePNOtherProcessing   bool               // PNOther processing looks for pn_other in the JSON on the recevied message
-	UseHTTP2                   bool               // HTTP2 Flag
-	MessageQueueOverflowCount  int                // When the limit is exceeded by the number of messages received in a single subscribe request, a status event PNRequestMessageCountExceededCategory is fired.
-	MaxIdleConnsPerHost        int                // Used to set the value of HTTP Transport's MaxIdleConnsPerHost.
-	MaxWorkers                 int                // Number of max workers for Publish and Grant requests
-	UsePAMV3                   bool               // Use PAM version 2, Objects requets would still use PAM v3
-	StoreTokensOnGrant         bool               // Will store grant v3 tokens in token manager for further use.
+	PublishKey                      string             // PublishKey you can get it from admin panel (only required if publishing).
+	SubscribeKey                    string             // SubscribeKey you can get it from admin panel.
+	SecretKey                       string             // SecretKey (only required for modifying/revealing access permissions).
+	AuthKey                         string             // AuthKey If Access Manager is utilized, client will use this AuthKey in all restricted requests.
+	AuthToken                       string             // AuthToken is a PAM v3 token sent as the auth param, when set it takes precedence over AuthKey.
+	Origin                          string             // Custom Origin if needed
+	Origins                         []string           // Failover origins, tried in order after Origin when a request fails to connect.
+	UUID                            string             // UUID to be used as a device identifier, a default uuid is generated if not passed.
+	CipherKey                       string             // If CipherKey is passed, all communications to/from PubNub will be encrypted.
+	UseRandomInitializationVector   bool               // When true a random IV is used for encryption and read from the received messages for decryption.
+	Secure                          bool               // True to use TLS
+	ConnectTimeout                  int                // net.Dialer.Timeout
+	NonSubscribeRequestTimeout      int                // http.Client.Timeout for non-subscribe requests
+	SubscribeRequestTimeout         int                // http.Client.Timeout for subscribe requests only
+	HeartbeatInterval               int                // The frequency of the pings to the server to state that the client is active
+	PresenceTimeout                 int                // The time after which the server will send a timeout for the client
+	MaximumReconnectionRetries      int                // The config sets how many times to retry to reconnect before giving up.
+	MaximumLatencyDataAge           int                // Max time to store the latency data for telemetry
+	FilterExpression                string             // Feature to subscribe with a custom filter expression.
+	PNReconnectionPolicy            ReconnectionPolicy // Reconnection policy selection
+	Log                             *log.Logger        // Logger instance
+	LogVerbosity                    PNLogVerbosity     // Detail of the request tracing written to Log, PNLogRequestsAndBodies also logs response bodies.
+	SuppressLeaveEvents             bool               // When true the SDK doesn't send out the leave requests.
+	DisablePNOtherProcessing        bool               // PNOther processing looks for pn_other in the JSON on the recevied message
+	UseHTTP2                        bool               // HTTP2 Flag
+	MessageQueueOverflowCount       int                // When the limit is exceeded by the number of messages received in a single subscribe request, a status event PNRequestMessageCountExceededCategory is fired.
+	MaxIdleConnsPerHost             int                // Used to set the value of HTTP Transport's MaxIdleConnsPerHost.
+	MaxWorkers                      int                // Number of max workers for Publish and Grant requests
+	UsePAMV3                        bool               // Use PAM version 2, Objects requets would still use PAM v3
+	StoreTokensOnGrant              bool               // Will store grant v3 tokens in token manager for further use.
+	UseNumber                       bool               // When true numbers in history and subscribe messages are decoded as json.Number instead of float64.
+	DisableDefaultQueryParams       bool               // When true pnsdk and uuid are not added to the requests, uuid must then be passed with QueryParam.
+	DecryptionFallbackToRaw         bool               // When true a message that fails to decrypt is returned as received instead of with a decryption error.
+	StatsListener                   func(RequestStats) // Called in a goroutine after each non-subscribe request with its operation, latency, status code and error.
+	NormalizeChannelNames           bool               // When true the channel names are NFC normalized before they are encoded in the request paths.
+	PublishRetryOnThrottle          bool               // When true a Publish answered 429 is retried after its Retry-After, at most 60s, up to MaximumReconnectionRetries times.
+	IncludeInstanceIdentifier       bool               // When true the requests carry the instanceid param, stable for the PubNub instance, to correlate them with PubNub support.
+	IncludeRequestIdentifier        bool               // When true the requests carry the requestid param, unique for each request.
+	StateCacheTTL                   int                // Seconds a state read by GetState with UseCache is served from the cache.
+	SubscribeMaxReconnectionRetries int                // When > 0 the subscribe loop stops after this many consecutive failed requests, the channels stay subscribed.
+
+	reconnectionBackoffMin    time.Duration
+	reconnectionBackoffMax    time.Duration
+	reconnectionBackoffJitter float64
 }
 
 // NewDemoConfig initiates the config with demo keys, for tests only.
@@ -72,13 +93,138 @@ func NewConfig() *Config {
 		MaxWorkers:                 20,
 		UsePAMV3:                   true,
 		StoreTokensOnGrant:         true,
+		LogVerbosity:               PNLogRequests,
+		StateCacheTTL:              60,
 	}
 
-	c.UUID = fmt.Sprintf("pn-%s", utils.UUID())
+	c.UUID = generateUUID()
 
 	return &c
 }
 
+// Copy returns a copy of the config, the copy shares the Log of the config and has its own Origins.
+// Config holds no subscribe or heartbeat state, a client created from the copy starts with its own.
+func (c *Config) Copy() *Config {
+	config := *c
+	if c.Origins != nil {
+		config.Origins = append([]string{}, c.Origins...)
+	}
+	return &config
+}
+
+// redacted returns a copy of the config to be logged, with the keys and tokens masked.
+func (c *Config) redacted() *Config {
+	config := *c
+	for _, secret := range []*string{&config.SecretKey, &config.AuthKey, &config.AuthToken, &config.CipherKey} {
+		if *secret != "" {
+			*secret = "REDACTED"
+		}
+	}
+	return &config
+}
+
+// channelName returns the channel NFC normalized when NormalizeChannelNames is set.
+func (c *Config) channelName(channel string) string {
+	if !c.NormalizeChannelNames {
+		return channel
+	}
+	return utils.NormalizeChannel(channel)
+}
+
+// channelNames returns the channels NFC normalized when NormalizeChannelNames is set.
+func (c *Config) channelNames(channels []string) []string {
+	if !c.NormalizeChannelNames {
+		return channels
+	}
+	normalized := make([]string, len(channels))
+	for i, channel := range channels {
+		normalized[i] = utils.NormalizeChannel(channel)
+	}
+	return normalized
+}
+
+func generateUUID() string {
+	return fmt.Sprintf("pn-%s", utils.UUID())
+}
+
+// SetUUID sets the UUID used as the device identifier.
+// Empty or whitespace only values are rejected and the current UUID is retained.
+func (c *Config) SetUUID(uuid string) error {
+	if strings.TrimSpace(uuid) == "" {
+		return pnerr.NewValidationError("Config", StrMissingUUID)
+	}
+	c.UUID = uuid
+
+	return nil
+}
+
+// SetAuthToken sets the PAM v3 token sent as the auth param of the requests, it takes precedence over AuthKey.
+// Pass an empty token to fall back to AuthKey.
+func (c *Config) SetAuthToken(token string) *Config {
+	c.AuthToken = strings.TrimSpace(token)
+
+	return c
+}
+
+var keyPrefixes = []string{"pub-c-", "sub-c-", "sec-c-"}
+
+// validateKey trims the key and checks it is not empty and, when it carries a
+// key type prefix, that the prefix is the expected one.
+func validateKey(key, prefix, missing string) (string, error) {
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return "", pnerr.NewValidationError("Config", missing)
+	}
+	for _, p := range keyPrefixes {
+		if strings.HasPrefix(key, p) && p != prefix {
+			return "", pnerr.NewValidationError("Config",
+				fmt.Sprintf("%s: expected prefix %s, got %s", StrInvalidKey, prefix, p))
+		}
+	}
+	if strings.HasPrefix(key, prefix) && len(key) == len(prefix) {
+		return "", pnerr.NewValidationError("Config",
+			fmt.Sprintf("%s: nothing after prefix %s", StrInvalidKey, prefix))
+	}
+
+	return key, nil
+}
+
+// SetPublishKey trims and sets the PublishKey.
+// Malformed keys are rejected and the current PublishKey is retained.
+func (c *Config) SetPublishKey(key string) error {
+	key, err := validateKey(key, "pub-c-", StrMissingPubKey)
+	if err != nil {
+		return err
+	}
+	c.PublishKey = key
+
+	return nil
+}
+
+// SetSubscribeKey trims and sets the SubscribeKey.
+// Malformed keys are rejected and the current SubscribeKey is retained.
+func (c *Config) SetSubscribeKey(key string) error {
+	key, err := validateKey(key, "sub-c-", StrMissingSubKey)
+	if err != nil {
+		return err
+	}
+	c.SubscribeKey = key
+
+	return nil
+}
+
+// SetSecretKey trims and sets the SecretKey.
+// Malformed keys are rejected and the current SecretKey is retained.
+func (c *Config) SetSecretKey(key string) error {
+	key, err := validateKey(key, "sec-c-", StrMissingSecretKey)
+	if err != nil {
+		return err
+	}
+	c.SecretKey = key
+
+	return nil
+}
+
 func (c *Config) checkMinTimeout(timeout int) int {
 	if timeout < minTimeout {
 		if c.Log != nil {
@@ -109,3 +255,27 @@ func (c *Config) SetPresenceTimeout(timeout int) *Config {
 	timeout = c.checkMinTimeout(timeout)
 	return c.SetPresenceTimeoutWithCustomInterval(timeout, (timeout/2)-1)
 }
+
+// SetReconnectionBackoff sets the delays of the reconnection loop, it overrides the delays of the PNReconnectionPolicy.
+// min: delay after the first failure, doubled after each further failure.
+// max: cap of the delay.
+// jitter: randomizes each delay by ±jitter of its value, between 0 and 1.
+func (c *Config) SetReconnectionBackoff(min, max time.Duration, jitter float64) *Config {
+	if min <= 0 {
+		min = reconnectionMinExponentialBackoff * time.Second
+	}
+	if max < min {
+		max = min
+	}
+	if jitter < 0 {
+		jitter = 0
+	} else if jitter > 1 {
+		jitter = 1
+	}
+
+	c.reconnectionBackoffMin = min
+	c.reconnectionBackoffMax = max
+	c.reconnectionBackoffJitter = jitter
+
+	return c
+}
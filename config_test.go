@@ -0,0 +1,115 @@
+package pubnub
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewConfigGeneratesUUID(t *testing.T) {
+	assert := assert.New(t)
+
+	c1 := NewConfig()
+	c2 := NewConfig()
+
+	assert.True(strings.HasPrefix(c1.UUID, "pn-"))
+	assert.NotEqual(c1.UUID, c2.UUID)
+}
+
+func TestConfigSetUUID(t *testing.T) {
+	assert := assert.New(t)
+
+	c := NewConfig()
+	assert.Nil(c.SetUUID("my-uuid"))
+	assert.Equal("my-uuid", c.UUID)
+
+	err := c.SetUUID("")
+	assert.Contains(err.Error(), StrMissingUUID)
+	assert.Equal("my-uuid", c.UUID)
+
+	err = c.SetUUID("  \t")
+	assert.Contains(err.Error(), StrMissingUUID)
+	assert.Equal("my-uuid", c.UUID)
+}
+
+func TestExecuteRequestEmptyUUID(t *testing.T) {
+	assert := assert.New(t)
+
+	pn := NewPubNub(NewDemoConfig())
+	pn.Config.UUID = " "
+
+	_, status, err := pn.Time().Execute()
+	assert.Contains(err.Error(), StrMissingUUID)
+	assert.Equal(PNUnknownCategory, status.Category)
+}
+
+func TestConfigSetKeys(t *testing.T) {
+	assert := assert.New(t)
+
+	c := NewConfig()
+	assert.Nil(c.SetPublishKey(" pub-c-123 "))
+	assert.Equal("pub-c-123", c.PublishKey)
+	assert.Nil(c.SetSubscribeKey("sub-c-123\n"))
+	assert.Equal("sub-c-123", c.SubscribeKey)
+	assert.Nil(c.SetSecretKey("sec-c-123"))
+	assert.Equal("sec-c-123", c.SecretKey)
+
+	assert.Nil(c.SetSubscribeKey("demo"))
+	assert.Equal("demo", c.SubscribeKey)
+}
+
+func TestConfigSetKeysMalformed(t *testing.T) {
+	assert := assert.New(t)
+
+	c := NewConfig()
+	c.PublishKey = "pub-c-123"
+	c.SubscribeKey = "sub-c-123"
+	c.SecretKey = "sec-c-123"
+
+	err := c.SetPublishKey("  ")
+	assert.Contains(err.Error(), StrMissingPubKey)
+
+	err = c.SetSubscribeKey("")
+	assert.Contains(err.Error(), StrMissingSubKey)
+
+	err = c.SetSubscribeKey("pub-c-456")
+	assert.Contains(err.Error(), "Invalid Key: expected prefix sub-c-, got pub-c-")
+
+	err = c.SetSecretKey("sub-c-456")
+	assert.Contains(err.Error(), "Invalid Key: expected prefix sec-c-, got sub-c-")
+
+	err = c.SetSecretKey("sec-c-")
+	assert.Contains(err.Error(), StrInvalidKey)
+
+	assert.Equal("pub-c-123", c.PublishKey)
+	assert.Equal("sub-c-123", c.SubscribeKey)
+	assert.Equal("sec-c-123", c.SecretKey)
+}
+
+func TestConfigCopy(t *testing.T) {
+	assert := assert.New(t)
+
+	c := NewDemoConfig()
+	c.SetReconnectionBackoff(time.Second, 10*time.Second, 0.5)
+	c.Origins = []string{"ps1.pndsn.com", "ps2.pndsn.com"}
+
+	cp := c.Copy()
+	assert.Equal(c, cp)
+	assert.True(c.Log == cp.Log)
+
+	cp.Origins[0] = "ps3.pndsn.com"
+	assert.Equal([]string{"ps1.pndsn.com", "ps2.pndsn.com"}, c.Origins)
+
+	cp.SubscribeKey = "sub-c-copy"
+	cp.SetUUID("copy-uuid")
+	cp.SetPresenceTimeout(300)
+	cp.SetReconnectionBackoff(2*time.Second, 20*time.Second, 0)
+
+	assert.Equal("demo", c.SubscribeKey)
+	assert.NotEqual("copy-uuid", c.UUID)
+	assert.NotEqual(300, c.PresenceTimeout)
+	assert.Equal(time.Second, c.reconnectionBackoffMin)
+	assert.Equal(0.5, c.reconnectionBackoffJitter)
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"time"
 
 	"github.com/pubnub/go/utils"
 )
@@ -45,6 +46,12 @@ func (b *deleteChannelGroupBuilder) ChannelGroup(
 	return b
 }
 
+// Transport sets the Transport for the DeleteChannelGroup request.
+func (b *deleteChannelGroupBuilder) Transport(tr http.RoundTripper) *deleteChannelGroupBuilder {
+	b.opts.Transport = tr
+	return b
+}
+
 // QueryParam accepts a map, the keys and values of the map are passed as the query string parameters of the URL called by the API.
 func (b *deleteChannelGroupBuilder) QueryParam(queryParam map[string]string) *deleteChannelGroupBuilder {
 	b.opts.QueryParam = queryParam
@@ -52,6 +59,18 @@ func (b *deleteChannelGroupBuilder) QueryParam(queryParam map[string]string) *de
 	return b
 }
 
+// Timeout sets the timeout for the DeleteChannelGroup request.
+func (b *deleteChannelGroupBuilder) Timeout(timeout time.Duration) *deleteChannelGroupBuilder {
+	b.opts.Timeout = timeout
+
+	return b
+}
+
+// SignedURL returns the URL of the DeleteChannelGroup request without executing it.
+func (b *deleteChannelGroupBuilder) SignedURL() (string, error) {
+	return signedURL(b.opts)
+}
+
 // Execute runs the DeleteChannelGroup request.
 func (b *deleteChannelGroupBuilder) Execute() (
 	*DeleteChannelGroupResponse, StatusResponse, error) {
@@ -69,6 +88,7 @@ type deleteChannelGroupOpts struct {
 	ChannelGroup string
 	Transport    http.RoundTripper
 	QueryParam   map[string]string
+	Timeout      time.Duration
 	ctx          Context
 }
 
@@ -106,7 +126,8 @@ func (o *deleteChannelGroupOpts) buildPath() (string, error) {
 }
 
 func (o *deleteChannelGroupOpts) buildQuery() (*url.Values, error) {
-	q := defaultQuery(o.pubnub.Config.UUID, o.pubnub.telemetryManager)
+	q := defaultQuery(o.pubnub.Config, o.pubnub.telemetryManager)
+	o.pubnub.tokenManager.SetAuthParan(q, o.ChannelGroup, PNGroups)
 	SetQueryParam(q, o.QueryParam)
 	return q, nil
 }
@@ -142,3 +163,15 @@ func (o *deleteChannelGroupOpts) operationType() OperationType {
 func (o *deleteChannelGroupOpts) telemetryManager() *TelemetryManager {
 	return o.pubnub.telemetryManager
 }
+
+func (o *deleteChannelGroupOpts) tokenManager() *TokenManager {
+	return o.pubnub.tokenManager
+}
+
+func (o *deleteChannelGroupOpts) timeout() time.Duration {
+	return o.Timeout
+}
+
+func (o *deleteChannelGroupOpts) transport() http.RoundTripper {
+	return o.Transport
+}
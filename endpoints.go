@@ -1,6 +1,8 @@
 package pubnub
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"log"
 	"net/http"
@@ -24,7 +26,33 @@ type endpointOpts interface {
 	buildBody() ([]byte, error)
 	httpMethod() string
 	operationType() OperationType
+	isAuthRequired() bool
 	telemetryManager() *TelemetryManager
+	tokenManager() *TokenManager
+	timeout() time.Duration
+}
+
+// endpointHeaders is implemented by the endpointOpts which send additional request headers.
+type endpointHeaders interface {
+	requestHeaders() map[string]string
+}
+
+// endpointTransport is implemented by the endpointOpts which accept a per request Transport.
+type endpointTransport interface {
+	transport() http.RoundTripper
+}
+
+// endpointInternal is implemented by the endpointOpts of the requests the SDK also sends on its own, e.g. the heartbeats.
+type endpointInternal interface {
+	isInternal() bool
+}
+
+// endpointTimeouts is implemented by the endpointOpts which have a connect and a request timeout in seconds,
+// they override Config.ConnectTimeout and Config.NonSubscribeRequestTimeout. The connect timeout must be less
+// than the request timeout, it is set on a copy of the *http.Transport of the client.
+type endpointTimeouts interface {
+	connectTimeout() int
+	requestTimeout() int
 }
 
 func SetQueryParam(q *url.Values, queryParam map[string]string) {
@@ -35,12 +63,14 @@ func SetQueryParam(q *url.Values, queryParam map[string]string) {
 	}
 }
 
-func defaultQuery(uuid string, telemetryManager *TelemetryManager) *url.Values {
+func defaultQuery(config *Config, telemetryManager *TelemetryManager) *url.Values {
 	v := &url.Values{}
 
-	v.Set("pnsdk", "PubNub-Go/"+Version)
+	if !config.DisableDefaultQueryParams {
+		v.Set("pnsdk", "PubNub-Go/"+Version)
 
-	v.Set("uuid", uuid)
+		v.Set("uuid", config.UUID)
+	}
 
 	for queryName, queryParam := range telemetryManager.OperationLatency() {
 		v.Set(queryName, queryParam)
@@ -49,7 +79,36 @@ func defaultQuery(uuid string, telemetryManager *TelemetryManager) *url.Values {
 	return v
 }
 
+// signedURL validates the request and returns its URL without executing it, the URL is signed when the
+// SecretKey is set. The requests which send a body are rejected, the URL alone can't be used to send them.
+func signedURL(o endpointOpts) (string, error) {
+	if err := o.validate(); err != nil {
+		return "", err
+	}
+
+	if method := o.httpMethod(); method == "POST" || method == "PATCH" {
+		return "", newValidationError(o, fmt.Sprintf("SignedURL can't be used for a %s request, it sends a body", method))
+	}
+
+	u, err := buildURL(o)
+	if err != nil {
+		return "", err
+	}
+
+	return u.String(), nil
+}
+
 func buildURL(o endpointOpts) (*url.URL, error) {
+	body, err := o.buildBody()
+	if err != nil {
+		body = nil
+	}
+
+	return buildURLWithBody(o, body)
+}
+
+// buildURLWithBody returns the URL of the request, the v2 signature covers body which must be the body sent.
+func buildURLWithBody(o endpointOpts, body []byte) (*url.URL, error) {
 	var stringifiedQuery string
 	var signature string
 
@@ -63,29 +122,59 @@ func buildURL(o endpointOpts) (*url.URL, error) {
 		return &url.URL{}, err
 	}
 
+	if o.config().DisableDefaultQueryParams && query.Get("uuid") == "" {
+		// the requests sent by the SDK on its own keep the Config.UUID, there is no QueryParam to pass it with
+		if i, ok := o.(endpointInternal); ok && i.isInternal() && strings.TrimSpace(o.config().UUID) != "" {
+			query.Set("uuid", o.config().UUID)
+		} else {
+			return &url.URL{}, newValidationError(o, fmt.Sprintf("%s: pass uuid with QueryParam when DisableDefaultQueryParams is set", StrMissingUUID))
+		}
+	}
+
 	if o.config().FilterExpression != "" {
 		query.Set("filter-expr", o.config().FilterExpression)
 	}
 
+	// the token takes precedence over the AuthKey, the AuthKey is the fallback when no token is set
+	if v := o.config().AuthToken; v != "" && query.Get("auth") == "" {
+		query.Set("auth", v)
+	}
+
 	if v := o.config().AuthKey; v != "" && query.Get("auth") == "" {
 		query.Set("auth", v)
 	}
 
+	if query.Get("auth") == "" && o.isAuthRequired() {
+		if v := o.tokenManager().GetAuthToken(); v != "" {
+			query.Set("auth", v)
+		}
+	}
+
+	if o.config().IncludeInstanceIdentifier {
+		if tm := o.tokenManager(); tm != nil && tm.pubnub != nil {
+			query.Set("instanceid", tm.pubnub.instanceID)
+		}
+	}
+
+	if o.config().IncludeRequestIdentifier {
+		query.Set("requestid", utils.UUID())
+	}
+
 	if o.config().SecretKey != "" {
 		timestamp := time.Now().Unix()
 		query.Set("timestamp", strconv.Itoa(int(timestamp)))
 
-		if (!o.config().UsePAMV3) || ((o.operationType() == PNPublishOperation) && (o.httpMethod() == "POST")) {
+		if !o.config().UsePAMV3 {
 			signedInput := o.config().SubscribeKey + "\n" + o.config().PublishKey + "\n"
 
 			signedInput += fmt.Sprintf("%s\n", path)
 
 			signedInput += utils.PreparePamParams(query)
-			o.config().Log.Println("signedInput:", signedInput)
+			o.config().Log.Println("signedInput:", path)
 
 			signature = utils.GetHmacSha256(o.config().SecretKey, signedInput)
 		} else {
-			signature = createSignatureV2(o, path, query)
+			signature = createSignatureV2(o, path, query, body)
 		}
 	}
 
@@ -137,24 +226,17 @@ func buildURL(o endpointOpts) (*url.URL, error) {
 	return retURL, nil
 }
 
-func createSignatureV2(o endpointOpts, path string, query *url.Values) string {
-	bodyString := ""
-	b, err := o.buildBody()
-	if err == nil {
-		bodyString = string(b)
-	}
-
+func createSignatureV2(o endpointOpts, path string, query *url.Values, body []byte) string {
 	sig := createSignatureV2FromStrings(
 		o.httpMethod(),
 		o.config().PublishKey,
 		o.config().SecretKey,
 		fmt.Sprintf("%s", path),
 		utils.PreparePamParams(query),
-		bodyString,
+		string(body),
 		o.config().Log,
 	)
 
-	o.config().Log.Println("signaturev2:", sig)
 	return sig
 }
 
@@ -165,15 +247,39 @@ func createSignatureV2FromStrings(httpMethod, pubKey, secKey, path, query, body
 	signedInputV2 += query + "\n"
 	signedInputV2 += body
 	if l != nil {
-		l.Println("signedInputV2:", signedInputV2)
+		// the query holds the auth key, only the method and path are logged
+		l.Println("signedInputV2:", httpMethod, path)
 	}
 
-	encoded := utils.GetHmacSha256(secKey, signedInputV2)
-	encoded = strings.TrimRight(encoded, "=")
-	signatureV2 := "v2." + encoded
-	return signatureV2
+	return "v2." + utils.GetHmacSha256Base64(secKey, signedInputV2)
 }
 
 func newValidationError(o endpointOpts, msg string) error {
-	return pnerr.NewValidationError(string(o.operationType()), msg)
+	return pnerr.NewValidationError(o.operationType().String(), msg)
+}
+
+// unmarshalJSON decodes data into v, with useNumber numbers in untyped values are
+// decoded as json.Number instead of float64 to keep the precision of large integers.
+func unmarshalJSON(data []byte, v interface{}, useNumber bool) error {
+	if !useNumber {
+		return json.Unmarshal(data, v)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	return decoder.Decode(v)
+}
+
+// channelForbiddenChars are not allowed in channel names, `,` is the channel separator.
+const channelForbiddenChars = "/?#,"
+
+// validateChannelNames returns a validation error naming the first channel with a forbidden character.
+func validateChannelNames(o endpointOpts, channels ...string) error {
+	for _, channel := range channels {
+		if i := strings.IndexAny(channel, channelForbiddenChars); i >= 0 {
+			return newValidationError(o, fmt.Sprintf("%s %s: '%c' is not allowed", StrInvalidChannel, channel, channel[i]))
+		}
+	}
+
+	return nil
 }
@@ -3,7 +3,9 @@ package pubnub
 import (
 	"net/http"
 	"net/url"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -54,6 +56,10 @@ func (o *fakeEndpointOpts) httpMethod() string {
 	return "GET"
 }
 
+func (o *fakeEndpointOpts) isAuthRequired() bool {
+	return false
+}
+
 func (o *fakeEndpointOpts) operationType() OperationType {
 	return PNSubscribeOperation
 }
@@ -62,6 +68,14 @@ func (o *fakeEndpointOpts) telemetryManager() *TelemetryManager {
 	return o.pubnub.telemetryManager
 }
 
+func (o *fakeEndpointOpts) tokenManager() *TokenManager {
+	return o.pubnub.tokenManager
+}
+
+func (o *fakeEndpointOpts) timeout() time.Duration {
+	return 0
+}
+
 func xTestBuildURL(t *testing.T) {
 	assert := assert.New(t)
 
@@ -122,3 +136,178 @@ func TestSignatureV2_1(t *testing.T) {
 	sigv2 := createSignatureV2FromStrings(httpMethod, pubKey, secKey, path, query, "", nil)
 	assert.Equal("v2.-S0k_J_rdoXqQTrQ7A3EVNxDSyupCv7OEPpS2EXukm4", sigv2)
 }
+
+func TestDisableDefaultQueryParams(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+	pn.Config.DisableDefaultQueryParams = true
+
+	o := newHereNowBuilder(pn)
+	o.Channels([]string{"ch"})
+	o.QueryParam(map[string]string{"uuid": "my-uuid", "pnsdk": "proxy"})
+
+	u, err := buildURL(o.opts)
+	assert.Nil(err)
+	assert.Contains(u.RawQuery, "uuid=my-uuid")
+	assert.Contains(u.RawQuery, "pnsdk=proxy")
+	assert.NotContains(u.RawQuery, Version)
+
+	o.QueryParam(nil)
+	q, err := o.opts.buildQuery()
+	assert.Nil(err)
+	assert.Equal("", q.Get("pnsdk"))
+	assert.Equal("", q.Get("uuid"))
+
+	_, err = buildURL(o.opts)
+	assert.Contains(err.Error(), "Missing UUID: pass uuid with QueryParam when DisableDefaultQueryParams is set")
+
+	// the time polling, heartbeats, leaves and subscribes of the SDK keep the Config.UUID
+	tb := newTimeBuilder(pn)
+	_, err = buildURL(tb.opts)
+	assert.Contains(err.Error(), StrMissingUUID)
+
+	tb.opts.internal = true
+	u, err = buildURL(tb.opts)
+	assert.Nil(err)
+	assert.Equal(pn.Config.UUID, u.Query().Get("uuid"))
+	assert.Equal("", u.Query().Get("pnsdk"))
+
+	u, err = buildURL(&subscribeOpts{pubnub: pn, Channels: []string{"ch"}})
+	assert.Nil(err)
+	assert.Equal(pn.Config.UUID, u.Query().Get("uuid"))
+
+	pn.Config.UUID = ""
+	_, err = buildURL(tb.opts)
+	assert.Contains(err.Error(), StrMissingUUID)
+
+	_, _, err = pn.HereNow().Channels([]string{"ch"}).Execute()
+	assert.Contains(err.Error(), StrMissingUUID)
+}
+
+func TestSignedURL(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+	pn.Config.SecretKey = "sec-key"
+
+	u, err := pn.Publish().Channel("ch").Message("hey").SignedURL()
+	assert.Nil(err)
+	assert.True(strings.HasPrefix(u, "https://ps.pndsn.com/publish/demo/demo/0/ch/0/"))
+	assert.Contains(u, "signature=v2.")
+	assert.Contains(u, "timestamp=")
+
+	_, err = pn.Publish().Message("hey").SignedURL()
+	assert.Contains(err.Error(), StrMissingChannel)
+
+	_, err = pn.Publish().Channel("ch").Message("hey").UsePost(true).SignedURL()
+	assert.Contains(err.Error(), "SignedURL can't be used for a POST request")
+
+	_, err = pn.CreateUser().ID("id").Name("name").SignedURL()
+	assert.Contains(err.Error(), "SignedURL can't be used for a POST request")
+
+	_, err = pn.UpdateUser().ID("id").Name("name").SignedURL()
+	assert.Contains(err.Error(), "SignedURL can't be used for a PATCH request")
+
+	pn.Config.SecretKey = ""
+	u, err = pn.GetUsers().SignedURL()
+	assert.Nil(err)
+	assert.NotContains(u, "signature=")
+}
+
+func TestSignedURLNormalizeChannelNames(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+	pn.Config.SecretKey = "sec-key"
+	pn.Config.NormalizeChannelNames = true
+
+	composed := "caf\u00e9"
+	decomposed := "cafe\u0301"
+	assert.NotEqual(composed, decomposed)
+
+	// the query params are in map order, the paths and the params are compared
+	split := func(u string) (string, url.Values) {
+		parts := strings.SplitN(u, "?", 2)
+		query, err := url.ParseQuery(parts[1])
+		assert.Nil(err)
+		return parts[0], query
+	}
+
+	// the URLs are signed with the current timestamp, they are compared when created in the same second
+	var p1, p2 string
+	var q1, q2 url.Values
+	for i := 0; i < 3; i++ {
+		u1, err := pn.GetState().Channels([]string{composed}).UUID("u").SignedURL()
+		assert.Nil(err)
+		u2, err := pn.GetState().Channels([]string{decomposed}).UUID("u").SignedURL()
+		assert.Nil(err)
+		p1, q1 = split(u1)
+		p2, q2 = split(u2)
+		if q1.Get("timestamp") == q2.Get("timestamp") {
+			break
+		}
+	}
+	assert.Equal(p1, p2)
+	assert.Equal(q1, q2)
+	assert.True(strings.HasSuffix(p1, "/channel/caf%C3%A9/uuid/u"))
+
+	u, err := pn.Publish().Channel(decomposed).Message("hey").SignedURL()
+	assert.Nil(err)
+	assert.Contains(u, "/publish/demo/demo/0/caf%C3%A9/0/")
+
+	pn.Config.NormalizeChannelNames = false
+	u, err = pn.Publish().Channel(decomposed).Message("hey").SignedURL()
+	assert.Nil(err)
+	assert.Contains(u, "/publish/demo/demo/0/cafe%CC%81/0/")
+}
+
+func TestIncludeInstanceAndRequestIdentifiers(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+
+	query := func() url.Values {
+		u, err := buildURL(newTimeBuilder(pn).opts)
+		assert.Nil(err)
+		q, err := url.ParseQuery(u.RawQuery)
+		assert.Nil(err)
+		return q
+	}
+
+	q := query()
+	assert.Equal("", q.Get("instanceid"))
+	assert.Equal("", q.Get("requestid"))
+
+	pn.Config.IncludeInstanceIdentifier = true
+	pn.Config.IncludeRequestIdentifier = true
+
+	q1, q2 := query(), query()
+	assert.NotEqual("", q1.Get("instanceid"))
+	assert.Equal(q1.Get("instanceid"), q2.Get("instanceid"))
+	assert.NotEqual("", q1.Get("requestid"))
+	assert.NotEqual(q1.Get("requestid"), q2.Get("requestid"))
+
+	other := NewPubNub(pn.Config)
+	u, err := buildURL(newTimeBuilder(other).opts)
+	assert.Nil(err)
+	assert.NotContains(u.RawQuery, "instanceid="+q1.Get("instanceid"))
+}
+
+func TestAuthTokenPrecedence(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+	pn.Config.AuthKey = "my-auth-key"
+
+	auth := func() string {
+		u, err := buildURL(newTimeBuilder(pn).opts)
+		assert.Nil(err)
+		q, err := url.ParseQuery(u.RawQuery)
+		assert.Nil(err)
+		return q.Get("auth")
+	}
+
+	assert.Equal("my-auth-key", auth())
+
+	pn.Config.SetAuthToken(" my-token ")
+	assert.Equal("my-token", auth())
+
+	pn.Config.SetAuthToken("")
+	assert.Equal("my-auth-key", auth())
+}
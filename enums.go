@@ -55,10 +55,18 @@ const (
 const (
 	// PNUserSpaceCustom is the enum equivalent to the value `custom` available User and Space include types
 	PNUserSpaceCustom PNUserSpaceInclude = 1 + iota
+	// PNUserMemberships is the enum equivalent to the value `memberships`, it expands the memberships of the User inline, only the User requests accept it
+	PNUserMemberships
+	// PNUserMembershipsSpace is the enum equivalent to the value `memberships.space`, it expands the space of the inline memberships, only the User requests accept it
+	PNUserMembershipsSpace
 )
 
 func (s PNUserSpaceInclude) String() string {
-	return [...]string{"custom"}[s-1]
+	names := [...]string{"custom", "memberships", "memberships.space"}
+	if s < 1 || int(s) > len(names) {
+		return fmt.Sprintf("PNUserSpaceInclude(%d)", int(s))
+	}
+	return names[s-1]
 }
 
 const (
@@ -68,10 +76,18 @@ const (
 	PNMembershipsSpace
 	// PNMembershipsSpaceCustom is the enum equivalent to the value `space.custom` available Memberships include types
 	PNMembershipsSpaceCustom
+	// PNMembershipsSpaceStatus is the enum equivalent to the value `space.status` available Memberships include types
+	PNMembershipsSpaceStatus
+	// PNMembershipsSpaceType is the enum equivalent to the value `space.type` available Memberships include types
+	PNMembershipsSpaceType
 )
 
 func (s PNMembershipsInclude) String() string {
-	return [...]string{"custom", "space", "space.custom"}[s-1]
+	names := [...]string{"custom", "space", "space.custom", "space.status", "space.type"}
+	if s < 1 || int(s) > len(names) {
+		return fmt.Sprintf("PNMembershipsInclude(%d)", int(s))
+	}
+	return names[s-1]
 }
 
 const (
@@ -81,10 +97,18 @@ const (
 	PNMembersUser
 	// PNMembersUserCustom is the enum equivalent to the value `user.custom` available Members include types
 	PNMembersUserCustom
+	// PNMembersUserStatus is the enum equivalent to the value `user.status` available Members include types
+	PNMembersUserStatus
+	// PNMembersUserType is the enum equivalent to the value `user.type` available Members include types
+	PNMembersUserType
 )
 
 func (s PNMembersInclude) String() string {
-	return [...]string{"custom", "user", "user.custom"}[s-1]
+	names := [...]string{"custom", "user", "user.custom", "user.status", "user.type"}
+	if s < 1 || int(s) > len(names) {
+		return fmt.Sprintf("PNMembersInclude(%d)", int(s))
+	}
+	return names[s-1]
 }
 
 // PNMessageType is used as an enum to catgorize the Subscribe response.
@@ -111,6 +135,16 @@ const (
 	PNMessageTypeActions
 )
 
+// PNLogVerbosity is used as an enum to select the detail of the request tracing written to Config.Log.
+type PNLogVerbosity int
+
+const (
+	// PNLogRequests logs the method, URL, status code and latency of each request.
+	PNLogRequests PNLogVerbosity = 1 + iota
+	// PNLogRequestsAndBodies additionally logs the response body of each request.
+	PNLogRequestsAndBodies
+)
+
 const (
 	// PNUnknownCategory as the StatusCategory means an unknown status category event occurred.
 	PNUnknownCategory StatusCategory = 1 + iota
@@ -139,9 +173,19 @@ const (
 	// to reconnect to the network were exhausted. All channels would be unsubscribed at this point.
 	// Applicable on for PNLinearPolicy and PNExponentialPolicy.
 	// Reconnection attempts are set in the config: MaximumReconnectionRetries.
+	// It is also sent with the PNSubscribeOperation when Config.SubscribeMaxReconnectionRetries consecutive subscribe requests failed,
+	// the subscribe loop is then stopped and the channels stay subscribed.
 	PNReconnectionAttemptsExhausted
 	// PNRequestMessageCountExceededCategory is fired when the MessageQueueOverflowCount limit is exceeded by the number of messages received in a single subscribe request
 	PNRequestMessageCountExceededCategory
+	// PNHeartbeatFailedCategory as the StatusCategory means that the heartbeat failed MaximumReconnectionRetries consecutive times
+	// and the presence of the client may be stale.
+	PNHeartbeatFailedCategory
+	// PNNetworkIssuesCategory as the StatusCategory means the subscribe request failed to reach the server.
+	PNNetworkIssuesCategory
+	// PNSubscribeSplitCategory as the StatusCategory means the channels were split into several subscribe requests
+	// to keep the URL length safe, the messages of all the requests are announced to the same listeners.
+	PNSubscribeSplitCategory
 )
 
 const (
@@ -336,6 +380,15 @@ func (c StatusCategory) String() string {
 	case PNNoStubMatchedCategory:
 		return "No Stub Matched"
 
+	case PNHeartbeatFailedCategory:
+		return "Heartbeat Failed"
+
+	case PNNetworkIssuesCategory:
+		return "Network Issues"
+
+	case PNSubscribeSplitCategory:
+		return "Subscribe Split"
+
 	default:
 		return "No Stub Matched"
 
@@ -413,6 +466,9 @@ func (t OperationType) String() string {
 	case PNDeleteMessagesOperation:
 		return "Delete messages"
 
+	case PNMessageCountsOperation:
+		return "Message Counts"
+
 	case PNSignalOperation:
 		return "Signal"
 
@@ -421,7 +477,7 @@ func (t OperationType) String() string {
 	case PNGetUsersOperation:
 		return "Get Users"
 	case PNGetUserOperation:
-		return "Fetch Users"
+		return "Get User"
 	case PNUpdateUserOperation:
 		return "Update User"
 	case PNDeleteUserOperation:
@@ -1,6 +1,7 @@
 package pubnub
 
 import (
+	"strconv"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -35,6 +36,8 @@ func TestStatusCategoryString(t *testing.T) {
 	assert.Equal("Reconnected", PNReconnectedCategory.String())
 	assert.Equal("Reconnection Attempts Exhausted", PNReconnectionAttemptsExhausted.String())
 	assert.Equal("No Stub Matched", PNNoStubMatchedCategory.String())
+	assert.Equal("Network Issues", PNNetworkIssuesCategory.String())
+	assert.Equal("Subscribe Split", PNSubscribeSplitCategory.String())
 }
 
 func TestOperationTypeString(t *testing.T) {
@@ -63,4 +66,20 @@ func TestOperationTypeString(t *testing.T) {
 	assert.Equal("Grant", PNAccessManagerGrant.String())
 	assert.Equal("Revoke", PNAccessManagerRevoke.String())
 	assert.Equal("Delete messages", PNDeleteMessagesOperation.String())
+	assert.Equal("Message Counts", PNMessageCountsOperation.String())
+	assert.Equal("Signal", PNSignalOperation.String())
+	assert.Equal("Get User", PNGetUserOperation.String())
+	assert.Equal("Grant Token", PNAccessManagerGrantToken.String())
+}
+
+func TestOperationTypeStringAllOperations(t *testing.T) {
+	assert := assert.New(t)
+
+	for op := PNSubscribeOperation; op <= PNAccessManagerGrantToken; op++ {
+		s := op.String()
+		assert.NotEmpty(s)
+		assert.NotEqual("No Category Matched", s, "operation %d", op)
+		_, err := strconv.Atoi(s)
+		assert.NotNil(err, "operation %d", op)
+	}
 }
@@ -1850,7 +1850,7 @@ func hereNowResponse(res *pubnub.HereNowResponse, status pubnub.StatusResponse,
 
 func hereNowRequest(args []string) {
 	if len(args) == 0 {
-		res, status, err := pn.HereNow().Execute()
+		res, status, err := pn.HereNow().Global(true).Execute()
 		hereNowResponse(res, status, err)
 		return
 	}
@@ -1888,7 +1888,7 @@ func hereNowRequest(args []string) {
 		res, status, err := pn.HereNow().ChannelGroups(channelGroups).IncludeState(includeState).IncludeUUIDs(includeUUIDs).Execute()
 		hereNowResponse(res, status, err)
 	} else {
-		res, status, err := pn.HereNow().IncludeState(includeState).IncludeUUIDs(includeUUIDs).Execute()
+		res, status, err := pn.HereNow().Global(true).IncludeState(includeState).IncludeUUIDs(includeUUIDs).Execute()
 		hereNowResponse(res, status, err)
 	}
 }
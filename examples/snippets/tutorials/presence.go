@@ -75,6 +75,7 @@ func globalHereNow() {
 	pn := pubnub.NewPubNub(config)
 
 	res, status, err := pn.HereNow().
+		Global(true).
 		IncludeState(true).
 		IncludeUUIDs(true).
 		Execute()
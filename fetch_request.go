@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"strconv"
+	"time"
 
 	"github.com/pubnub/go/pnerr"
 	"github.com/pubnub/go/utils"
@@ -84,12 +85,38 @@ func (b *fetchBuilder) QueryParam(queryParam map[string]string) *fetchBuilder {
 	return b
 }
 
+// Timeout sets the timeout for the Fetch request.
+func (b *fetchBuilder) Timeout(timeout time.Duration) *fetchBuilder {
+	b.opts.Timeout = timeout
+
+	return b
+}
+
+// RequestTimeout sets the timeout in seconds for the Fetch request.
+func (b *fetchBuilder) RequestTimeout(seconds int) *fetchBuilder {
+	b.opts.setRequestTimeout = true
+
+	return b.Timeout(time.Duration(seconds) * time.Second)
+}
+
+// ConnectTimeout sets the connect timeout in seconds for the Fetch request.
+func (b *fetchBuilder) ConnectTimeout(seconds int) *fetchBuilder {
+	b.opts.ConnectTimeout = seconds
+
+	return b
+}
+
 // Transport sets the Transport for the Fetch request.
 func (b *fetchBuilder) Transport(tr http.RoundTripper) *fetchBuilder {
 	b.opts.Transport = tr
 	return b
 }
 
+// SignedURL returns the URL of the Fetch request without executing it.
+func (b *fetchBuilder) SignedURL() (string, error) {
+	return signedURL(b.opts)
+}
+
 // Execute runs the Fetch request.
 func (b *fetchBuilder) Execute() (*FetchResponse, StatusResponse, error) {
 	rawJSON, status, err := executeRequest(b.opts)
@@ -117,10 +144,13 @@ type fetchOpts struct {
 	// default: false
 	IncludeTimetoken bool
 	QueryParam       map[string]string
+	Timeout          time.Duration
+	ConnectTimeout   int
 
 	// nil hacks
-	setStart bool
-	setEnd   bool
+	setRequestTimeout bool
+	setStart          bool
+	setEnd            bool
 
 	Transport http.RoundTripper
 
@@ -144,6 +174,10 @@ func (o *fetchOpts) validate() error {
 		return newValidationError(o, StrMissingSubKey)
 	}
 
+	if o.setRequestTimeout && o.Timeout <= 0 {
+		return newValidationError(o, fmt.Sprintf("%s %s: must be positive", StrInvalidTimeout, o.Timeout))
+	}
+
 	if len(o.Channels) <= 0 {
 		return newValidationError(o, StrMissingChannel)
 	}
@@ -152,7 +186,7 @@ func (o *fetchOpts) validate() error {
 }
 
 func (o *fetchOpts) buildPath() (string, error) {
-	channels := utils.JoinChannels(o.Channels)
+	channels := utils.JoinChannels(o.pubnub.Config.channelNames(o.Channels))
 
 	return fmt.Sprintf(fetchPath,
 		o.pubnub.Config.SubscribeKey,
@@ -160,7 +194,7 @@ func (o *fetchOpts) buildPath() (string, error) {
 }
 
 func (o *fetchOpts) buildQuery() (*url.Values, error) {
-	q := defaultQuery(o.pubnub.Config.UUID, o.pubnub.telemetryManager)
+	q := defaultQuery(o.pubnub.Config, o.pubnub.telemetryManager)
 
 	if o.setStart {
 		q.Set("start", strconv.FormatInt(o.Start, 10))
@@ -177,6 +211,7 @@ func (o *fetchOpts) buildQuery() (*url.Values, error) {
 	}
 
 	q.Set("reverse", strconv.FormatBool(o.Reverse))
+	o.pubnub.tokenManager.setAuthParamForChannels(q, o.Channels, nil)
 	SetQueryParam(q, o.QueryParam)
 
 	return q, nil
@@ -203,6 +238,9 @@ func (o *fetchOpts) requestTimeout() int {
 }
 
 func (o *fetchOpts) connectTimeout() int {
+	if o.ConnectTimeout != 0 {
+		return o.ConnectTimeout
+	}
 	return o.pubnub.Config.ConnectTimeout
 }
 
@@ -214,9 +252,23 @@ func (o *fetchOpts) telemetryManager() *TelemetryManager {
 	return o.pubnub.telemetryManager
 }
 
+func (o *fetchOpts) tokenManager() *TokenManager {
+	return o.pubnub.tokenManager
+}
+
+func (o *fetchOpts) timeout() time.Duration {
+	return o.Timeout
+}
+
+func (o *fetchOpts) transport() http.RoundTripper {
+	return o.Transport
+}
+
 // FetchResponse is the response to Fetch request. It contains a map of type FetchResponseItem
 type FetchResponse struct {
 	Messages map[string][]FetchResponseItem
+	// Region is the region of the timetokens, it is 0 when the server does not send it.
+	Region int
 }
 
 func (o *fetchOpts) fetchMessages(channels map[string]interface{}) map[string][]FetchResponseItem {
@@ -235,6 +287,7 @@ func (o *fetchOpts) fetchMessages(channels map[string]interface{}) map[string][]
 					histItem := FetchResponseItem{
 						Message:   msg,
 						Timetoken: histResponse["timetoken"].(string),
+						Region:    parseRegion(histResponse["region"]),
 					}
 					items[count] = histItem
 					o.pubnub.Config.Log.Printf("Channel:%s, count:%d %d\n", channel, count, len(items))
@@ -244,8 +297,9 @@ func (o *fetchOpts) fetchMessages(channels map[string]interface{}) map[string][]
 					continue
 				}
 			}
-			messages[channel] = items
-			o.pubnub.Config.Log.Printf("Channel:%s, count:%d\n", channel, len(messages[channel]))
+			decoded := utils.DecodeChannel(channel, o.Channels)
+			messages[decoded] = items
+			o.pubnub.Config.Log.Printf("Channel:%s, count:%d\n", decoded, len(messages[decoded]))
 		} else {
 			o.pubnub.Config.Log.Printf("histResponseSliceMap not an []interface %v\n", histResponseSliceMap)
 			continue
@@ -271,6 +325,7 @@ func newFetchResponse(jsonBytes []byte, o *fetchOpts,
 
 	if result, ok := value.(map[string]interface{}); ok {
 		o.pubnub.Config.Log.Println(result["channels"])
+		resp.Region = parseRegion(result["region"])
 		if channels, ok1 := result["channels"].(map[string]interface{}); ok1 {
 			if channels != nil {
 				resp.Messages = o.fetchMessages(channels)
@@ -289,4 +344,19 @@ func newFetchResponse(jsonBytes []byte, o *fetchOpts,
 type FetchResponseItem struct {
 	Message   interface{}
 	Timetoken string
+	// Region is the region of the Timetoken, it is 0 when the server does not send it.
+	Region int
+}
+
+// parseRegion returns the timetoken region of a decoded JSON value, or 0 when it is missing.
+func parseRegion(region interface{}) int {
+	switch r := region.(type) {
+	case float64:
+		return int(r)
+	case string:
+		i, _ := strconv.Atoi(r)
+		return i
+	}
+
+	return 0
 }
@@ -1,7 +1,9 @@
 package pubnub
 
 import (
+	"bytes"
 	"fmt"
+	"log"
 	"reflect"
 	"testing"
 
@@ -275,7 +277,7 @@ func TestFireValidateSubscribeKey(t *testing.T) {
 		pubnub:  pn,
 	}
 
-	assert.Equal("pubnub/validation: pubnub: \x06: Missing Subscribe Key", opts.validate().Error())
+	assert.Equal("pubnub/validation: pubnub: Fetch Messages: Missing Subscribe Key", opts.validate().Error())
 }
 
 func TestFireValidateCH(t *testing.T) {
@@ -285,7 +287,7 @@ func TestFireValidateCH(t *testing.T) {
 		Reverse: false,
 		pubnub:  pn,
 	}
-	assert.Equal("pubnub/validation: pubnub: \x06: Missing Channel", opts.validate().Error())
+	assert.Equal("pubnub/validation: pubnub: Fetch Messages: Missing Channel", opts.validate().Error())
 }
 
 func TestNewFetchResponseValueError(t *testing.T) {
@@ -300,3 +302,40 @@ func TestNewFetchResponseValueError(t *testing.T) {
 	_, _, err := newFetchResponse(jsonBytes, opts, StatusResponse{})
 	assert.Equal("pubnub/parsing: Error unmarshalling response: {s}", err.Error())
 }
+
+func TestFetchChannelWithComma(t *testing.T) {
+	assert := assert.New(t)
+	var buf bytes.Buffer
+	config := pubnub.Config.Copy()
+	config.Log = log.New(&buf, "", 0)
+
+	opts := &fetchOpts{
+		Channels: []string{"a,b", "c"},
+		pubnub:   NewPubNub(config),
+	}
+
+	path, err := opts.buildPath()
+	assert.Nil(err)
+	assert.Equal("/v3/history/sub-key/sub_key/channel/a%2Cb,c", path)
+
+	jsonString := []byte(`{"status": 200, "error": false, "error_message": "", "channels": {"a%2Cb":[{"message":"hey","timetoken":"15229448184080121"}],"c":[{"message":"yo","timetoken":"15229448086016618"}]}}`)
+
+	resp, _, err := newFetchResponse(jsonString, opts, fakeResponseState)
+	assert.Nil(err)
+	assert.Equal("hey", resp.Messages["a,b"][0].Message)
+	assert.Equal("yo", resp.Messages["c"][0].Message)
+	assert.Contains(buf.String(), "Channel:a,b, count:1\n")
+}
+
+func TestFetchResponseRegion(t *testing.T) {
+	assert := assert.New(t)
+
+	jsonString := []byte(`{"status": 200, "error": false, "error_message": "", "region": 12, "channels": {"test":[{"message":"hey","timetoken":"15229448184080121","region":12},{"message":"hey-2","timetoken":"15229448184080122"}]}}`)
+
+	resp, _, err := newFetchResponse(jsonString, initFetchOpts(""), fakeResponseState)
+	assert.Nil(err)
+	assert.Equal(12, resp.Region)
+	assert.Equal("15229448184080121", resp.Messages["test"][0].Timetoken)
+	assert.Equal(12, resp.Messages["test"][0].Region)
+	assert.Equal(0, resp.Messages["test"][1].Region)
+}
@@ -3,6 +3,7 @@ package pubnub
 import (
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/pubnub/go/pnerr"
 	"github.com/pubnub/go/utils"
@@ -25,6 +26,7 @@ type fireOpts struct {
 	Transport      http.RoundTripper
 	ctx            Context
 	QueryParam     map[string]string
+	Timeout        time.Duration
 	// nil hacks
 	setTTL         bool
 	setShouldStore bool
@@ -113,6 +115,18 @@ func (b *fireBuilder) QueryParam(queryParam map[string]string) *fireBuilder {
 	return b
 }
 
+// Timeout sets the timeout for the Fire request.
+func (b *fireBuilder) Timeout(timeout time.Duration) *fireBuilder {
+	b.opts.Timeout = timeout
+
+	return b
+}
+
+// SignedURL returns the URL of the Fire request without executing it, it returns an error with UsePost.
+func (b *fireBuilder) SignedURL() (string, error) {
+	return signedURL(b.opts)
+}
+
 // Execute runs the Fire request.
 func (b *fireBuilder) Execute() (*PublishResponse, StatusResponse, error) {
 	b.opts.ShouldStore = false
@@ -162,7 +176,7 @@ func (o *fireOpts) buildPath() (string, error) {
 		return fmt.Sprintf(publishPostPath,
 			o.pubnub.Config.PublishKey,
 			o.pubnub.Config.SubscribeKey,
-			utils.URLEncode(o.Channel),
+			utils.URLEncode(o.pubnub.Config.channelName(o.Channel)),
 			"0"), nil
 	}
 
@@ -170,7 +184,10 @@ func (o *fireOpts) buildPath() (string, error) {
 	var err error
 
 	if cipherKey := o.pubnub.Config.CipherKey; cipherKey != "" {
-		msg := utils.EncryptString(cipherKey, string(message))
+		msg, err := utils.EncryptStringWithIV(cipherKey, string(message), o.pubnub.Config.UseRandomInitializationVector)
+		if err != nil {
+			return "", err
+		}
 
 		o.Message = []byte(msg)
 	}
@@ -183,13 +200,13 @@ func (o *fireOpts) buildPath() (string, error) {
 	return fmt.Sprintf(publishGetPath,
 		o.pubnub.Config.PublishKey,
 		o.pubnub.Config.SubscribeKey,
-		utils.URLEncode(o.Channel),
+		utils.URLEncode(o.pubnub.Config.channelName(o.Channel)),
 		"0",
 		utils.URLEncode(string(message))), nil
 }
 
 func (o *fireOpts) buildQuery() (*url.Values, error) {
-	q := defaultQuery(o.pubnub.Config.UUID, o.pubnub.telemetryManager)
+	q := defaultQuery(o.pubnub.Config, o.pubnub.telemetryManager)
 
 	if o.Meta != nil {
 		meta, err := utils.ValueAsString(o.Meta)
@@ -210,6 +227,7 @@ func (o *fireOpts) buildQuery() (*url.Values, error) {
 	}
 
 	q.Set("seqn", strconv.Itoa(o.pubnub.getPublishSequence()))
+	o.pubnub.tokenManager.SetAuthParan(q, o.Channel, PNChannels)
 	SetQueryParam(q, o.QueryParam)
 
 	return q, nil
@@ -239,7 +257,10 @@ func (o *fireOpts) buildBody() ([]byte, error) {
 		}
 
 		if cipherKey := o.pubnub.Config.CipherKey; cipherKey != "" {
-			enc := utils.EncryptString(cipherKey, string(msg))
+			enc, err := utils.EncryptStringWithIV(cipherKey, string(msg), o.pubnub.Config.UseRandomInitializationVector)
+			if err != nil {
+				return []byte{}, err
+			}
 			msg, err := utils.ValueAsString(enc)
 			if err != nil {
 				return []byte{}, err
@@ -277,3 +298,15 @@ func (o *fireOpts) operationType() OperationType {
 func (o *fireOpts) telemetryManager() *TelemetryManager {
 	return o.pubnub.telemetryManager
 }
+
+func (o *fireOpts) tokenManager() *TokenManager {
+	return o.pubnub.tokenManager
+}
+
+func (o *fireOpts) timeout() time.Duration {
+	return o.Timeout
+}
+
+func (o *fireOpts) transport() http.RoundTripper {
+	return o.Transport
+}
@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/pubnub/go/pnerr"
 	"github.com/pubnub/go/utils"
@@ -65,6 +66,13 @@ func (b *getStateBuilder) QueryParam(queryParam map[string]string) *getStateBuil
 	return b
 }
 
+// Timeout sets the timeout for the Get State request.
+func (b *getStateBuilder) Timeout(timeout time.Duration) *getStateBuilder {
+	b.opts.Timeout = timeout
+
+	return b
+}
+
 // UUID sets the UUID for the Get State request.
 func (b *getStateBuilder) UUID(uuid string) *getStateBuilder {
 	b.opts.UUID = uuid
@@ -72,6 +80,15 @@ func (b *getStateBuilder) UUID(uuid string) *getStateBuilder {
 	return b
 }
 
+// UseCache serves the states from the cache of the PubNub instance when they were read less than Config.StateCacheTTL seconds ago,
+// the states read from the network are cached. The cache is only used for channels, not channel groups, and SetState invalidates it.
+// The states returned are copies, changing them doesn't change the cache.
+func (b *getStateBuilder) UseCache(useCache bool) *getStateBuilder {
+	b.opts.UseCache = useCache
+
+	return b
+}
+
 // Transport sets the Transport for the Get State request.
 func (b *getStateBuilder) Transport(
 	tr http.RoundTripper) *getStateBuilder {
@@ -81,15 +98,38 @@ func (b *getStateBuilder) Transport(
 	return b
 }
 
+// SignedURL returns the URL of the Get State request without executing it.
+func (b *getStateBuilder) SignedURL() (string, error) {
+	return signedURL(b.opts)
+}
+
 // Execute runs the the Get State request.
 func (b *getStateBuilder) Execute() (
 	*GetStateResponse, StatusResponse, error) {
+	useCache := b.opts.UseCache && len(b.opts.Channels) > 0 && len(b.opts.ChannelGroups) == 0
+	uuid := b.opts.uuid()
+	if useCache {
+		if states, ok := b.opts.pubnub.stateCache.get(b.opts.pubnub.Config.channelNames(b.opts.Channels), uuid); ok {
+			return &GetStateResponse{State: states, UUID: uuid}, StatusResponse{
+				Category:   PNAcknowledgmentCategory,
+				Operation:  PNGetStateOperation,
+				StatusCode: 200,
+				UUID:       uuid,
+			}, nil
+		}
+	}
+
 	rawJSON, status, err := executeRequest(b.opts)
 	if err != nil {
 		return emptyGetStateResp, status, err
 	}
 
-	return newGetStateResponse(rawJSON, status)
+	resp, status, err := newGetStateResponse(rawJSON, status)
+	if err == nil && useCache {
+		b.opts.pubnub.stateCache.set(resp.State, uuid, time.Duration(b.opts.pubnub.Config.StateCacheTTL)*time.Second)
+	}
+
+	return resp, status, err
 }
 
 type getStateOpts struct {
@@ -98,12 +138,22 @@ type getStateOpts struct {
 	ChannelGroups []string
 	UUID          string
 	QueryParam    map[string]string
+	Timeout       time.Duration
+	UseCache      bool
 
 	Transport http.RoundTripper
 
 	ctx Context
 }
 
+func (o *getStateOpts) uuid() string {
+	if o.UUID != "" {
+		return o.UUID
+	}
+
+	return o.pubnub.Config.UUID
+}
+
 func (o *getStateOpts) config() Config {
 	return *o.pubnub.Config
 }
@@ -131,23 +181,26 @@ func (o *getStateOpts) validate() error {
 func (o *getStateOpts) buildPath() (string, error) {
 	var channels []string
 
-	for _, channel := range o.Channels {
+	for _, channel := range o.pubnub.Config.channelNames(o.Channels) {
 		channels = append(channels, utils.PamEncode(channel))
 	}
 
-	uuid := o.UUID
-	if uuid == "" {
-		uuid = o.pubnub.Config.UUID
+	uuid := o.uuid()
+
+	// the channel segment is a single separator when only channel groups are set
+	channelsPath := strings.Join(channels, ",")
+	if channelsPath == "" {
+		channelsPath = ","
 	}
 
 	return fmt.Sprintf(getStatePath,
 		o.pubnub.Config.SubscribeKey,
-		strings.Join(channels, ","),
+		channelsPath,
 		utils.URLEncode(uuid)), nil
 }
 
 func (o *getStateOpts) buildQuery() (*url.Values, error) {
-	q := defaultQuery(o.pubnub.Config.UUID, o.pubnub.telemetryManager)
+	q := defaultQuery(o.pubnub.Config, o.pubnub.telemetryManager)
 
 	var groups []string
 
@@ -155,7 +208,10 @@ func (o *getStateOpts) buildQuery() (*url.Values, error) {
 		groups = append(groups, utils.PamEncode(group))
 	}
 
-	q.Set("channel-group", strings.Join(groups, ","))
+	if len(groups) > 0 {
+		q.Set("channel-group", strings.Join(groups, ","))
+	}
+	o.pubnub.tokenManager.setAuthParamForChannels(q, o.Channels, o.ChannelGroups)
 	SetQueryParam(q, o.QueryParam)
 
 	return q, nil
@@ -193,6 +249,18 @@ func (o *getStateOpts) telemetryManager() *TelemetryManager {
 	return o.pubnub.telemetryManager
 }
 
+func (o *getStateOpts) tokenManager() *TokenManager {
+	return o.pubnub.tokenManager
+}
+
+func (o *getStateOpts) timeout() time.Duration {
+	return o.Timeout
+}
+
+func (o *getStateOpts) transport() http.RoundTripper {
+	return o.Transport
+}
+
 // GetStateResponse is the struct returned when the Execute function of GetState is called.
 type GetStateResponse struct {
 	State map[string]interface{}
@@ -2,8 +2,11 @@ package pubnub
 
 import (
 	"fmt"
+	"net/http"
 	"net/url"
+	"strings"
 	"testing"
+	"time"
 
 	h "github.com/pubnub/go/tests/helpers"
 	"github.com/stretchr/testify/assert"
@@ -268,7 +271,7 @@ func TestGetStateValidateChannel(t *testing.T) {
 	opts := &getStateOpts{
 		pubnub: pn,
 	}
-	assert.Equal("pubnub/validation: pubnub: \v: Missing Channel or Channel Group", opts.validate().Error())
+	assert.Equal("pubnub/validation: pubnub: Get State: Missing Channel or Channel Group", opts.validate().Error())
 }
 
 func TestGetStateValidateSubscribeKey(t *testing.T) {
@@ -281,7 +284,7 @@ func TestGetStateValidateSubscribeKey(t *testing.T) {
 		pubnub:        pn,
 	}
 
-	assert.Equal("pubnub/validation: pubnub: \v: Missing Subscribe Key", opts.validate().Error())
+	assert.Equal("pubnub/validation: pubnub: Get State: Missing Subscribe Key", opts.validate().Error())
 }
 
 func TestNewGetStateResponseErrorUnmarshalling(t *testing.T) {
@@ -339,3 +342,141 @@ func TestNewGetStateResponseParsingChannelNull(t *testing.T) {
 	_, _, err := newGetStateResponse(jsonBytes, fakeResponseState)
 	assert.Equal("Response parsing channel 2", err.Error())
 }
+
+func TestGetStateTwoChannels(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+	pn.SetClient(&http.Client{Transport: newStubTransport(200, `{"status": 200, "message": "OK", "payload": {"channels": {"ch1": {"k": "v1"}, "ch2": {"k": "v2"}}}, "uuid": "my-custom-uuid", "service": "Presence"}`)})
+
+	res, _, err := pn.GetState().Channels([]string{"ch1", "ch2"}).UUID("my-custom-uuid").Execute()
+	assert.Nil(err)
+	assert.Equal("my-custom-uuid", res.UUID)
+	assert.Equal(2, len(res.State))
+	assert.Equal(map[string]interface{}{"k": "v1"}, res.State["ch1"])
+	assert.Equal(map[string]interface{}{"k": "v2"}, res.State["ch2"])
+
+	pn.SetClient(&http.Client{Transport: newStubTransport(200, `{"status": 200, "message": "OK", "payload": {"k": "v1"}, "uuid": "my-custom-uuid", "channel": "ch1", "service": "Presence"}`)})
+
+	res, _, err = pn.GetState().Channels([]string{"ch1"}).UUID("my-custom-uuid").Execute()
+	assert.Nil(err)
+	assert.Equal(map[string]interface{}{"ch1": map[string]interface{}{"k": "v1"}}, res.State)
+}
+
+func TestGetStateChannelGroup(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+	pn.Config.UUID = "my-uuid"
+	// the state set on the channel group is returned for each channel of the group
+	state := ""
+	tr := newTestTransport(func(req *http.Request) (*http.Response, error) {
+		if req.URL.Query().Get("channel-group") != "cg" {
+			return stubResponse(req, 200, `{"status":200,"message":"OK","service":"Presence"}`), nil
+		}
+		if s := req.URL.Query().Get("state"); s != "" {
+			state = s
+			return stubResponse(req, 200, fmt.Sprintf(`{"status":200,"message":"OK","payload":%s,"service":"Presence"}`, state)), nil
+		}
+		return stubResponse(req, 200, fmt.Sprintf(`{"status":200,"message":"OK","payload":{"channels":{"ch1":%s,"ch2":%s}},"uuid":"my-uuid","service":"Presence"}`, state, state)), nil
+	})
+	pn.SetClient(&http.Client{Transport: tr})
+
+	_, _, err := pn.SetState().ChannelGroups([]string{"cg"}).State(map[string]interface{}{"age": 20}).Execute()
+	assert.Nil(err)
+
+	res, _, err := pn.GetState().ChannelGroups([]string{"cg"}).Execute()
+	assert.Nil(err)
+	assert.Equal(2, len(res.State))
+	assert.Equal(float64(20), res.State["ch1"].(map[string]interface{})["age"])
+	assert.Equal(float64(20), res.State["ch2"].(map[string]interface{})["age"])
+
+	for _, req := range tr.requests {
+		assert.Contains(req.URL.Opaque, "/channel/,/uuid/my-uuid")
+	}
+}
+
+const ch1StateBody = `{"status": 200, "message": "OK", "payload": {"k": "v1"}, "uuid": "my-custom-uuid", "channel": "ch1", "service": "Presence"}`
+
+func TestGetStateUseCache(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+	transport := newStubTransport(200, ch1StateBody)
+	pn.SetClient(&http.Client{Transport: transport})
+
+	res, _, err := pn.GetState().Channels([]string{"ch1"}).UUID("my-custom-uuid").UseCache(true).Execute()
+	assert.Nil(err)
+	assert.Equal(1, transport.count())
+
+	res, status, err := pn.GetState().Channels([]string{"ch1"}).UUID("my-custom-uuid").UseCache(true).Execute()
+	assert.Nil(err)
+	assert.Equal(1, transport.count())
+	assert.Equal(200, status.StatusCode)
+	assert.Equal("my-custom-uuid", res.UUID)
+	assert.Equal(map[string]interface{}{"ch1": map[string]interface{}{"k": "v1"}}, res.State)
+
+	_, _, err = pn.GetState().Channels([]string{"ch1"}).UUID("my-custom-uuid").Execute()
+	assert.Nil(err)
+	assert.Equal(2, transport.count())
+
+	_, _, err = pn.GetState().Channels([]string{"ch1"}).UUID("other-uuid").UseCache(true).Execute()
+	assert.Nil(err)
+	assert.Equal(3, transport.count())
+
+	_, _, err = pn.SetState().Channels([]string{"ch1"}).UUID("my-custom-uuid").State(map[string]interface{}{"k": "v1"}).Execute()
+	assert.Nil(err)
+	assert.Equal(4, transport.count())
+
+	_, _, err = pn.GetState().Channels([]string{"ch1"}).UUID("my-custom-uuid").UseCache(true).Execute()
+	assert.Nil(err)
+	assert.Equal(5, transport.count())
+
+	pn.Config.StateCacheTTL = 0
+	pn.stateCache.invalidate(nil, "my-custom-uuid")
+	_, _, err = pn.GetState().Channels([]string{"ch1"}).UUID("my-custom-uuid").UseCache(true).Execute()
+	assert.Nil(err)
+	time.Sleep(time.Millisecond)
+	_, _, err = pn.GetState().Channels([]string{"ch1"}).UUID("my-custom-uuid").UseCache(true).Execute()
+	assert.Nil(err)
+	assert.Equal(7, transport.count())
+}
+
+func TestGetStateUseCacheReturnsCopies(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+	transport := newStubTransport(200, ch1StateBody)
+	pn.SetClient(&http.Client{Transport: transport})
+
+	res, _, err := pn.GetState().Channels([]string{"ch1"}).UUID("my-custom-uuid").UseCache(true).Execute()
+	assert.Nil(err)
+	res.State["ch1"].(map[string]interface{})["k"] = "changed"
+
+	res, _, err = pn.GetState().Channels([]string{"ch1"}).UUID("my-custom-uuid").UseCache(true).Execute()
+	assert.Nil(err)
+	assert.Equal(1, transport.count())
+	res.State["ch1"].(map[string]interface{})["k"] = "changed"
+
+	res, _, err = pn.GetState().Channels([]string{"ch1"}).UUID("my-custom-uuid").UseCache(true).Execute()
+	assert.Nil(err)
+	assert.Equal(1, transport.count())
+	assert.Equal(map[string]interface{}{"ch1": map[string]interface{}{"k": "v1"}}, res.State)
+}
+
+func TestSetStateInvalidatesCacheAfterRequest(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+	// the state read while the SetState request is in flight is the previous one
+	transport := newTestTransport(func(req *http.Request) (*http.Response, error) {
+		if strings.HasSuffix(req.URL.Opaque, "/data") {
+			_, _, err := pn.GetState().Channels([]string{"ch1"}).UUID("my-custom-uuid").UseCache(true).Execute()
+			assert.Nil(err)
+		}
+		return stubResponse(req, 200, ch1StateBody), nil
+	})
+	pn.SetClient(&http.Client{Transport: transport})
+	_, _, err := pn.SetState().Channels([]string{"ch1"}).UUID("my-custom-uuid").State(map[string]interface{}{"k": "v2"}).Execute()
+	assert.Nil(err)
+	assert.Equal(2, transport.count())
+
+	_, _, err = pn.GetState().Channels([]string{"ch1"}).UUID("my-custom-uuid").UseCache(true).Execute()
+	assert.Nil(err)
+	assert.Equal(3, transport.count())
+}
@@ -88,6 +88,97 @@ type ResourcePermissions struct {
 	Create bool
 }
 
+// ToGroupPermissions returns the channel group permissions, the groups only support read and manage
+// so an error is returned when write, delete or create is set.
+func (p ResourcePermissions) ToGroupPermissions() (GroupPermissions, error) {
+	if p.Write || p.Delete || p.Create {
+		return GroupPermissions{}, fmt.Errorf("%s: channel groups only support read and manage", StrInvalidGroupPermissions)
+	}
+	return GroupPermissions{Read: p.Read, Manage: p.Manage}, nil
+}
+
+// ResourcePermissionsBuilder builds the permissions of a grant, e.g.
+// NewResourcePermissions().Read().Write().ForChannels("ch1", "ch2").
+type ResourcePermissionsBuilder struct {
+	perms ResourcePermissions
+}
+
+// NewResourcePermissions returns a ResourcePermissionsBuilder without any permission.
+func NewResourcePermissions() *ResourcePermissionsBuilder {
+	return &ResourcePermissionsBuilder{}
+}
+
+// Read grants the read permission.
+func (b *ResourcePermissionsBuilder) Read() *ResourcePermissionsBuilder {
+	b.perms.Read = true
+	return b
+}
+
+// Write grants the write permission.
+func (b *ResourcePermissionsBuilder) Write() *ResourcePermissionsBuilder {
+	b.perms.Write = true
+	return b
+}
+
+// Manage grants the manage permission.
+func (b *ResourcePermissionsBuilder) Manage() *ResourcePermissionsBuilder {
+	b.perms.Manage = true
+	return b
+}
+
+// Delete grants the delete permission.
+func (b *ResourcePermissionsBuilder) Delete() *ResourcePermissionsBuilder {
+	b.perms.Delete = true
+	return b
+}
+
+// Create grants the create permission.
+func (b *ResourcePermissionsBuilder) Create() *ResourcePermissionsBuilder {
+	b.perms.Create = true
+	return b
+}
+
+// Get returns the permissions.
+func (b *ResourcePermissionsBuilder) Get() ResourcePermissions {
+	return b.perms
+}
+
+// ForChannels returns the permissions of the channels, the permissions which don't apply to channels are ignored.
+func (b *ResourcePermissionsBuilder) ForChannels(channels ...string) map[string]ChannelPermissions {
+	m := make(map[string]ChannelPermissions, len(channels))
+	for _, channel := range channels {
+		m[channel] = ChannelPermissions{Read: b.perms.Read, Write: b.perms.Write, Delete: b.perms.Delete}
+	}
+	return m
+}
+
+// ForChannelGroups returns the permissions of the channel groups, the permissions which don't apply to groups are ignored.
+func (b *ResourcePermissionsBuilder) ForChannelGroups(groups ...string) map[string]GroupPermissions {
+	m := make(map[string]GroupPermissions, len(groups))
+	for _, group := range groups {
+		m[group] = GroupPermissions{Read: b.perms.Read, Manage: b.perms.Manage}
+	}
+	return m
+}
+
+// ForUsers returns the permissions of the users.
+func (b *ResourcePermissionsBuilder) ForUsers(users ...string) map[string]UserSpacePermissions {
+	return b.forUserSpaces(users)
+}
+
+// ForSpaces returns the permissions of the spaces.
+func (b *ResourcePermissionsBuilder) ForSpaces(spaces ...string) map[string]UserSpacePermissions {
+	return b.forUserSpaces(spaces)
+}
+
+func (b *ResourcePermissionsBuilder) forUserSpaces(ids []string) map[string]UserSpacePermissions {
+	m := make(map[string]UserSpacePermissions, len(ids))
+	for _, id := range ids {
+		m[id] = UserSpacePermissions(b.perms)
+	}
+	return m
+}
+
 // PNPAMEntityData is the struct containing the access details of the channels.
 type PNPAMEntityData struct {
 	Name          string
@@ -237,6 +328,23 @@ func ParseGrantResources(res GrantResources, token string, timetoken int64, ttl
 	return &g
 }
 
+// parseTokenPermissions returns the resources and the patterns granted by the decoded token.
+func parseTokenPermissions(decoded PNGrantTokenDecoded, token string) GrantResourcesWithPermissions {
+	res := ParseGrantResources(decoded.Resources, token, decoded.Timestamp, decoded.TTL)
+	pat := ParseGrantResources(decoded.Patterns, token, decoded.Timestamp, decoded.TTL)
+
+	return GrantResourcesWithPermissions{
+		Channels:        res.Channels,
+		Groups:          res.Groups,
+		Users:           res.Users,
+		Spaces:          res.Spaces,
+		ChannelsPattern: pat.Channels,
+		GroupsPattern:   pat.Groups,
+		UsersPattern:    pat.Users,
+		SpacesPattern:   pat.Spaces,
+	}
+}
+
 // ChannelPermissionsWithToken is used for channels resource type permissions
 type ChannelPermissionsWithToken struct {
 	Permissions  ChannelPermissions
@@ -278,9 +386,10 @@ type GrantResourcesWithPermissions struct {
 
 // PermissionsBody is the struct used to decode the server response
 type PermissionsBody struct {
-	Resources GrantResources         `json:"resources"`
-	Patterns  GrantResources         `json:"patterns"`
-	Meta      map[string]interface{} `json:"meta"`
+	Resources      GrantResources         `json:"resources"`
+	Patterns       GrantResources         `json:"patterns"`
+	Meta           map[string]interface{} `json:"meta"`
+	AuthorizedUUID string                 `json:"authorized_uuid,omitempty"`
 }
 
 // GrantResources is the struct used to decode the server response
@@ -293,11 +402,12 @@ type GrantResources struct {
 
 // PNGrantTokenDecoded is the struct used to decode the server response
 type PNGrantTokenDecoded struct {
-	Resources GrantResources         `cbor:"res"`
-	Patterns  GrantResources         `cbor:"pat"`
-	Meta      map[string]interface{} `cbor:"meta"`
-	Signature []byte                 `cbor:"sig"`
-	Version   int                    `cbor:"v"`
-	Timestamp int64                  `cbor:"t"`
-	TTL       int                    `cbor:"ttl"`
+	Resources      GrantResources         `cbor:"res"`
+	Patterns       GrantResources         `cbor:"pat"`
+	Meta           map[string]interface{} `cbor:"meta"`
+	Signature      []byte                 `cbor:"sig"`
+	Version        int                    `cbor:"v"`
+	Timestamp      int64                  `cbor:"t"`
+	TTL            int                    `cbor:"ttl"`
+	AuthorizedUUID string                 `cbor:"uuid"`
 }
@@ -116,6 +116,18 @@ func (b *grantBuilder) QueryParam(queryParam map[string]string) *grantBuilder {
 	return b
 }
 
+// Timeout sets the timeout for the Grant request.
+func (b *grantBuilder) Timeout(timeout time.Duration) *grantBuilder {
+	b.opts.Timeout = timeout
+
+	return b
+}
+
+// SignedURL returns the URL of the Grant request without executing it.
+func (b *grantBuilder) SignedURL() (string, error) {
+	return signedURL(b.opts)
+}
+
 // Execute runs the Grant request.
 func (b *grantBuilder) Execute() (*GrantResponse, StatusResponse, error) {
 	rawJSON, status, err := executeRequest(b.opts)
@@ -134,6 +146,7 @@ type grantOpts struct {
 	Channels      []string
 	ChannelGroups []string
 	QueryParam    map[string]string
+	Timeout       time.Duration
 	Meta          map[string]interface{}
 
 	// Stringified permissions
@@ -174,7 +187,7 @@ func (o *grantOpts) validate() error {
 	}
 
 	if o.config().SecretKey == "" {
-		return newValidationError(o, StrMissingSecretKey)
+		return newValidationError(o, StrMissingSecretKeyPAM)
 	}
 
 	return nil
@@ -185,7 +198,7 @@ func (o *grantOpts) buildPath() (string, error) {
 }
 
 func (o *grantOpts) buildQuery() (*url.Values, error) {
-	q := defaultQuery(o.pubnub.Config.UUID, o.pubnub.telemetryManager)
+	q := defaultQuery(o.pubnub.Config, o.pubnub.telemetryManager)
 
 	if o.Read {
 		q.Set("r", "1")
@@ -268,6 +281,14 @@ func (o *grantOpts) telemetryManager() *TelemetryManager {
 	return o.pubnub.telemetryManager
 }
 
+func (o *grantOpts) tokenManager() *TokenManager {
+	return o.pubnub.tokenManager
+}
+
+func (o *grantOpts) timeout() time.Duration {
+	return o.Timeout
+}
+
 // GrantResponse is the struct returned when the Execute function of Grant is called.
 type GrantResponse struct {
 	Level        string
@@ -8,10 +8,25 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"regexp"
+	"sort"
+	"time"
 )
 
 const grantTokenPath = "/v3/pam/%s/grant"
 
+// grantTokenMaxMetaSize is the max size in bytes of the serialized meta embedded in the token.
+const grantTokenMaxMetaSize = 32 * 1024
+
+// grantTokenDefaultTTL is the TTL in minutes of the token when TTL is not set.
+const grantTokenDefaultTTL = 60
+
+// grantTokenMaxTTL is the max TTL in minutes of a token, it is used for TTL(0).
+const grantTokenMaxTTL = 43200
+
+// grantTokenMaxBodySize is the max size in bytes of the grant request body accepted by the server.
+const grantTokenMaxBodySize = 64 * 1024
+
 var emptyPNGrantTokenResponse *PNGrantTokenResponse
 
 type grantTokenBuilder struct {
@@ -39,13 +54,13 @@ func newGrantTokenBuilderWithContext(pubnub *PubNub, context Context) *grantToke
 	return &builder
 }
 
-// TTL in minutes for which granted permissions are valid.
+// TTL in minutes for which the token is valid.
 //
 // Min: 1
-// Max: 525600
-// Default: 1440
+// Max: 43200
+// Default: 60, used when TTL is not called
 //
-// Setting value to 0 will apply the grant indefinitely (forever grant).
+// Setting value to 0 requests the max TTL.
 func (b *grantTokenBuilder) TTL(ttl int) *grantTokenBuilder {
 	b.opts.TTL = ttl
 	b.opts.setTTL = true
@@ -60,20 +75,19 @@ func (b *grantTokenBuilder) AuthKeys(authKeys []string) *grantTokenBuilder {
 	return b
 }
 
-// Uncomment when PAMv3 is fully functional.
 // Channels sets the Channels for the Grant request.
-// func (b *grantTokenBuilder) Channels(channels map[string]ChannelPermissions) *grantTokenBuilder {
-// 	b.opts.Channels = channels
+func (b *grantTokenBuilder) Channels(channels map[string]ChannelPermissions) *grantTokenBuilder {
+	b.opts.Channels = channels
 
-// 	return b
-// }
+	return b
+}
 
-// // ChannelGroups sets the ChannelGroups for the Grant request.
-// func (b *grantTokenBuilder) ChannelGroups(groups map[string]GroupPermissions) *grantTokenBuilder {
-// 	b.opts.ChannelGroups = groups
+// ChannelGroups sets the ChannelGroups for the Grant request.
+func (b *grantTokenBuilder) ChannelGroups(groups map[string]GroupPermissions) *grantTokenBuilder {
+	b.opts.ChannelGroups = groups
 
-// 	return b
-// }
+	return b
+}
 
 // Users sets the Users for the Grant request.
 func (b *grantTokenBuilder) Users(users map[string]UserSpacePermissions) *grantTokenBuilder {
@@ -89,20 +103,19 @@ func (b *grantTokenBuilder) Spaces(spaces map[string]UserSpacePermissions) *gran
 	return b
 }
 
-// Uncomment when PAMv3 is fully functional.
-// // Channels sets the Channels for the Grant request.
-// func (b *grantTokenBuilder) ChannelsPattern(channels map[string]ChannelPermissions) *grantTokenBuilder {
-// 	b.opts.ChannelsPattern = channels
+// ChannelsPattern sets the Channels patterns for the Grant request.
+func (b *grantTokenBuilder) ChannelsPattern(channels map[string]ChannelPermissions) *grantTokenBuilder {
+	b.opts.ChannelsPattern = channels
 
-// 	return b
-// }
+	return b
+}
 
-// // ChannelGroups sets the ChannelGroups for the Grant request.
-// func (b *grantTokenBuilder) ChannelGroupsPattern(groups map[string]GroupPermissions) *grantTokenBuilder {
-// 	b.opts.ChannelGroupsPattern = groups
+// ChannelGroupsPattern sets the ChannelGroups patterns for the Grant request.
+func (b *grantTokenBuilder) ChannelGroupsPattern(groups map[string]GroupPermissions) *grantTokenBuilder {
+	b.opts.ChannelGroupsPattern = groups
 
-// 	return b
-// }
+	return b
+}
 
 // Users sets the Users for the Grant request.
 func (b *grantTokenBuilder) UsersPattern(users map[string]UserSpacePermissions) *grantTokenBuilder {
@@ -119,12 +132,22 @@ func (b *grantTokenBuilder) SpacesPattern(spaces map[string]UserSpacePermissions
 }
 
 // Meta sets the Meta for the Grant request.
+// The meta is embedded in the token and can be read back using ParseToken.
+// It must be JSON serializable and at most 32KB when serialized.
 func (b *grantTokenBuilder) Meta(meta map[string]interface{}) *grantTokenBuilder {
 	b.opts.Meta = meta
 
 	return b
 }
 
+// AuthorizedUUID binds the token to a single client, the token can only be used by the client with this UUID.
+func (b *grantTokenBuilder) AuthorizedUUID(uuid string) *grantTokenBuilder {
+	b.opts.AuthorizedUUID = uuid
+	b.opts.setAuthorizedUUID = true
+
+	return b
+}
+
 // QueryParam accepts a map, the keys and values of the map are passed as the query string parameters of the URL called by the API.
 func (b *grantTokenBuilder) QueryParam(queryParam map[string]string) *grantTokenBuilder {
 	b.opts.QueryParam = queryParam
@@ -132,16 +155,58 @@ func (b *grantTokenBuilder) QueryParam(queryParam map[string]string) *grantToken
 	return b
 }
 
+// Timeout sets the timeout for the GrantToken request.
+func (b *grantTokenBuilder) Timeout(timeout time.Duration) *grantTokenBuilder {
+	b.opts.Timeout = timeout
+
+	return b
+}
+
+// DryRun validates the Grant Token request without sending it, Execute then returns the body it would send in RequestBody.
+func (b *grantTokenBuilder) DryRun(dryRun bool) *grantTokenBuilder {
+	b.opts.DryRun = dryRun
+
+	return b
+}
+
+// SignedURL returns an error, the GrantToken request sends a body which the URL doesn't carry.
+func (b *grantTokenBuilder) SignedURL() (string, error) {
+	return signedURL(b.opts)
+}
+
 // Execute runs the Grant request.
 func (b *grantTokenBuilder) Execute() (*PNGrantTokenResponse, StatusResponse, error) {
+	if b.opts.DryRun {
+		return b.dryRun()
+	}
+
 	rawJSON, status, err := executeRequest(b.opts)
 	if err != nil {
-		return emptyPNGrantTokenResponse, status, err
+		return emptyPNGrantTokenResponse, status, newGrantTokenError(err)
 	}
 
 	return newGrantTokenResponse(b, rawJSON, status)
 }
 
+// dryRun validates and builds the request as executeRequest does, without sending it.
+func (b *grantTokenBuilder) dryRun() (*PNGrantTokenResponse, StatusResponse, error) {
+	status := StatusResponse{Operation: b.opts.operationType()}
+	if _, err := validateRequest(b.opts); err != nil {
+		return emptyPNGrantTokenResponse, status, newGrantTokenError(err)
+	}
+
+	body, err := buildBody(b.opts)
+	if err != nil {
+		return emptyPNGrantTokenResponse, status, newGrantTokenError(err)
+	}
+
+	if _, err := buildURLWithBody(b.opts, body); err != nil {
+		return emptyPNGrantTokenResponse, status, newGrantTokenError(err)
+	}
+
+	return &PNGrantTokenResponse{RequestBody: body}, status, nil
+}
+
 type grantTokenOpts struct {
 	pubnub *PubNub
 	ctx    Context
@@ -156,16 +221,20 @@ type grantTokenOpts struct {
 	SpacesPattern        map[string]UserSpacePermissions
 	UsersPattern         map[string]UserSpacePermissions
 	QueryParam           map[string]string
+	Timeout              time.Duration
 	Meta                 map[string]interface{}
+	AuthorizedUUID       string
+	DryRun               bool
 
-	// Max: 525600
+	// Max: 43200
 	// Min: 1
-	// Default: 1440
-	// Setting 0 will apply the grant indefinitely
+	// Default: 60
+	// Setting 0 requests the max TTL
 	TTL int
 
 	// nil hacks
-	setTTL bool
+	setTTL            bool
+	setAuthorizedUUID bool
 }
 
 func (o *grantTokenOpts) config() Config {
@@ -190,7 +259,63 @@ func (o *grantTokenOpts) validate() error {
 	}
 
 	if o.config().SecretKey == "" {
-		return newValidationError(o, StrMissingSecretKey)
+		return newValidationError(o, StrMissingSecretKeyPAM)
+	}
+
+	if o.setTTL && (o.TTL < 0 || o.TTL > grantTokenMaxTTL) {
+		return newValidationError(o, fmt.Sprintf("%s %d: must be between 0 and %d", StrInvalidTTL, o.TTL, grantTokenMaxTTL))
+	}
+
+	if o.setAuthorizedUUID && o.AuthorizedUUID == "" {
+		return newValidationError(o, StrMissingUUID)
+	}
+
+	if err := o.validatePatterns(); err != nil {
+		return err
+	}
+
+	if o.Meta != nil {
+		meta, err := json.Marshal(o.Meta)
+		if err != nil {
+			return newValidationError(o, fmt.Sprintf("%s: %s", StrInvalidMeta, err.Error()))
+		}
+		if len(meta) > grantTokenMaxMetaSize {
+			return newValidationError(o, StrMetaTooLarge)
+		}
+	}
+
+	body, err := o.buildBody()
+	if err != nil {
+		return newValidationError(o, err.Error())
+	}
+	if len(body) > grantTokenMaxBodySize {
+		return newValidationError(o, fmt.Sprintf("%s: %d bytes, max %d", StrGrantTooLarge, len(body), grantTokenMaxBodySize))
+	}
+
+	return nil
+}
+
+// validatePatterns returns a validation error naming the first pattern which doesn't compile as a regexp.
+func (o *grantTokenOpts) validatePatterns() error {
+	var patterns []string
+	for p := range o.ChannelsPattern {
+		patterns = append(patterns, p)
+	}
+	for p := range o.ChannelGroupsPattern {
+		patterns = append(patterns, p)
+	}
+	for p := range o.UsersPattern {
+		patterns = append(patterns, p)
+	}
+	for p := range o.SpacesPattern {
+		patterns = append(patterns, p)
+	}
+	sort.Strings(patterns)
+
+	for _, p := range patterns {
+		if _, err := regexp.Compile(p); err != nil {
+			return newValidationError(o, fmt.Sprintf("%s %s: %s", StrInvalidPattern, p, err.Error()))
+		}
 	}
 
 	return nil
@@ -274,7 +399,7 @@ func (o *grantTokenOpts) parseResourcePermissions(resource interface{}, resource
 }
 
 func (o *grantTokenOpts) buildQuery() (*url.Values, error) {
-	q := defaultQuery(o.pubnub.Config.UUID, o.pubnub.telemetryManager)
+	q := defaultQuery(o.pubnub.Config, o.pubnub.telemetryManager)
 
 	SetQueryParam(q, o.QueryParam)
 
@@ -306,15 +431,17 @@ func (o *grantTokenOpts) buildBody() ([]byte, error) {
 			Users:    o.parseResourcePermissions(o.UsersPattern, PNUsers),
 			Spaces:   o.parseResourcePermissions(o.SpacesPattern, PNSpaces),
 		},
-		Meta: meta,
+		Meta:           meta,
+		AuthorizedUUID: o.AuthorizedUUID,
 	}
 
 	o.pubnub.Config.Log.Println("permissions: ", permissions)
 
-	ttl := -1
+	ttl := grantTokenDefaultTTL
 	if o.setTTL {
-		if o.TTL >= -1 {
-			ttl = o.TTL
+		ttl = o.TTL
+		if ttl == 0 {
+			ttl = grantTokenMaxTTL
 		}
 	}
 
@@ -356,17 +483,74 @@ func (o *grantTokenOpts) telemetryManager() *TelemetryManager {
 	return o.pubnub.telemetryManager
 }
 
+func (o *grantTokenOpts) tokenManager() *TokenManager {
+	return o.pubnub.tokenManager
+}
+
+func (o *grantTokenOpts) timeout() time.Duration {
+	return o.Timeout
+}
+
 // PNGrantTokenData is the struct used to decode the server response
 type PNGrantTokenData struct {
 	Message string `json:"message"`
 	Token   string `json:"token"`
 }
 
+// PNGrantTokenError is the error returned by Grant Token when the server responds with an error body.
+// Details name the invalid fields of the request, e.g. the location ttl for a TTL out of range,
+// it is usually empty for a 403 auth failure.
+type PNGrantTokenError struct {
+	*pnerr.ServerError
+	Message string
+	Source  string
+	Details []PNGrantTokenErrorDetails
+}
+
+// PNGrantTokenErrorDetails names the request field which failed the server validation.
+type PNGrantTokenErrorDetails struct {
+	Message      string `json:"message"`
+	Location     string `json:"location"`
+	LocationType string `json:"locationType"`
+}
+
+// newGrantTokenError parses the error body of a *pnerr.ServerError into a *PNGrantTokenError,
+// other errors and bodies without an error envelope are returned as is.
+func newGrantTokenError(err error) error {
+	e, ok := err.(*pnerr.ServerError)
+	if !ok {
+		return err
+	}
+
+	var envelope struct {
+		Error *struct {
+			Message string                     `json:"message"`
+			Source  string                     `json:"source"`
+			Details []PNGrantTokenErrorDetails `json:"details"`
+		} `json:"error"`
+	}
+	if json.Unmarshal(e.Body, &envelope) != nil || envelope.Error == nil {
+		return err
+	}
+
+	return &PNGrantTokenError{
+		ServerError: e,
+		Message:     envelope.Error.Message,
+		Source:      envelope.Error.Source,
+		Details:     envelope.Error.Details,
+	}
+}
+
 // PNGrantTokenResponse is the struct returned when the Execute function of Grant Token is called.
+// Permissions and TTL are decoded from the Token and echo what the server granted.
 type PNGrantTokenResponse struct {
-	status  int              `json:"status"`
-	Data    PNGrantTokenData `json:"data"`
-	service string           `json:"service"`
+	status      int                           `json:"status"`
+	Data        PNGrantTokenData              `json:"data"`
+	service     string                        `json:"service"`
+	Permissions GrantResourcesWithPermissions `json:"-"`
+	TTL         int                           `json:"-"`
+	// RequestBody is the body the request would send, it is only set by a DryRun.
+	RequestBody []byte `json:"-"`
 }
 
 func newGrantTokenResponse(b *grantTokenBuilder, jsonBytes []byte, status StatusResponse) (*PNGrantTokenResponse, StatusResponse, error) {
@@ -380,7 +564,112 @@ func newGrantTokenResponse(b *grantTokenBuilder, jsonBytes []byte, status Status
 		return emptyPNGrantTokenResponse, status, e
 	}
 
+	if decoded, err := GetPermissions(resp.Data.Token); err == nil {
+		resp.Permissions = parseTokenPermissions(decoded, resp.Data.Token)
+		resp.TTL = decoded.TTL
+	} else {
+		b.opts.pubnub.Config.Log.Println("Error decoding the granted token", err)
+	}
+
 	b.opts.pubnub.tokenManager.StoreToken(resp.Data.Token)
 
 	return resp, status, nil
 }
+
+// PNSecureObjectsResponse is the response of SecureObjectsAndSubscribe, Token authorizes the Objects requests
+// of the user and the space, Grant is the v2 grant of the auth key on their channels.
+type PNSecureObjectsResponse struct {
+	Token string
+	Grant *GrantResponse
+}
+
+// secureObjectsAndSubscribe grants the token of the Objects requests on the user and the space,
+// then the v2 read and write of the auth key on the channels named by their ids, used by subscribe.
+func secureObjectsAndSubscribe(pn *PubNub, userID, spaceID, authKey string) (*PNSecureObjectsResponse, error) {
+	all := UserSpacePermissions{Read: true, Write: true, Manage: true, Delete: true, Create: true}
+
+	token, _, err := pn.GrantToken().
+		Users(map[string]UserSpacePermissions{userID: all}).
+		Spaces(map[string]UserSpacePermissions{spaceID: all}).
+		Execute()
+	if err != nil {
+		return nil, err
+	}
+
+	grant, _, err := pn.Grant().
+		Channels([]string{userID, spaceID}).
+		AuthKeys([]string{authKey}).
+		Read(true).
+		Write(true).
+		Execute()
+	if err != nil {
+		return nil, err
+	}
+
+	return &PNSecureObjectsResponse{
+		Token: token.Data.Token,
+		Grant: grant,
+	}, nil
+}
+
+// renewToken grants a new token with the resources, patterns, meta and authorized UUID of token
+// for the TTL of token, counted from now.
+func renewToken(pn *PubNub, token string) (string, error) {
+	b := newGrantTokenBuilder(pn)
+	if pn.Config.SecretKey == "" {
+		return "", newValidationError(b.opts, StrMissingSecretKeyPAM)
+	}
+
+	decoded, err := GetPermissions(token)
+	if err != nil {
+		return "", err
+	}
+	permissions := parseTokenPermissions(decoded, token)
+
+	b.TTL(decoded.TTL).
+		Channels(channelPermissionsOf(permissions.Channels)).
+		ChannelGroups(groupPermissionsOf(permissions.Groups)).
+		Users(userSpacePermissionsOf(permissions.Users)).
+		Spaces(userSpacePermissionsOf(permissions.Spaces)).
+		ChannelsPattern(channelPermissionsOf(permissions.ChannelsPattern)).
+		ChannelGroupsPattern(groupPermissionsOf(permissions.GroupsPattern)).
+		UsersPattern(userSpacePermissionsOf(permissions.UsersPattern)).
+		SpacesPattern(userSpacePermissionsOf(permissions.SpacesPattern))
+	if decoded.Meta != nil {
+		b.Meta(decoded.Meta)
+	}
+	if decoded.AuthorizedUUID != "" {
+		b.AuthorizedUUID(decoded.AuthorizedUUID)
+	}
+
+	res, _, err := b.Execute()
+	if err != nil {
+		return "", err
+	}
+
+	return res.Data.Token, nil
+}
+
+func channelPermissionsOf(tokens map[string]ChannelPermissionsWithToken) map[string]ChannelPermissions {
+	permissions := make(map[string]ChannelPermissions, len(tokens))
+	for k, v := range tokens {
+		permissions[k] = v.Permissions
+	}
+	return permissions
+}
+
+func groupPermissionsOf(tokens map[string]GroupPermissionsWithToken) map[string]GroupPermissions {
+	permissions := make(map[string]GroupPermissions, len(tokens))
+	for k, v := range tokens {
+		permissions[k] = v.Permissions
+	}
+	return permissions
+}
+
+func userSpacePermissionsOf(tokens map[string]UserSpacePermissionsWithToken) map[string]UserSpacePermissions {
+	permissions := make(map[string]UserSpacePermissions, len(tokens))
+	for k, v := range tokens {
+		permissions[k] = v.Permissions
+	}
+	return permissions
+}
@@ -1,9 +1,15 @@
 package pubnub
 
 import (
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"strings"
 	"testing"
 
+	cbor "github.com/brianolson/cbor_go"
+	"github.com/pubnub/go/pnerr"
 	h "github.com/pubnub/go/tests/helpers"
 	"github.com/stretchr/testify/assert"
 )
@@ -189,3 +195,448 @@ func AssertTestGrantToken(t *testing.T, checkQueryParam, testContext bool) {
 		assert.Equal("v2", u.Get("q2"))
 	}
 }
+
+func TestGrantTokenMeta(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+
+	o := newGrantTokenBuilder(pn)
+	o.TTL(100)
+	o.Meta(map[string]interface{}{
+		"m1": "v1",
+	})
+
+	assert.Nil(o.opts.validate())
+
+	body, err := o.opts.buildBody()
+	assert.Nil(err)
+
+	expectedBody := "{\"ttl\":100,\"permissions\":{\"resources\":{\"channels\":{},\"groups\":{},\"users\":{},\"spaces\":{}},\"patterns\":{\"channels\":{},\"groups\":{},\"users\":{},\"spaces\":{}},\"meta\":{\"m1\":\"v1\"}}}"
+	assert.Equal(expectedBody, string(body))
+
+	tokenBytes, err := cbor.Dumps(PNGrantTokenDecoded{
+		Meta:      map[string]interface{}{"m1": "v1"},
+		Version:   2,
+		Timestamp: 1568805412,
+		TTL:       100,
+	})
+	assert.Nil(err)
+
+	token := base64.URLEncoding.EncodeToString(tokenBytes)
+	decoded, err := pn.ParseToken(token)
+	assert.Nil(err)
+	assert.Equal("v1", decoded.Meta["m1"])
+	assert.Equal(100, decoded.TTL)
+}
+
+func TestGrantTokenMetaValidation(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+
+	o := newGrantTokenBuilder(pn)
+	o.Meta(map[string]interface{}{
+		"m1": make(chan int),
+	})
+	assert.Contains(o.opts.validate().Error(), StrInvalidMeta)
+
+	o.Meta(map[string]interface{}{
+		"m1": strings.Repeat("a", grantTokenMaxMetaSize),
+	})
+	assert.Contains(o.opts.validate().Error(), StrMetaTooLarge)
+}
+
+func TestGrantTokenAuthorizedUUID(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+
+	o := newGrantTokenBuilder(pn)
+	o.TTL(100)
+	o.AuthorizedUUID("authorized-client")
+
+	assert.Nil(o.opts.validate())
+
+	body, err := o.opts.buildBody()
+	assert.Nil(err)
+
+	expectedBody := "{\"ttl\":100,\"permissions\":{\"resources\":{\"channels\":{},\"groups\":{},\"users\":{},\"spaces\":{}},\"patterns\":{\"channels\":{},\"groups\":{},\"users\":{},\"spaces\":{}},\"meta\":{},\"authorized_uuid\":\"authorized-client\"}}"
+	assert.Equal(expectedBody, string(body))
+
+	tokenBytes, err := cbor.Dumps(PNGrantTokenDecoded{
+		Version:        2,
+		Timestamp:      1568805412,
+		TTL:            100,
+		AuthorizedUUID: "authorized-client",
+	})
+	assert.Nil(err)
+
+	decoded, err := pn.ParseToken(base64.URLEncoding.EncodeToString(tokenBytes))
+	assert.Nil(err)
+	assert.Equal("authorized-client", decoded.AuthorizedUUID)
+}
+
+func TestGrantTokenAuthorizedUUIDValidation(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+
+	o := newGrantTokenBuilder(pn)
+	o.AuthorizedUUID("")
+	assert.Contains(o.opts.validate().Error(), StrMissingUUID)
+}
+
+func TestGrantTokenValidateSecretKey(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+	pn.Config.SecretKey = ""
+
+	o := newGrantTokenBuilder(pn)
+	o.TTL(10)
+	assert.Equal("pubnub/validation: pubnub: Grant Token: Secret Key is required for PAM operations", o.opts.validate().Error())
+}
+
+func TestGrantTokenResponsePermissions(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+
+	users := map[string]UserSpacePermissions{
+		"user1": UserSpacePermissions{Read: true, Write: true},
+	}
+	spacesPattern := map[string]UserSpacePermissions{
+		"^space-.*": UserSpacePermissions{Read: true, Manage: true, Delete: true},
+	}
+	o := newGrantTokenBuilder(pn)
+	o.TTL(60).Users(users).SpacesPattern(spacesPattern)
+
+	// the server encodes the requested permissions in the token
+	body, err := o.opts.buildBody()
+	assert.Nil(err)
+	var request struct {
+		TTL         int             `json:"ttl"`
+		Permissions PermissionsBody `json:"permissions"`
+	}
+	assert.Nil(json.Unmarshal(body, &request))
+	tokenBytes, err := cbor.Dumps(PNGrantTokenDecoded{
+		Resources: request.Permissions.Resources,
+		Patterns:  request.Permissions.Patterns,
+		Version:   2,
+		Timestamp: 1568805412,
+		TTL:       request.TTL,
+	})
+	assert.Nil(err)
+	token := base64.URLEncoding.EncodeToString(tokenBytes)
+
+	pn.SetClient(&http.Client{Transport: newStubTransport(200, fmt.Sprintf(`{"status":200,"data":{"message":"Success","token":"%s"},"service":"Access Manager"}`, token))})
+
+	resp, _, err := o.Execute()
+	assert.Nil(err)
+	assert.Equal(token, resp.Data.Token)
+	assert.Equal(60, resp.TTL)
+
+	assert.Equal(1, len(resp.Permissions.Users))
+	assert.Equal(users["user1"], resp.Permissions.Users["user1"].Permissions)
+	assert.Equal(60, resp.Permissions.Users["user1"].TTL)
+	assert.Equal(int64(PNRead|PNWrite), resp.Permissions.Users["user1"].BitMaskPerms)
+
+	assert.Equal(1, len(resp.Permissions.SpacesPattern))
+	assert.Equal(spacesPattern["^space-.*"], resp.Permissions.SpacesPattern["^space-.*"].Permissions)
+	assert.Equal(0, len(resp.Permissions.Spaces))
+	assert.Equal(0, len(resp.Permissions.Channels))
+}
+
+func TestGrantTokenMixedResources(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+
+	perms := UserSpacePermissions{Read: true, Write: true}
+	channels := map[string]ChannelPermissions{"ch": ChannelPermissions{Read: true}}
+	groups := map[string]GroupPermissions{"cg": GroupPermissions{Read: true, Manage: true}}
+	o := newGrantTokenBuilder(pn)
+	o.TTL(60).
+		Users(map[string]UserSpacePermissions{"user1": perms}).
+		Spaces(map[string]UserSpacePermissions{"space1": perms}).
+		Channels(channels).
+		ChannelGroups(groups).
+		UsersPattern(map[string]UserSpacePermissions{"^user-.*": perms}).
+		SpacesPattern(map[string]UserSpacePermissions{"^space-.*": perms}).
+		ChannelsPattern(map[string]ChannelPermissions{"^ch-.*": ChannelPermissions{Write: true}})
+
+	body, err := o.opts.buildBody()
+	assert.Nil(err)
+	var request struct {
+		TTL         int             `json:"ttl"`
+		Permissions PermissionsBody `json:"permissions"`
+	}
+	assert.Nil(json.Unmarshal(body, &request))
+	tokenBytes, err := cbor.Dumps(PNGrantTokenDecoded{
+		Resources: request.Permissions.Resources,
+		Patterns:  request.Permissions.Patterns,
+		Version:   2,
+		Timestamp: 1568805412,
+		TTL:       request.TTL,
+	})
+	assert.Nil(err)
+	token := base64.URLEncoding.EncodeToString(tokenBytes)
+
+	tr := newStubTransport(200, fmt.Sprintf(`{"status":200,"data":{"message":"Success","token":"%s"},"service":"Access Manager"}`, token))
+	pn.SetClient(&http.Client{Transport: tr})
+
+	resp, _, err := o.Execute()
+	assert.Nil(err)
+	assert.Equal(1, tr.count())
+	assert.Equal(token, resp.Data.Token)
+	assert.Equal(perms, resp.Permissions.Users["user1"].Permissions)
+	assert.Equal(perms, resp.Permissions.Spaces["space1"].Permissions)
+	assert.Equal(channels["ch"], resp.Permissions.Channels["ch"].Permissions)
+	assert.Equal(groups["cg"], resp.Permissions.Groups["cg"].Permissions)
+	assert.Equal(perms, resp.Permissions.UsersPattern["^user-.*"].Permissions)
+	assert.Equal(perms, resp.Permissions.SpacesPattern["^space-.*"].Permissions)
+	assert.True(resp.Permissions.ChannelsPattern["^ch-.*"].Permissions.Write)
+}
+
+func TestGrantTokenValidateBodySize(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+
+	users := map[string]UserSpacePermissions{}
+	for i := 0; i < 5000; i++ {
+		users[fmt.Sprintf("user-%d", i)] = UserSpacePermissions{Read: true}
+	}
+	o := newGrantTokenBuilder(pn)
+	o.TTL(10).Users(users)
+	assert.Contains(o.opts.validate().Error(), StrGrantTooLarge)
+}
+
+func TestGrantTokenTTL(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+
+	ttl := func(o *grantTokenBuilder) int {
+		body, err := o.opts.buildBody()
+		assert.Nil(err)
+		var request struct {
+			TTL int `json:"ttl"`
+		}
+		assert.Nil(json.Unmarshal(body, &request))
+		return request.TTL
+	}
+
+	assert.Equal(grantTokenDefaultTTL, ttl(newGrantTokenBuilder(pn)))
+	assert.Equal(15, ttl(newGrantTokenBuilder(pn).TTL(15)))
+	assert.Equal(grantTokenMaxTTL, ttl(newGrantTokenBuilder(pn).TTL(0)))
+
+	assert.Nil(newGrantTokenBuilder(pn).TTL(0).opts.validate())
+	assert.Equal("pubnub/validation: pubnub: Grant Token: Invalid TTL -1: must be between 0 and 43200",
+		newGrantTokenBuilder(pn).TTL(-1).opts.validate().Error())
+	assert.Contains(newGrantTokenBuilder(pn).TTL(grantTokenMaxTTL+1).opts.validate().Error(), StrInvalidTTL)
+}
+
+func TestGrantTokenError(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+
+	grantTokenError := func(statusCode int, body string) *PNGrantTokenError {
+		pn.SetClient(&http.Client{Transport: newStubTransport(statusCode, body)})
+		_, _, err := pn.GrantToken().TTL(10).Execute()
+		e, ok := err.(*PNGrantTokenError)
+		assert.True(ok)
+		return e
+	}
+
+	e := grantTokenError(400, `{"status":400,"error":{"message":"Invalid resource name","source":"grant","details":[{"message":"Channel name is not valid","location":"permissions.resources.channels.ch,1","locationType":"body"}]},"service":"Access Manager"}`)
+	assert.Equal(400, e.StatusCode)
+	assert.Equal("Invalid resource name", e.Message)
+	assert.Equal("grant", e.Source)
+	assert.Equal("permissions.resources.channels.ch,1", e.Details[0].Location)
+
+	e = grantTokenError(400, `{"status":400,"error":{"message":"Invalid ttl","source":"grant","details":[{"message":"Must be between 1 and 43200","location":"ttl","locationType":"body"}]},"service":"Access Manager"}`)
+	assert.Equal("ttl", e.Details[0].Location)
+	assert.Equal("body", e.Details[0].LocationType)
+	assert.Equal("Must be between 1 and 43200", e.Details[0].Message)
+
+	e = grantTokenError(403, `{"status":403,"error":{"message":"Signature mismatch","source":"authz"},"service":"Access Manager"}`)
+	assert.Equal(403, e.StatusCode)
+	assert.Equal("Signature mismatch", e.Message)
+	assert.Equal("authz", e.Source)
+	assert.Empty(e.Details)
+
+	pn.SetClient(&http.Client{Transport: newStubTransport(500, `Internal Server Error`)})
+	_, _, err := pn.GrantToken().TTL(10).Execute()
+	_, ok := err.(*pnerr.ServerError)
+	assert.True(ok)
+}
+
+func TestGrantTokenResourcePermissionsBuilder(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+
+	perms := UserSpacePermissions{Read: true, Write: true, Manage: true, Delete: true, Create: true}
+	maps := newGrantTokenBuilder(pn).TTL(10).
+		Users(map[string]UserSpacePermissions{"user1": perms, "user2": perms}).
+		Spaces(map[string]UserSpacePermissions{"space1": UserSpacePermissions{Read: true}}).
+		Channels(map[string]ChannelPermissions{"ch": ChannelPermissions{Read: true, Write: true}}).
+		ChannelGroups(map[string]GroupPermissions{"cg": GroupPermissions{Read: true, Manage: true}})
+
+	all := NewResourcePermissions().Read().Write().Manage().Delete().Create()
+	fluent := newGrantTokenBuilder(pn).TTL(10).
+		Users(all.ForUsers("user1", "user2")).
+		Spaces(NewResourcePermissions().Read().ForSpaces("space1")).
+		Channels(NewResourcePermissions().Read().Write().ForChannels("ch")).
+		ChannelGroups(NewResourcePermissions().Read().Manage().ForChannelGroups("cg"))
+
+	mapsBody, err := maps.opts.buildBody()
+	assert.Nil(err)
+	fluentBody, err := fluent.opts.buildBody()
+	assert.Nil(err)
+	assert.Equal(string(mapsBody), string(fluentBody))
+
+	assert.Equal(ResourcePermissions{Read: true, Write: true, Manage: true, Delete: true, Create: true}, all.Get())
+}
+
+func TestGrantTokenChannelGroups(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+
+	o := newGrantTokenBuilder(pn).TTL(10).
+		ChannelGroups(map[string]GroupPermissions{"cg": GroupPermissions{Read: true, Manage: true}, "cg2": GroupPermissions{Read: true}}).
+		ChannelGroupsPattern(map[string]GroupPermissions{"cg-.*": GroupPermissions{Manage: true}})
+
+	body, err := o.opts.buildBody()
+	assert.Nil(err)
+	assert.Contains(string(body), `"resources":{"channels":{},"groups":{"cg":5,"cg2":1}`)
+	assert.Contains(string(body), `"patterns":{"channels":{},"groups":{"cg-.*":4}`)
+	assert.Equal(GroupPermissions{Read: true, Manage: true}, parseGrantPerms(5, PNGroups))
+
+	perms, err := NewResourcePermissions().Read().Manage().Get().ToGroupPermissions()
+	assert.Nil(err)
+	assert.Equal(GroupPermissions{Read: true, Manage: true}, perms)
+
+	for _, p := range []*ResourcePermissionsBuilder{NewResourcePermissions().Read().Write(), NewResourcePermissions().Delete(), NewResourcePermissions().Create()} {
+		_, err = p.Get().ToGroupPermissions()
+		assert.Equal("Invalid Group Permissions: channel groups only support read and manage", err.Error())
+	}
+}
+
+func TestGrantTokenValidatePatterns(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+
+	o := newGrantTokenBuilder(pn).
+		ChannelsPattern(map[string]ChannelPermissions{"^ch-.*": ChannelPermissions{Read: true}}).
+		UsersPattern(map[string]UserSpacePermissions{"^user-[0-9]+$": UserSpacePermissions{Read: true}})
+	assert.Nil(o.opts.validate())
+
+	o.SpacesPattern(map[string]UserSpacePermissions{"^space-[a-z": UserSpacePermissions{Read: true}})
+	assert.Equal("pubnub/validation: pubnub: Grant Token: Invalid Pattern ^space-[a-z: error parsing regexp: missing closing ]: `[a-z`", o.opts.validate().Error())
+}
+
+func TestSecureObjectsAndSubscribe(t *testing.T) {
+	assert := assert.New(t)
+	transport := newTestTransport(func(req *http.Request) (*http.Response, error) {
+		if strings.Contains(req.URL.Opaque, "/v2/auth/grant/") {
+			return stubResponse(req, 200, `{"message":"Success","payload":{"level":"user","subscribe_key":"demo","ttl":1440,"channels":{"user1":{"auths":{"my-auth":{"r":1,"w":1,"m":0,"d":0}}},"space1":{"auths":{"my-auth":{"r":1,"w":1,"m":0,"d":0}}}}},"service":"Access Manager","status":200}`), nil
+		}
+		return stubResponse(req, 200, `{"status":200,"data":{"message":"Success","token":"p0F2AkF0"},"service":"Access Manager"}`), nil
+	})
+	pn := NewPubNub(NewDemoConfig())
+	pn.SetClient(&http.Client{Transport: transport})
+
+	res, err := pn.SecureObjectsAndSubscribe("user1", "space1", "my-auth")
+	assert.Nil(err)
+	assert.Equal("p0F2AkF0", res.Token)
+	assert.Equal(2, len(res.Grant.Channels))
+
+	assert.Equal(2, transport.count())
+	assert.Contains(transport.requests[0].URL.Opaque, "/v3/pam/demo/grant")
+	assert.Contains(transport.requests[1].URL.Opaque, "/v2/auth/grant/sub-key/demo")
+	query := transport.requests[1].URL.RawQuery
+	assert.Contains(query, "auth=my-auth")
+	assert.Contains(query, "channel=user1")
+	assert.Contains(query, "r=1")
+	assert.Contains(query, "w=1")
+
+	pn.Config.SecretKey = ""
+	_, err = pn.SecureObjectsAndSubscribe("user1", "space1", "my-auth")
+	assert.Contains(err.Error(), StrMissingSecretKeyPAM)
+}
+
+func TestRenewToken(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+
+	old := PNGrantTokenDecoded{
+		Resources: GrantResources{
+			Channels: map[string]int64{"ch1": 3},
+			Groups:   map[string]int64{"cg1": 5},
+			Users:    map[string]int64{"user1": 31},
+			Spaces:   map[string]int64{"space1": 1},
+		},
+		Patterns: GrantResources{
+			Spaces: map[string]int64{"^space-.*": 13},
+		},
+		Meta:           map[string]interface{}{"app": "chat"},
+		AuthorizedUUID: "client-1",
+		Version:        2,
+		Timestamp:      1568805412,
+		TTL:            120,
+	}
+	tokenBytes, err := cbor.Dumps(old)
+	assert.Nil(err)
+	oldToken := base64.URLEncoding.EncodeToString(tokenBytes)
+
+	transport := newStubTransport(200, `{"status":200,"data":{"message":"Success","token":"p0F2AkF0Gl2"},"service":"Access Manager"}`)
+	pn.SetClient(&http.Client{Transport: transport})
+
+	token, err := pn.RenewToken(oldToken)
+	assert.Nil(err)
+	assert.Equal("p0F2AkF0Gl2", token)
+
+	var request struct {
+		TTL         int             `json:"ttl"`
+		Permissions PermissionsBody `json:"permissions"`
+	}
+	_, body := transport.last()
+	assert.Nil(json.Unmarshal([]byte(body), &request))
+	assert.Equal(120, request.TTL)
+	assert.Equal(old.Resources, request.Permissions.Resources)
+	assert.Equal(old.Patterns.Spaces, request.Permissions.Patterns.Spaces)
+	assert.Empty(request.Permissions.Patterns.Channels)
+	assert.Equal(old.Meta, request.Permissions.Meta)
+	assert.Equal("client-1", request.Permissions.AuthorizedUUID)
+
+	_, err = pn.RenewToken("not a token")
+	assert.NotNil(err)
+
+	pn.Config.SecretKey = ""
+	_, err = pn.RenewToken(oldToken)
+	assert.Contains(err.Error(), StrMissingSecretKeyPAM)
+}
+
+func TestGrantTokenDryRun(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+	tr := newStubTransport(200, "")
+	pn.SetClient(&http.Client{Transport: tr})
+
+	o := pn.GrantToken().TTL(10).Channels(map[string]ChannelPermissions{"ch": {Read: true}}).DryRun(true)
+	expected, err := o.opts.buildBody()
+	assert.Nil(err)
+
+	resp, _, err := o.Execute()
+	assert.Nil(err)
+	assert.Equal(0, tr.count())
+	assert.Equal(expected, resp.RequestBody)
+	assert.Contains(string(resp.RequestBody), `"ttl":10`)
+
+	// the dry run fails as Execute would
+	pn.Config.UUID = ""
+	_, _, err = o.Execute()
+	assert.Contains(err.Error(), StrMissingUUID)
+
+	pn.Config.DisableDefaultQueryParams = true
+	_, _, err = o.Execute()
+	assert.Contains(err.Error(), "pass uuid with QueryParam when DisableDefaultQueryParams is set")
+
+	pn.Config.SecretKey = ""
+	_, _, err = o.Execute()
+	assert.Contains(err.Error(), "Secret Key is required")
+	assert.Equal(0, tr.count())
+}
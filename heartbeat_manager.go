@@ -22,6 +22,7 @@ type HeartbeatManager struct {
 	hbRunning                 bool
 	queryParam                map[string]string
 	state                     map[string]interface{}
+	failedCalls               int
 }
 
 func newHeartbeatManager(pn *PubNub, context Context) *HeartbeatManager {
@@ -184,28 +185,55 @@ func (m *HeartbeatManager) performHeartbeatLoop() error {
 		return nil
 	}
 
-	_, status, err := newHeartbeatBuilder(m.pubnub).
+	heartbeatBuilder := newHeartbeatBuilder(m.pubnub).
 		Channels(presenceChannels).
 		ChannelGroups(presenceGroups).
 		State(stateStorage).
-		QueryParam(queryParam).
-		Execute()
+		QueryParam(queryParam)
+	heartbeatBuilder.opts.internal = true
+
+	_, status, err := heartbeatBuilder.Execute()
 
 	if err != nil {
+		category := PNBadRequestCategory
+		if status.Category == PNTimeoutCategory {
+			category = PNTimeoutCategory
+		}
 
 		pnStatus := &PNStatus{
-			Operation: PNHeartBeatOperation,
-			Category:  PNBadRequestCategory,
-			Error:     true,
-			ErrorData: err,
+			Operation:  PNHeartBeatOperation,
+			Category:   category,
+			Error:      true,
+			ErrorData:  err,
+			StatusCode: status.StatusCode,
 		}
 		m.pubnub.Config.Log.Println("performHeartbeatLoop: err", err, pnStatus)
 
 		m.pubnub.subscriptionManager.listenerManager.announceStatus(pnStatus)
 
+		m.Lock()
+		m.failedCalls++
+		failedCalls := m.failedCalls
+		m.Unlock()
+
+		if retries := m.pubnub.Config.MaximumReconnectionRetries; retries > 0 && failedCalls == retries {
+			m.pubnub.Config.Log.Println(fmt.Sprintf("performHeartbeatLoop: %d consecutive failures", failedCalls))
+
+			m.pubnub.subscriptionManager.listenerManager.announceStatus(&PNStatus{
+				Operation: PNHeartBeatOperation,
+				Category:  PNHeartbeatFailedCategory,
+				Error:     true,
+				ErrorData: err,
+			})
+		}
+
 		return err
 	}
 
+	m.Lock()
+	m.failedCalls = 0
+	m.Unlock()
+
 	pnStatus := &PNStatus{
 		Category:   PNUnknownCategory,
 		Error:      false,
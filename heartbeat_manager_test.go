@@ -0,0 +1,59 @@
+package pubnub
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pubnub/go/tests/stubs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHeartbeatFailureEscalation(t *testing.T) {
+	assert := assert.New(t)
+
+	config := NewDemoConfig()
+	config.MaximumReconnectionRetries = 2
+	pn := NewPubNub(config)
+	pn.SetClient(stubs.NewInterceptor().GetClient())
+
+	listener := NewListener()
+	pn.AddListener(listener)
+
+	m := newHeartbeatManager(pn, nil)
+	m.heartbeatChannels["ch"] = &SubscriptionItem{name: "ch"}
+
+	categories := make(chan StatusCategory, 10)
+	go func() {
+		for status := range listener.Status {
+			categories <- status.Category
+		}
+	}()
+
+	nextCategory := func() StatusCategory {
+		select {
+		case c := <-categories:
+			return c
+		case <-time.After(5 * time.Second):
+			return 0
+		}
+	}
+
+	assert.NotNil(m.performHeartbeatLoop())
+	assert.Equal(PNBadRequestCategory, nextCategory())
+
+	assert.NotNil(m.performHeartbeatLoop())
+	received := []StatusCategory{nextCategory(), nextCategory()}
+	assert.Contains(received, PNBadRequestCategory)
+	assert.Contains(received, PNHeartbeatFailedCategory)
+
+	assert.NotNil(m.performHeartbeatLoop())
+	assert.Equal(PNBadRequestCategory, nextCategory())
+
+	select {
+	case c := <-categories:
+		assert.Fail("unexpected status", c.String())
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	assert.Equal("Heartbeat Failed", PNHeartbeatFailedCategory.String())
+}
@@ -7,6 +7,7 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/pubnub/go/utils"
 )
@@ -46,6 +47,13 @@ func (b *heartbeatBuilder) QueryParam(queryParam map[string]string) *heartbeatBu
 	return b
 }
 
+// Timeout sets the timeout for the Heartbeat request.
+func (b *heartbeatBuilder) Timeout(timeout time.Duration) *heartbeatBuilder {
+	b.opts.Timeout = timeout
+
+	return b
+}
+
 // State sets the state for the Heartbeat request.
 func (b *heartbeatBuilder) State(state interface{}) *heartbeatBuilder {
 	b.opts.State = state
@@ -67,6 +75,11 @@ func (b *heartbeatBuilder) ChannelGroups(cg []string) *heartbeatBuilder {
 	return b
 }
 
+// SignedURL returns the URL of the Heartbeat request without executing it.
+func (b *heartbeatBuilder) SignedURL() (string, error) {
+	return signedURL(b.opts)
+}
+
 // Execute runs the Heartbeat request
 func (b *heartbeatBuilder) Execute() (interface{}, StatusResponse, error) {
 	rawJSON, status, err := executeRequest(b.opts)
@@ -92,8 +105,16 @@ type heartbeatOpts struct {
 	Channels      []string
 	ChannelGroups []string
 	QueryParam    map[string]string
+	Timeout       time.Duration
 
-	ctx Context
+	ctx      Context
+	internal bool
+}
+
+// isInternal is true when the heartbeat request is sent by the heartbeat manager, it then keeps the Config.UUID
+// when DisableDefaultQueryParams is set.
+func (o *heartbeatOpts) isInternal() bool {
+	return o.internal
 }
 
 func (o *heartbeatOpts) config() Config {
@@ -121,7 +142,7 @@ func (o *heartbeatOpts) validate() error {
 }
 
 func (o *heartbeatOpts) buildPath() (string, error) {
-	channels := string(utils.JoinChannels(o.Channels))
+	channels := string(utils.JoinChannels(o.pubnub.Config.channelNames(o.Channels)))
 
 	return fmt.Sprintf(heartbeatPath,
 		o.pubnub.Config.SubscribeKey,
@@ -129,7 +150,7 @@ func (o *heartbeatOpts) buildPath() (string, error) {
 }
 
 func (o *heartbeatOpts) buildQuery() (*url.Values, error) {
-	q := defaultQuery(o.pubnub.Config.UUID, o.pubnub.telemetryManager)
+	q := defaultQuery(o.pubnub.Config, o.pubnub.telemetryManager)
 
 	q.Set("heartbeat", strconv.Itoa(o.pubnub.Config.PresenceTimeout))
 
@@ -147,6 +168,7 @@ func (o *heartbeatOpts) buildQuery() (*url.Values, error) {
 			q.Set("state", string(state))
 		}
 	}
+	o.pubnub.tokenManager.setAuthParamForChannels(q, o.Channels, o.ChannelGroups)
 	SetQueryParam(q, o.QueryParam)
 
 	return q, nil
@@ -183,3 +205,11 @@ func (o *heartbeatOpts) operationType() OperationType {
 func (o *heartbeatOpts) telemetryManager() *TelemetryManager {
 	return o.pubnub.telemetryManager
 }
+
+func (o *heartbeatOpts) tokenManager() *TokenManager {
+	return o.pubnub.tokenManager
+}
+
+func (o *heartbeatOpts) timeout() time.Duration {
+	return o.Timeout
+}
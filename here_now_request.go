@@ -8,8 +8,10 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/pubnub/go/pnerr"
+	"github.com/pubnub/go/utils"
 )
 
 var hereNowPath = "/v2/presence/sub_key/%s/channel/%s"
@@ -73,6 +75,20 @@ func (b *hereNowBuilder) IncludeUUIDs(uuid bool) *hereNowBuilder {
 	return b
 }
 
+// Global when true and no channels or channel groups are set gets the occupancy of all the channels of the subscribe key.
+// It is required for the key wide request, which can be expensive on keys with many channels.
+func (b *hereNowBuilder) Global(global bool) *hereNowBuilder {
+	b.opts.Global = global
+
+	return b
+}
+
+// Transport sets the Transport for the HereNow request.
+func (b *hereNowBuilder) Transport(tr http.RoundTripper) *hereNowBuilder {
+	b.opts.Transport = tr
+	return b
+}
+
 // QueryParam accepts a map, the keys and values of the map are passed as the query string parameters of the URL called by the API.
 func (b *hereNowBuilder) QueryParam(queryParam map[string]string) *hereNowBuilder {
 	b.opts.QueryParam = queryParam
@@ -80,6 +96,18 @@ func (b *hereNowBuilder) QueryParam(queryParam map[string]string) *hereNowBuilde
 	return b
 }
 
+// Timeout sets the timeout for the HereNow request.
+func (b *hereNowBuilder) Timeout(timeout time.Duration) *hereNowBuilder {
+	b.opts.Timeout = timeout
+
+	return b
+}
+
+// SignedURL returns the URL of the HereNow request without executing it.
+func (b *hereNowBuilder) SignedURL() (string, error) {
+	return signedURL(b.opts)
+}
+
 // Execute runs the HereNow request.
 func (b *hereNowBuilder) Execute() (*HereNowResponse, StatusResponse, error) {
 	rawJSON, status, err := executeRequest(b.opts)
@@ -99,7 +127,9 @@ type hereNowOpts struct {
 	IncludeState    bool
 	SetIncludeState bool
 	SetIncludeUUIDs bool
+	Global          bool
 	QueryParam      map[string]string
+	Timeout         time.Duration
 
 	Transport http.RoundTripper
 
@@ -123,6 +153,10 @@ func (o *hereNowOpts) validate() error {
 		return newValidationError(o, StrMissingSubKey)
 	}
 
+	if len(o.Channels) == 0 && len(o.ChannelGroups) == 0 && !o.Global {
+		return newValidationError(o, fmt.Sprintf("%s: set Global(true) to get the occupancy of all the channels", StrMissingChannel))
+	}
+
 	return nil
 }
 
@@ -140,11 +174,11 @@ func (o *hereNowOpts) buildPath() (string, error) {
 
 	return fmt.Sprintf(hereNowPath,
 		o.pubnub.Config.SubscribeKey,
-		strings.Join(o.Channels, ",")), nil
+		utils.JoinChannels(o.pubnub.Config.channelNames(o.Channels))), nil
 }
 
 func (o *hereNowOpts) buildQuery() (*url.Values, error) {
-	q := defaultQuery(o.pubnub.Config.UUID, o.pubnub.telemetryManager)
+	q := defaultQuery(o.pubnub.Config, o.pubnub.telemetryManager)
 
 	if len(o.ChannelGroups) > 0 {
 		q.Set("channel-group", strings.Join(o.ChannelGroups, ","))
@@ -162,6 +196,7 @@ func (o *hereNowOpts) buildQuery() (*url.Values, error) {
 		q.Set("disable-uuids", "0")
 	}
 
+	o.pubnub.tokenManager.setAuthParamForChannels(q, o.Channels, o.ChannelGroups)
 	SetQueryParam(q, o.QueryParam)
 
 	return q, nil
@@ -199,6 +234,18 @@ func (o *hereNowOpts) telemetryManager() *TelemetryManager {
 	return o.pubnub.telemetryManager
 }
 
+func (o *hereNowOpts) tokenManager() *TokenManager {
+	return o.pubnub.tokenManager
+}
+
+func (o *hereNowOpts) timeout() time.Duration {
+	return o.Timeout
+}
+
+func (o *hereNowOpts) transport() http.RoundTripper {
+	return o.Transport
+}
+
 // HereNowResponse is the struct returned when the Execute function of HereNow is called.
 type HereNowResponse struct {
 	TotalChannels  int
@@ -246,7 +293,7 @@ func newHereNowResponse(jsonBytes []byte, channelNames []string,
 				if val, ok := parsedPayload["channels"].(map[string]interface{}); ok {
 					if len(val) > 0 {
 						for channelName, rawData := range val {
-							channels = append(channels, parseChannelData(channelName, rawData))
+							channels = append(channels, parseChannelData(utils.DecodeChannel(channelName, channelNames), rawData))
 						}
 
 						if totalCh, ok := parsedPayload["total_channels"].(float64); ok {
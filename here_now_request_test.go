@@ -176,6 +176,22 @@ func TestHereNowGlobal(t *testing.T) {
 	assert.Equal([]byte{}, body)
 }
 
+func TestHereNowGlobalFlag(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+
+	_, _, err := pn.HereNow().Execute()
+	assert.Equal("pubnub/validation: pubnub: Here Now: Missing Channel: set Global(true) to get the occupancy of all the channels", err.Error())
+
+	u, err := pn.HereNow().Global(true).SignedURL()
+	assert.Nil(err)
+	assert.Contains(u, "/v2/presence/sub_key/demo?")
+
+	u, err = pn.HereNow().Global(true).Channels([]string{"ch"}).SignedURL()
+	assert.Nil(err)
+	assert.Contains(u, "/v2/presence/sub_key/demo/channel/ch?")
+}
+
 func TestHereNowValidateSubscribeKey(t *testing.T) {
 	assert := assert.New(t)
 	pn := NewPubNub(NewDemoConfig())
@@ -184,7 +200,7 @@ func TestHereNowValidateSubscribeKey(t *testing.T) {
 		pubnub: pn,
 	}
 
-	assert.Equal("pubnub/validation: pubnub: \b: Missing Subscribe Key", opts.validate().Error())
+	assert.Equal("pubnub/validation: pubnub: Here Now: Missing Subscribe Key", opts.validate().Error())
 }
 
 func TestHereNowBuildPath(t *testing.T) {
@@ -276,3 +292,22 @@ func TestNewHereNowResponseOccupancyZeroPayloadWithoutCh(t *testing.T) {
 	assert.Equal(0, r.TotalOccupancy)
 
 }
+
+func TestHereNowChannelWithComma(t *testing.T) {
+	assert := assert.New(t)
+	opts := &hereNowOpts{
+		Channels: []string{"a,b", "c"},
+		pubnub:   pubnub,
+	}
+
+	path, err := opts.buildPath()
+	assert.Nil(err)
+	assert.Equal("/v2/presence/sub_key/sub_key/channel/a%2Cb,c", path)
+
+	jsonBytes := []byte(`{"status":200,"message":"OK","payload":{"channels":{"a%2Cb":{"occupancy":1,"uuids":["u1"]}},"total_channels":1,"total_occupancy":1},"service":"Presence"}`)
+
+	res, _, err := newHereNowResponse(jsonBytes, opts.Channels, StatusResponse{})
+	assert.Nil(err)
+	assert.Equal("a,b", res.Channels[0].ChannelName)
+	assert.Equal(1, res.Channels[0].Occupancy)
+}
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"net/url"
 	"strconv"
+	"time"
 
 	"github.com/pubnub/go/utils"
 )
@@ -66,12 +67,24 @@ func (b *historyDeleteBuilder) QueryParam(queryParam map[string]string) *history
 	return b
 }
 
+// Timeout sets the timeout for the DeleteMessages request.
+func (b *historyDeleteBuilder) Timeout(timeout time.Duration) *historyDeleteBuilder {
+	b.opts.Timeout = timeout
+
+	return b
+}
+
 // Transport sets the Transport for the DeleteMessages request.
 func (b *historyDeleteBuilder) Transport(tr http.RoundTripper) *historyDeleteBuilder {
 	b.opts.Transport = tr
 	return b
 }
 
+// SignedURL returns the URL of the DeleteMessages request without executing it.
+func (b *historyDeleteBuilder) SignedURL() (string, error) {
+	return signedURL(b.opts)
+}
+
 // Execute runs the DeleteMessages request.
 func (b *historyDeleteBuilder) Execute() (*HistoryDeleteResponse, StatusResponse, error) {
 	_, status, err := executeRequest(b.opts)
@@ -89,6 +102,7 @@ type historyDeleteOpts struct {
 	Start      int64
 	End        int64
 	QueryParam map[string]string
+	Timeout    time.Duration
 
 	SetStart bool
 	SetEnd   bool
@@ -133,11 +147,11 @@ func (o *historyDeleteOpts) jobQueue() chan *JobQItem {
 func (o *historyDeleteOpts) buildPath() (string, error) {
 	return fmt.Sprintf(historyDeletePath,
 		o.pubnub.Config.SubscribeKey,
-		utils.URLEncode(o.Channel)), nil
+		utils.URLEncode(o.pubnub.Config.channelName(o.Channel))), nil
 }
 
 func (o *historyDeleteOpts) buildQuery() (*url.Values, error) {
-	q := defaultQuery(o.pubnub.Config.UUID, o.pubnub.telemetryManager)
+	q := defaultQuery(o.pubnub.Config, o.pubnub.telemetryManager)
 
 	if o.SetStart {
 		q.Set("start", strconv.FormatInt(o.Start, 10))
@@ -147,6 +161,7 @@ func (o *historyDeleteOpts) buildQuery() (*url.Values, error) {
 		q.Set("end", strconv.FormatInt(o.End, 10))
 	}
 
+	o.pubnub.tokenManager.SetAuthParan(q, o.Channel, PNChannels)
 	SetQueryParam(q, o.QueryParam)
 
 	return q, nil
@@ -180,6 +195,18 @@ func (o *historyDeleteOpts) telemetryManager() *TelemetryManager {
 	return o.pubnub.telemetryManager
 }
 
+func (o *historyDeleteOpts) tokenManager() *TokenManager {
+	return o.pubnub.tokenManager
+}
+
+func (o *historyDeleteOpts) timeout() time.Duration {
+	return o.Timeout
+}
+
+func (o *historyDeleteOpts) transport() http.RoundTripper {
+	return o.Transport
+}
+
 // HistoryDeleteResponse is the struct returned when Delete Messages is called.
 type HistoryDeleteResponse struct {
 }
@@ -8,6 +8,7 @@ import (
 	"github.com/pubnub/go/utils"
 	"io/ioutil"
 	"strconv"
+	"time"
 
 	"net/http"
 	"net/url"
@@ -70,7 +71,9 @@ func (b *historyBuilder) Count(count int) *historyBuilder {
 	return b
 }
 
-// Reverse sets the order of messages in the History request.
+// Reverse reads the Count messages from the oldest end of the range when true, from the newest end otherwise.
+// The messages of the response are ordered oldest first either way,
+// and StartTimetoken and EndTimetoken are the timetokens of the first and last of them.
 func (b *historyBuilder) Reverse(r bool) *historyBuilder {
 	b.opts.Reverse = r
 	return b
@@ -82,6 +85,14 @@ func (b *historyBuilder) IncludeTimetoken(i bool) *historyBuilder {
 	return b
 }
 
+// CipherKey overrides the Config.CipherKey used to decrypt the messages of this History request only.
+// Useful to fetch the messages published before the cipher key was rotated.
+func (b *historyBuilder) CipherKey(cipherKey string) *historyBuilder {
+	b.opts.CipherKey = cipherKey
+	b.opts.setCipherKey = true
+	return b
+}
+
 // QueryParam accepts a map, the keys and values of the map are passed as the query string parameters of the URL called by the API.
 func (b *historyBuilder) QueryParam(queryParam map[string]string) *historyBuilder {
 	b.opts.QueryParam = queryParam
@@ -89,12 +100,38 @@ func (b *historyBuilder) QueryParam(queryParam map[string]string) *historyBuilde
 	return b
 }
 
+// Timeout sets the timeout for the History request.
+func (b *historyBuilder) Timeout(timeout time.Duration) *historyBuilder {
+	b.opts.Timeout = timeout
+
+	return b
+}
+
+// RequestTimeout sets the timeout in seconds for the History request.
+func (b *historyBuilder) RequestTimeout(seconds int) *historyBuilder {
+	b.opts.setRequestTimeout = true
+
+	return b.Timeout(time.Duration(seconds) * time.Second)
+}
+
+// ConnectTimeout sets the connect timeout in seconds for the History request.
+func (b *historyBuilder) ConnectTimeout(seconds int) *historyBuilder {
+	b.opts.ConnectTimeout = seconds
+
+	return b
+}
+
 // Transport sets the Transport for the History request.
 func (b *historyBuilder) Transport(tr http.RoundTripper) *historyBuilder {
 	b.opts.Transport = tr
 	return b
 }
 
+// SignedURL returns the URL of the History request without executing it.
+func (b *historyBuilder) SignedURL() (string, error) {
+	return signedURL(b.opts)
+}
+
 // Execute runs the History request.
 func (b *historyBuilder) Execute() (*HistoryResponse, StatusResponse, error) {
 	rawJSON, status, err := executeRequest(b.opts)
@@ -110,9 +147,11 @@ type historyOpts struct {
 
 	Channel string
 
-	Start      int64
-	End        int64
-	QueryParam map[string]string
+	Start          int64
+	End            int64
+	QueryParam     map[string]string
+	Timeout        time.Duration
+	ConnectTimeout int
 
 	// default: 100
 	Count int
@@ -123,9 +162,14 @@ type historyOpts struct {
 	// default: false
 	IncludeTimetoken bool
 
+	// default: Config.CipherKey
+	CipherKey string
+
 	// nil hacks
-	setStart bool
-	setEnd   bool
+	setRequestTimeout bool
+	setStart          bool
+	setEnd            bool
+	setCipherKey      bool
 
 	Transport http.RoundTripper
 
@@ -136,6 +180,16 @@ func (o *historyOpts) config() Config {
 	return *o.pubnub.Config
 }
 
+// cipherConfig returns the Config used for decryption, with the CipherKey override applied.
+func (o *historyOpts) cipherConfig() *Config {
+	if !o.setCipherKey {
+		return o.pubnub.Config
+	}
+	c := *o.pubnub.Config
+	c.CipherKey = o.CipherKey
+	return &c
+}
+
 func (o *historyOpts) client() *http.Client {
 	return o.pubnub.GetClient()
 }
@@ -149,21 +203,29 @@ func (o *historyOpts) validate() error {
 		return newValidationError(o, StrMissingSubKey)
 	}
 
+	if o.setRequestTimeout && o.Timeout <= 0 {
+		return newValidationError(o, fmt.Sprintf("%s %s: must be positive", StrInvalidTimeout, o.Timeout))
+	}
+
 	if o.Channel == "" {
 		return newValidationError(o, StrMissingChannel)
 	}
 
+	if err := validateChannelNames(o, o.Channel); err != nil {
+		return err
+	}
+
 	return nil
 }
 
 func (o *historyOpts) buildPath() (string, error) {
 	return fmt.Sprintf(historyPath,
 		o.pubnub.Config.SubscribeKey,
-		utils.URLEncode(o.Channel)), nil
+		utils.URLEncode(o.pubnub.Config.channelName(o.Channel))), nil
 }
 
 func (o *historyOpts) buildQuery() (*url.Values, error) {
-	q := defaultQuery(o.pubnub.Config.UUID, o.pubnub.telemetryManager)
+	q := defaultQuery(o.pubnub.Config, o.pubnub.telemetryManager)
 
 	if o.setStart {
 		q.Set("start", strconv.FormatInt(o.Start, 10))
@@ -182,6 +244,7 @@ func (o *historyOpts) buildQuery() (*url.Values, error) {
 	q.Set("reverse", strconv.FormatBool(o.Reverse))
 	q.Set("include_token", strconv.FormatBool(o.IncludeTimetoken))
 
+	o.pubnub.tokenManager.SetAuthParan(q, o.Channel, PNChannels)
 	SetQueryParam(q, o.QueryParam)
 
 	return q, nil
@@ -208,6 +271,9 @@ func (o *historyOpts) requestTimeout() int {
 }
 
 func (o *historyOpts) connectTimeout() int {
+	if o.ConnectTimeout != 0 {
+		return o.ConnectTimeout
+	}
 	return o.pubnub.Config.ConnectTimeout
 }
 
@@ -219,6 +285,18 @@ func (o *historyOpts) telemetryManager() *TelemetryManager {
 	return o.pubnub.telemetryManager
 }
 
+func (o *historyOpts) tokenManager() *TokenManager {
+	return o.pubnub.tokenManager
+}
+
+func (o *historyOpts) timeout() time.Duration {
+	return o.Timeout
+}
+
+func (o *historyOpts) transport() http.RoundTripper {
+	return o.Transport
+}
+
 // HistoryResponse is used to store the response from the History request.
 type HistoryResponse struct {
 	Messages       []HistoryResponseItem
@@ -227,9 +305,35 @@ type HistoryResponse struct {
 }
 
 // HistoryResponseItem is used to store the Message and the associated timetoken from the History request.
+// Error is set when the Message could not be decrypted, the Message is then returned as received.
 type HistoryResponseItem struct {
 	Message   interface{}
 	Timetoken int64
+	// Region is the region of the Timetoken, it is 0 when the server does not send it.
+	Region int
+	Error  error `json:"-"`
+}
+
+// DecodeMessage decodes the Message into target, a pointer to the type of the message, with a JSON round trip.
+func (item HistoryResponseItem) DecodeMessage(target interface{}) error {
+	return decodeJSONInto(item.Message, target)
+}
+
+// DecodeMessages decodes the Messages into sliceTarget, a pointer to a slice of the type of the messages, with a JSON round trip.
+func (resp *HistoryResponse) DecodeMessages(sliceTarget interface{}) error {
+	messages := make([]interface{}, len(resp.Messages))
+	for i, item := range resp.Messages {
+		messages[i] = item.Message
+	}
+	return decodeJSONInto(messages, sliceTarget)
+}
+
+func decodeJSONInto(value, target interface{}) error {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, target)
 }
 
 func logAndCreateNewResponseParsingError(o *historyOpts, err error, jsonBody string, message string) *pnerr.ResponseParsingError {
@@ -239,9 +343,14 @@ func logAndCreateNewResponseParsingError(o *historyOpts, err error, jsonBody str
 	return e
 }
 
+func newHistoryDecryptionError(o *historyOpts, index int, message interface{}, err error) *pnerr.ResponseParsingError {
+	return logAndCreateNewResponseParsingError(o, err, fmt.Sprintf("%v", message),
+		fmt.Sprintf("failed to decrypt message %d on channel %s: %s", index, o.Channel, err.Error()))
+}
+
 func getHistoryItemsWithoutTimetoken(historyResponseRaw []byte, o *historyOpts, err1 error, jsonBytes []byte) ([]HistoryResponseItem, *pnerr.ResponseParsingError) {
 	var historyResponseItems []interface{}
-	err0 := json.Unmarshal(historyResponseRaw, &historyResponseItems)
+	err0 := unmarshalJSON(historyResponseRaw, &historyResponseItems, o.pubnub.Config.UseNumber)
 	if err0 != nil {
 		e := logAndCreateNewResponseParsingError(o, fmt.Errorf("%e, %e, %s", err0, err1, string(jsonBytes)), string(jsonBytes), "Error unmarshalling response")
 
@@ -252,7 +361,11 @@ func getHistoryItemsWithoutTimetoken(historyResponseRaw []byte, o *historyOpts,
 
 	for i, v := range historyResponseItems {
 		o.pubnub.Config.Log.Println(v)
-		items[i].Message, _ = parseCipherInterface(v, o.pubnub.Config)
+		msg, err := parseCipherInterface(v, o.cipherConfig())
+		if err != nil {
+			items[i].Error = newHistoryDecryptionError(o, i, v, err)
+		}
+		items[i].Message = msg
 	}
 	return items, nil
 }
@@ -263,12 +376,18 @@ func getHistoryItemsWithTimetoken(historyResponseItems []HistoryResponseItem, o
 	b := false
 
 	for i, v := range historyResponseItems {
-		if v.Message != nil {
+		// a null message still carries its timetoken, only the items without one are not timetoken items
+		if v.Timetoken != 0 {
 			o.pubnub.Config.Log.Println(v.Message)
-			items[i].Message, _ = parseCipherInterface(v.Message, o.pubnub.Config)
+			msg, err := parseCipherInterface(v.Message, o.cipherConfig())
+			if err != nil {
+				items[i].Error = newHistoryDecryptionError(o, i, v.Message, err)
+			}
+			items[i].Message = msg
 
 			o.pubnub.Config.Log.Println(v.Timetoken)
 			items[i].Timetoken = v.Timetoken
+			items[i].Region = v.Region
 		} else {
 			b = true
 			break
@@ -282,6 +401,16 @@ func getHistoryItemsWithTimetoken(historyResponseItems []HistoryResponseItem, o
 	return items, nil
 }
 
+// parseHistoryTimetoken parses the start and end timetokens of the history response, sent as numbers or quoted numbers.
+func parseHistoryTimetoken(raw json.RawMessage) (int64, error) {
+	value := string(raw)
+	if unquoted, err := strconv.Unquote(value); err == nil {
+		value = unquoted
+	}
+
+	return strconv.ParseInt(value, 10, 64)
+}
+
 func newHistoryResponse(jsonBytes []byte, o *historyOpts,
 	status StatusResponse) (*HistoryResponse, StatusResponse, error) {
 
@@ -303,35 +432,39 @@ func newHistoryResponse(jsonBytes []byte, o *historyOpts,
 
 		var historyResponseItems []HistoryResponseItem
 		var items []HistoryResponseItem
-
-		err1 := json.Unmarshal(historyResponseRaw[0], &historyResponseItems)
 		var e *pnerr.ResponseParsingError
-		if err1 != nil {
-			o.pubnub.Config.Log.Println(err1.Error())
 
-			items, e = getHistoryItemsWithoutTimetoken(historyResponseRaw[0], o, err1, jsonBytes)
-			if e != nil {
-				return emptyHistoryResp, status, e
-			}
-		} else {
+		// without IncludeTimetoken the items are the messages, a message shaped like {"message":...} is not unwrapped
+		var err1 error
+		if o.IncludeTimetoken {
+			err1 = unmarshalJSON(historyResponseRaw[0], &historyResponseItems, o.pubnub.Config.UseNumber)
+		}
+		if o.IncludeTimetoken && err1 == nil {
 			items, e = getHistoryItemsWithTimetoken(historyResponseItems, o, historyResponseRaw[0], jsonBytes)
-			if e != nil {
-				return emptyHistoryResp, status, e
+		} else {
+			if err1 != nil {
+				o.pubnub.Config.Log.Println(err1.Error())
 			}
+			items, e = getHistoryItemsWithoutTimetoken(historyResponseRaw[0], o, err1, jsonBytes)
+		}
+		if e != nil {
+			return emptyHistoryResp, status, e
 		}
 		if items != nil {
 			resp.Messages = items
 			o.pubnub.Config.Log.Printf("returning []interface, %v\n", items)
 		} else {
+			// a channel without messages returns [[],"tt","tt"], Messages is empty but not nil
+			resp.Messages = []HistoryResponseItem{}
 			o.pubnub.Config.Log.Println("items nil")
 		}
 
-		startTimetoken, err := strconv.ParseInt(string(historyResponseRaw[1]), 10, 64)
+		startTimetoken, err := parseHistoryTimetoken(historyResponseRaw[1])
 		if err == nil {
 			resp.StartTimetoken = startTimetoken
 		}
 
-		endTimetoken, err := strconv.ParseInt(string(historyResponseRaw[2]), 10, 64)
+		endTimetoken, err := parseHistoryTimetoken(historyResponseRaw[2])
 		if err == nil {
 			resp.EndTimetoken = endTimetoken
 		}
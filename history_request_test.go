@@ -1,10 +1,13 @@
 package pubnub
 
 import (
+	"encoding/json"
 	"fmt"
+	"net/http"
 	"net/url"
 	"reflect"
 	"testing"
+	"time"
 
 	h "github.com/pubnub/go/tests/helpers"
 	"github.com/stretchr/testify/assert"
@@ -449,3 +452,230 @@ func TestHistoryResponseEndTTError(t *testing.T) {
 	assert.Nil(err)
 
 }
+
+func TestHistoryResponseEmpty(t *testing.T) {
+	assert := assert.New(t)
+
+	jsonString := []byte(`[[],"15699986472636251","15699986472636252"]`)
+
+	resp, _, err := newHistoryResponse(jsonString, initHistoryOpts(), fakeResponseState)
+	assert.Nil(err)
+	assert.NotNil(resp.Messages)
+	assert.Equal(0, len(resp.Messages))
+	assert.Equal(int64(15699986472636251), resp.StartTimetoken)
+	assert.Equal(int64(15699986472636252), resp.EndTimetoken)
+
+	jsonString = []byte(`[[],0,0]`)
+
+	resp, _, err = newHistoryResponse(jsonString, initHistoryOpts(), fakeResponseState)
+	assert.Nil(err)
+	assert.NotNil(resp.Messages)
+	assert.Equal(0, len(resp.Messages))
+	assert.Equal(int64(0), resp.StartTimetoken)
+	assert.Equal(int64(0), resp.EndTimetoken)
+}
+
+func TestHistoryDecryptionErrorContext(t *testing.T) {
+	assert := assert.New(t)
+	pnconfig.CipherKey = "testCipher"
+
+	jsonString := []byte(`[["MnwzPGdVgz2osQCIQJviGg==","MnwzPGdVgz2osQCIQJviGg==","MnwzPGdVgz2osQCIQJviGg==","not-encrypted"],14991775432719844,14991868111600528]`)
+
+	resp, _, err := newHistoryResponse(jsonString, initHistoryOpts(), fakeResponseState)
+	assert.Nil(err)
+
+	messages := resp.Messages
+	assert.Equal("hey", messages[0].Message)
+	assert.Nil(messages[0].Error)
+	assert.Equal("not-encrypted", messages[3].Message)
+	assert.Contains(messages[3].Error.Error(), "failed to decrypt message 3 on channel ch")
+
+	pnconfig.CipherKey = ""
+}
+
+func TestHistoryDecryptionFallbackToRaw(t *testing.T) {
+	assert := assert.New(t)
+	pnconfig.CipherKey = "testCipher"
+	pnconfig.DecryptionFallbackToRaw = true
+
+	jsonString := []byte(`[["MnwzPGdVgz2osQCIQJviGg==","not-encrypted"],14991775432719844,14991868111600528]`)
+
+	resp, _, err := newHistoryResponse(jsonString, initHistoryOpts(), fakeResponseState)
+	assert.Nil(err)
+
+	messages := resp.Messages
+	assert.Equal("hey", messages[0].Message)
+	assert.Nil(messages[0].Error)
+	assert.Equal("not-encrypted", messages[1].Message)
+	assert.Nil(messages[1].Error)
+
+	pnconfig.DecryptionFallbackToRaw = false
+	pnconfig.CipherKey = ""
+}
+
+func TestHistoryCipherKeyOverride(t *testing.T) {
+	assert := assert.New(t)
+	pnconfig.CipherKey = "enigma"
+
+	jsonString := []byte(`[["MnwzPGdVgz2osQCIQJviGg=="],14991775432719844,14991868111600528]`)
+
+	resp, _, err := newHistoryResponse(jsonString, initHistoryOpts(), fakeResponseState)
+	assert.Nil(err)
+	assert.NotNil(resp.Messages[0].Error)
+
+	o := newHistoryBuilder(pubnub)
+	o.Channel("ch")
+	o.CipherKey("testCipher")
+
+	resp, _, err = newHistoryResponse(jsonString, o.opts, fakeResponseState)
+	assert.Nil(err)
+	assert.Nil(resp.Messages[0].Error)
+	assert.Equal("hey", resp.Messages[0].Message)
+	assert.Equal("enigma", pnconfig.CipherKey)
+
+	pnconfig.CipherKey = ""
+}
+
+func TestHistoryValidateChannelName(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, c := range []string{"/", "?", "#", ","} {
+		opts := initHistoryOpts()
+		opts.Channel = "a" + c + "b"
+
+		assert.Equal(fmt.Sprintf("pubnub/validation: pubnub: History: Invalid Channel a%sb: '%s' is not allowed", c, c), opts.validate().Error())
+	}
+
+	opts := initHistoryOpts()
+	opts.Channel = "-._~:[]@!$&'()*+;=`|"
+
+	assert.Nil(opts.validate())
+}
+
+func TestHistoryResponseParsingUseNumber(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+	pn.Config.UseNumber = true
+	opts := initHistoryOpts()
+	opts.pubnub = pn
+
+	jsonString := []byte(`[[9007199254740993,{"id":1234567890123456789}],14991775432719844,14991868111600528]`)
+
+	resp, _, err := newHistoryResponse(jsonString, opts, fakeResponseState)
+	assert.Nil(err)
+	assert.Equal(int64(14991775432719844), resp.StartTimetoken)
+
+	n, err := resp.Messages[0].Message.(json.Number).Int64()
+	assert.Nil(err)
+	assert.Equal(int64(9007199254740993), n)
+	n, err = resp.Messages[1].Message.(map[string]interface{})["id"].(json.Number).Int64()
+	assert.Nil(err)
+	assert.Equal(int64(1234567890123456789), n)
+
+	jsonString = []byte(`[[{"message":1234567890123456789,"timetoken":15032211829005450}],15032211829005450,15032211829005450]`)
+
+	resp, _, err = newHistoryResponse(jsonString, opts, fakeResponseState)
+	assert.Nil(err)
+	assert.Equal(int64(15032211829005450), resp.Messages[0].Timetoken)
+	assert.Equal(json.Number("1234567890123456789"), resp.Messages[0].Message)
+}
+
+func TestHistoryRequestTimeout(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+	pn.SetClient(&http.Client{Transport: newSlowTransport(1500*time.Millisecond, `[[1],14991775432719844,14991868111600528]`), Timeout: 10 * time.Second})
+
+	start := time.Now()
+	_, _, err := pn.History().Channel("ch").RequestTimeout(1).Execute()
+	assert.NotNil(err)
+	assert.True(time.Since(start) < 1400*time.Millisecond)
+
+	// longer than the client timeout
+	pn.SetClient(&http.Client{Transport: newSlowTransport(100*time.Millisecond, `[[1],14991775432719844,14991868111600528]`), Timeout: 50 * time.Millisecond})
+	res, _, err := pn.History().Channel("ch").RequestTimeout(1).Execute()
+	assert.Nil(err)
+	assert.Equal(1, len(res.Messages))
+
+	_, _, err = pn.History().Channel("ch").RequestTimeout(-1).Execute()
+	assert.Equal("pubnub/validation: pubnub: History: Invalid Timeout -1s: must be positive", err.Error())
+
+	// 0 is rejected too, it doesn't fall back to Config.NonSubscribeRequestTimeout
+	_, _, err = pn.History().Channel("ch").RequestTimeout(0).Execute()
+	assert.Equal("pubnub/validation: pubnub: History: Invalid Timeout 0s: must be positive", err.Error())
+}
+
+func TestHistoryResponseRegion(t *testing.T) {
+	assert := assert.New(t)
+
+	jsonString := []byte(`[[{"message":"hey","timetoken":15232761410327866,"region":4},{"message":"hey-2","timetoken":15232761410327867}],15232761410327866,15232761410327867]`)
+
+	resp, _, err := newHistoryResponse(jsonString, initHistoryOpts(), fakeResponseState)
+	assert.Nil(err)
+	assert.Equal(int64(15232761410327866), resp.Messages[0].Timetoken)
+	assert.Equal(4, resp.Messages[0].Region)
+	assert.Equal(0, resp.Messages[1].Region)
+}
+
+func TestHistoryResponseReverseWithTimetoken(t *testing.T) {
+	assert := assert.New(t)
+
+	opts := initHistoryOpts()
+	opts.Reverse = true
+
+	u, err := opts.buildQuery()
+	assert.Nil(err)
+	assert.Equal("true", u.Get("reverse"))
+	assert.Equal("true", u.Get("include_token"))
+
+	jsonString := []byte(`[[{"message":"first","timetoken":15232761410327866},{"message":null,"timetoken":15232761410327867},{"message":{"message":"third"},"timetoken":15232761410327868}],15232761410327866,15232761410327868]`)
+
+	resp, _, err := newHistoryResponse(jsonString, opts, fakeResponseState)
+	assert.Nil(err)
+	assert.Equal(3, len(resp.Messages))
+	assert.Equal("first", resp.Messages[0].Message)
+	assert.Equal(int64(15232761410327866), resp.Messages[0].Timetoken)
+	assert.Nil(resp.Messages[1].Message)
+	assert.Equal(int64(15232761410327867), resp.Messages[1].Timetoken)
+	assert.Equal(map[string]interface{}{"message": "third"}, resp.Messages[2].Message)
+	assert.Equal(int64(15232761410327868), resp.Messages[2].Timetoken)
+	assert.Equal(resp.Messages[0].Timetoken, resp.StartTimetoken)
+	assert.Equal(resp.Messages[2].Timetoken, resp.EndTimetoken)
+
+	opts.IncludeTimetoken = false
+	jsonString = []byte(`[[{"message":"first"},"second"],15232761410327866,15232761410327867]`)
+
+	resp, _, err = newHistoryResponse(jsonString, opts, fakeResponseState)
+	assert.Nil(err)
+	assert.Equal(map[string]interface{}{"message": "first"}, resp.Messages[0].Message)
+	assert.Equal(int64(0), resp.Messages[0].Timetoken)
+	assert.Equal("second", resp.Messages[1].Message)
+}
+
+func TestHistoryDecodeMessages(t *testing.T) {
+	assert := assert.New(t)
+
+	type chatMessage struct {
+		Text   string `json:"text"`
+		Sender string `json:"sender"`
+		Count  int    `json:"count"`
+	}
+
+	jsonString := []byte(`[[{"message":{"text":"hey","sender":"a","count":1},"timetoken":15010808292416521},{"message":{"text":"hi","sender":"b","count":2},"timetoken":15010808292416522}],15010808292416521,15010808292416522]`)
+
+	resp, _, err := newHistoryResponse(jsonString, initHistoryOpts(), fakeResponseState)
+	assert.Nil(err)
+
+	var first chatMessage
+	assert.Nil(resp.Messages[0].DecodeMessage(&first))
+	assert.Equal(chatMessage{Text: "hey", Sender: "a", Count: 1}, first)
+
+	var messages []chatMessage
+	assert.Nil(resp.DecodeMessages(&messages))
+	assert.Equal([]chatMessage{
+		chatMessage{Text: "hey", Sender: "a", Count: 1},
+		chatMessage{Text: "hi", Sender: "b", Count: 2},
+	}, messages)
+
+	var text string
+	assert.NotNil(resp.Messages[0].DecodeMessage(&text))
+}
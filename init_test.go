@@ -1,5 +1,14 @@
 package pubnub
 
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+)
+
 var pnconfig *Config
 var pubnub *PubNub
 
@@ -18,3 +27,94 @@ func pubnubCopy() *PubNub {
 	*pn = *pubnub
 	return pn
 }
+
+// testTransport is the Transport of the tests which check the requests sent or answer them depending on the request,
+// the tests/stubs Interceptor is used when the responses only depend on the path and query.
+// The requests are recorded with their bodies, respond gets the body unread.
+type testTransport struct {
+	sync.Mutex
+	requests []*http.Request
+	bodies   []string
+	respond  func(req *http.Request) (*http.Response, error)
+}
+
+func newTestTransport(respond func(req *http.Request) (*http.Response, error)) *testTransport {
+	return &testTransport{respond: respond}
+}
+
+// newStubTransport returns a testTransport answering all the requests with the status code and body.
+func newStubTransport(statusCode int, body string) *testTransport {
+	return newTestTransport(func(req *http.Request) (*http.Response, error) {
+		return stubResponse(req, statusCode, body), nil
+	})
+}
+
+func (t *testTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		body, _ = ioutil.ReadAll(req.Body)
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+
+	t.Lock()
+	t.requests = append(t.requests, req)
+	t.bodies = append(t.bodies, string(body))
+	t.Unlock()
+
+	return t.respond(req)
+}
+
+// count returns the number of requests sent.
+func (t *testTransport) count() int {
+	t.Lock()
+	defer t.Unlock()
+
+	return len(t.requests)
+}
+
+// last returns the last request sent and its body.
+func (t *testTransport) last() (*http.Request, string) {
+	t.Lock()
+	defer t.Unlock()
+
+	if len(t.requests) == 0 {
+		return nil, ""
+	}
+	return t.requests[len(t.requests)-1], t.bodies[len(t.bodies)-1]
+}
+
+// queries returns the value of the query param key of each request sent.
+func (t *testTransport) queries(key string) []string {
+	t.Lock()
+	defer t.Unlock()
+
+	values := make([]string, len(t.requests))
+	for i, req := range t.requests {
+		values[i] = req.URL.Query().Get(key)
+	}
+	return values
+}
+
+// sent returns the requests sent whose path contains path.
+func (t *testTransport) sent(path string) []*http.Request {
+	t.Lock()
+	defer t.Unlock()
+
+	requests := []*http.Request{}
+	for _, req := range t.requests {
+		if strings.Contains(req.URL.Opaque, path) || strings.Contains(req.URL.Path, path) {
+			requests = append(requests, req)
+		}
+	}
+	return requests
+}
+
+// stubResponse returns a response with the status code and body.
+func stubResponse(req *http.Request, statusCode int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: statusCode,
+		Status:     fmt.Sprintf("%d %s", statusCode, http.StatusText(statusCode)),
+		Request:    req,
+		Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+	}
+}
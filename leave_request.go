@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"time"
 
 	"github.com/pubnub/go/utils"
 )
@@ -54,6 +55,18 @@ func (b *leaveBuilder) QueryParam(queryParam map[string]string) *leaveBuilder {
 	return b
 }
 
+// Timeout sets the timeout for the Leave request.
+func (b *leaveBuilder) Timeout(timeout time.Duration) *leaveBuilder {
+	b.opts.Timeout = timeout
+
+	return b
+}
+
+// SignedURL returns the URL of the Leave request without executing it.
+func (b *leaveBuilder) SignedURL() (string, error) {
+	return signedURL(b.opts)
+}
+
 // Execute runs the Leave request.
 func (b *leaveBuilder) Execute() (StatusResponse, error) {
 	_, status, err := executeRequest(b.opts)
@@ -68,9 +81,17 @@ type leaveOpts struct {
 	Channels      []string
 	ChannelGroups []string
 	QueryParam    map[string]string
+	Timeout       time.Duration
+
+	pubnub   *PubNub
+	ctx      Context
+	internal bool
+}
 
-	pubnub *PubNub
-	ctx    Context
+// isInternal is true when the leave request is sent by the subscription manager on unsubscribe, it then keeps the Config.UUID
+// when DisableDefaultQueryParams is set.
+func (o *leaveOpts) isInternal() bool {
+	return o.internal
 }
 
 func (o *leaveOpts) buildBody() ([]byte, error) {
@@ -82,7 +103,7 @@ func (o *leaveOpts) httpMethod() string {
 }
 
 func (o *leaveOpts) buildPath() (string, error) {
-	channels := utils.JoinChannels(o.Channels)
+	channels := utils.JoinChannels(o.pubnub.Config.channelNames(o.Channels))
 
 	if string(channels) == "" {
 		channels = []byte(",")
@@ -98,12 +119,13 @@ func (o *leaveOpts) jobQueue() chan *JobQItem {
 }
 
 func (o *leaveOpts) buildQuery() (*url.Values, error) {
-	q := defaultQuery(o.pubnub.Config.UUID, o.pubnub.telemetryManager)
+	q := defaultQuery(o.pubnub.Config, o.pubnub.telemetryManager)
 
 	if len(o.ChannelGroups) > 0 {
 		channelGroup := utils.JoinChannels(o.ChannelGroups)
 		q.Set("channel-group", string(channelGroup))
 	}
+	o.pubnub.tokenManager.setAuthParamForChannels(q, o.Channels, o.ChannelGroups)
 	SetQueryParam(q, o.QueryParam)
 	return q, nil
 }
@@ -132,6 +154,10 @@ func (o *leaveOpts) validate() error {
 	return nil
 }
 
+func (o *leaveOpts) isAuthRequired() bool {
+	return true
+}
+
 func (o *leaveOpts) operationType() OperationType {
 	return PNUnsubscribeOperation
 }
@@ -139,3 +165,11 @@ func (o *leaveOpts) operationType() OperationType {
 func (o *leaveOpts) telemetryManager() *TelemetryManager {
 	return o.pubnub.telemetryManager
 }
+
+func (o *leaveOpts) tokenManager() *TokenManager {
+	return o.pubnub.tokenManager
+}
+
+func (o *leaveOpts) timeout() time.Duration {
+	return o.Timeout
+}
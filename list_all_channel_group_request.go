@@ -7,6 +7,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"time"
 
 	"github.com/pubnub/go/pnerr"
 	"github.com/pubnub/go/utils"
@@ -49,6 +50,19 @@ func (b *allChannelGroupBuilder) ChannelGroup(
 	return b
 }
 
+// ChannelCallback passes each channel of the group to f as it is parsed, the channels are then not collected in the response.
+// The channel registration endpoint does not paginate, use it to avoid building a large slice for big groups.
+func (b *allChannelGroupBuilder) ChannelCallback(f func(channel string)) *allChannelGroupBuilder {
+	b.opts.ChannelCallback = f
+	return b
+}
+
+// Transport sets the Transport for the ListChannelsInChannelGroup request.
+func (b *allChannelGroupBuilder) Transport(tr http.RoundTripper) *allChannelGroupBuilder {
+	b.opts.Transport = tr
+	return b
+}
+
 // QueryParam accepts a map, the keys and values of the map are passed as the query string parameters of the URL called by the API.
 func (b *allChannelGroupBuilder) QueryParam(queryParam map[string]string) *allChannelGroupBuilder {
 	b.opts.QueryParam = queryParam
@@ -56,6 +70,18 @@ func (b *allChannelGroupBuilder) QueryParam(queryParam map[string]string) *allCh
 	return b
 }
 
+// Timeout sets the timeout for the ListChannelsInChannelGroup request.
+func (b *allChannelGroupBuilder) Timeout(timeout time.Duration) *allChannelGroupBuilder {
+	b.opts.Timeout = timeout
+
+	return b
+}
+
+// SignedURL returns the URL of the ListChannelsInChannelGroup request without executing it.
+func (b *allChannelGroupBuilder) SignedURL() (string, error) {
+	return signedURL(b.opts)
+}
+
 // Execute runs the ListChannelsInChannelGroup request.
 func (b *allChannelGroupBuilder) Execute() (
 	*AllChannelGroupResponse, StatusResponse, error) {
@@ -63,6 +89,9 @@ func (b *allChannelGroupBuilder) Execute() (
 	if err != nil {
 		return emptyAllChannelGroupResponse, status, err
 	}
+	if b.opts.ChannelCallback != nil {
+		return newAllChannelGroupResponseWithCallback(rawJSON, status, b.opts.ChannelCallback)
+	}
 
 	return newAllChannelGroupResponse(rawJSON, status)
 }
@@ -70,9 +99,11 @@ func (b *allChannelGroupBuilder) Execute() (
 type allChannelGroupOpts struct {
 	pubnub *PubNub
 
-	ChannelGroup string
-	QueryParam   map[string]string
-	Transport    http.RoundTripper
+	ChannelGroup    string
+	ChannelCallback func(channel string)
+	QueryParam      map[string]string
+	Timeout         time.Duration
+	Transport       http.RoundTripper
 
 	ctx Context
 }
@@ -108,7 +139,8 @@ func (o *allChannelGroupOpts) buildPath() (string, error) {
 }
 
 func (o *allChannelGroupOpts) buildQuery() (*url.Values, error) {
-	q := defaultQuery(o.pubnub.Config.UUID, o.pubnub.telemetryManager)
+	q := defaultQuery(o.pubnub.Config, o.pubnub.telemetryManager)
+	o.pubnub.tokenManager.SetAuthParan(q, o.ChannelGroup, PNGroups)
 	SetQueryParam(q, o.QueryParam)
 	return q, nil
 }
@@ -145,6 +177,18 @@ func (o *allChannelGroupOpts) telemetryManager() *TelemetryManager {
 	return o.pubnub.telemetryManager
 }
 
+func (o *allChannelGroupOpts) tokenManager() *TokenManager {
+	return o.pubnub.tokenManager
+}
+
+func (o *allChannelGroupOpts) timeout() time.Duration {
+	return o.Timeout
+}
+
+func (o *allChannelGroupOpts) transport() http.RoundTripper {
+	return o.Transport
+}
+
 // AllChannelGroupResponse is the struct returned when the Execute function of List All Channel Groups is called.
 type AllChannelGroupResponse struct {
 	Channels     []string
@@ -187,3 +231,47 @@ func newAllChannelGroupResponse(jsonBytes []byte, status StatusResponse) (
 
 	return resp, status, nil
 }
+
+// channelStream calls f for each string of a JSON array while it is decoded.
+type channelStream struct {
+	f func(channel string)
+}
+
+func (s *channelStream) UnmarshalJSON(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if _, err := dec.Token(); err != nil {
+		return err
+	}
+	for dec.More() {
+		var channel interface{}
+		if err := dec.Decode(&channel); err != nil {
+			return err
+		}
+		if ch, ok := channel.(string); ok {
+			s.f(ch)
+		}
+	}
+
+	return nil
+}
+
+func newAllChannelGroupResponseWithCallback(jsonBytes []byte, status StatusResponse,
+	f func(channel string)) (*AllChannelGroupResponse, StatusResponse, error) {
+	var value struct {
+		Payload struct {
+			Group    string        `json:"group"`
+			Channels channelStream `json:"channels"`
+		} `json:"payload"`
+	}
+	value.Payload.Channels.f = f
+
+	err := json.Unmarshal(jsonBytes, &value)
+	if err != nil {
+		e := pnerr.NewResponseParsingError("Error unmarshalling response",
+			ioutil.NopCloser(bytes.NewBufferString(string(jsonBytes))), err)
+
+		return emptyAllChannelGroupResponse, status, e
+	}
+
+	return &AllChannelGroupResponse{ChannelGroup: value.Payload.Group}, status, nil
+}
@@ -2,7 +2,9 @@ package pubnub
 
 import (
 	"fmt"
+	"net/http"
 	"net/url"
+	"strings"
 	"testing"
 
 	h "github.com/pubnub/go/tests/helpers"
@@ -135,7 +137,7 @@ func TestListAllChannelsValidateSubscribeKey(t *testing.T) {
 		pubnub:       pn,
 	}
 
-	assert.Equal("pubnub/validation: pubnub: \x0f: Missing Subscribe Key", opts.validate().Error())
+	assert.Equal("pubnub/validation: pubnub: List Channels In Channel Group: Missing Subscribe Key", opts.validate().Error())
 }
 
 func TestListAllChannelsValidateChannelGrp(t *testing.T) {
@@ -145,5 +147,32 @@ func TestListAllChannelsValidateChannelGrp(t *testing.T) {
 		pubnub: pn,
 	}
 
-	assert.Equal("pubnub/validation: pubnub: \x0f: Missing Channel Group", opts.validate().Error())
+	assert.Equal("pubnub/validation: pubnub: List Channels In Channel Group: Missing Channel Group", opts.validate().Error())
+}
+
+func TestListAllChannelsChannelCallback(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+
+	channels := make([]string, 10000)
+	for i := range channels {
+		channels[i] = fmt.Sprintf(`"ch%d"`, i)
+	}
+	body := fmt.Sprintf(`{"status":200,"payload":{"channels":[%s],"group":"cg"},"service":"channel-registry","error":false}`,
+		strings.Join(channels, ","))
+	pn.SetClient(&http.Client{Transport: newStubTransport(200, body)})
+
+	count := 0
+	res, _, err := pn.ListChannelsInChannelGroup().ChannelGroup("cg").ChannelCallback(func(channel string) {
+		assert.Equal(fmt.Sprintf("ch%d", count), channel)
+		count++
+	}).Execute()
+	assert.Nil(err)
+	assert.Equal(10000, count)
+	assert.Equal("cg", res.ChannelGroup)
+	assert.Nil(res.Channels)
+
+	res, _, err = pn.ListChannelsInChannelGroup().ChannelGroup("cg").Execute()
+	assert.Nil(err)
+	assert.Equal(10000, len(res.Channels))
 }
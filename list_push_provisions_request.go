@@ -7,6 +7,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"time"
 
 	"github.com/pubnub/go/pnerr"
 	"github.com/pubnub/go/utils"
@@ -56,6 +57,12 @@ func (b *listPushProvisionsRequestBuilder) DeviceIDForPush(
 	return b
 }
 
+// Transport sets the Transport for the ListPushProvisions request.
+func (b *listPushProvisionsRequestBuilder) Transport(tr http.RoundTripper) *listPushProvisionsRequestBuilder {
+	b.opts.Transport = tr
+	return b
+}
+
 // QueryParam accepts a map, the keys and values of the map are passed as the query string parameters of the URL called by the API.
 func (b *listPushProvisionsRequestBuilder) QueryParam(queryParam map[string]string) *listPushProvisionsRequestBuilder {
 	b.opts.QueryParam = queryParam
@@ -63,6 +70,18 @@ func (b *listPushProvisionsRequestBuilder) QueryParam(queryParam map[string]stri
 	return b
 }
 
+// Timeout sets the timeout for the ListPushProvisions request.
+func (b *listPushProvisionsRequestBuilder) Timeout(timeout time.Duration) *listPushProvisionsRequestBuilder {
+	b.opts.Timeout = timeout
+
+	return b
+}
+
+// SignedURL returns the URL of the ListPushProvisions request without executing it.
+func (b *listPushProvisionsRequestBuilder) SignedURL() (string, error) {
+	return signedURL(b.opts)
+}
+
 // Execute runs the List Push Provisions request.
 func (b *listPushProvisionsRequestBuilder) Execute() (
 	*ListPushProvisionsRequestResponse, StatusResponse, error) {
@@ -106,6 +125,7 @@ type listPushProvisionsRequestOpts struct {
 
 	DeviceIDForPush string
 	QueryParam      map[string]string
+	Timeout         time.Duration
 	Transport       http.RoundTripper
 
 	ctx Context
@@ -151,7 +171,7 @@ func (o *listPushProvisionsRequestOpts) buildPath() (string, error) {
 }
 
 func (o *listPushProvisionsRequestOpts) buildQuery() (*url.Values, error) {
-	q := defaultQuery(o.pubnub.Config.UUID, o.pubnub.telemetryManager)
+	q := defaultQuery(o.pubnub.Config, o.pubnub.telemetryManager)
 	q.Set("type", o.PushType.String())
 	SetQueryParam(q, o.QueryParam)
 	return q, nil
@@ -182,9 +202,21 @@ func (o *listPushProvisionsRequestOpts) connectTimeout() int {
 }
 
 func (o *listPushProvisionsRequestOpts) operationType() OperationType {
-	return PNRemoveGroupOperation
+	return PNPushNotificationsEnabledChannelsOperation
 }
 
 func (o *listPushProvisionsRequestOpts) telemetryManager() *TelemetryManager {
 	return o.pubnub.telemetryManager
 }
+
+func (o *listPushProvisionsRequestOpts) tokenManager() *TokenManager {
+	return o.pubnub.tokenManager
+}
+
+func (o *listPushProvisionsRequestOpts) timeout() time.Duration {
+	return o.Timeout
+}
+
+func (o *listPushProvisionsRequestOpts) transport() http.RoundTripper {
+	return o.Transport
+}
@@ -146,5 +146,5 @@ func TestListPushProvisionsValidateSubscribeKey(t *testing.T) {
 		pubnub:          pn,
 	}
 
-	assert.Equal("pubnub/validation: pubnub: \x0e: Missing Subscribe Key", opts.validate().Error())
+	assert.Equal("pubnub/validation: pubnub: List Push Enabled Channels: Missing Subscribe Key", opts.validate().Error())
 }
@@ -31,14 +31,20 @@ type ListenerManager struct {
 	sync.RWMutex
 	ctx                  Context
 	listeners            map[*Listener]bool
+	messageQueues        map[*Listener]*messageQueue
 	exitListener         chan bool
 	exitListenerAnnounce chan bool
 	pubnub               *PubNub
+
+	// the filters have their own lock, the listeners lock is held while the announcements block
+	filterMutex    sync.RWMutex
+	messageFilters []func(PNMessage) bool
 }
 
 func newListenerManager(ctx Context, pn *PubNub) *ListenerManager {
 	return &ListenerManager{
 		listeners:            make(map[*Listener]bool, 2),
+		messageQueues:        make(map[*Listener]*messageQueue, 2),
 		ctx:                  ctx,
 		exitListener:         make(chan bool),
 		exitListenerAnnounce: make(chan bool),
@@ -46,22 +52,73 @@ func newListenerManager(ctx Context, pn *PubNub) *ListenerManager {
 	}
 }
 
+// messageQueue holds the messages not yet delivered to a listener, a single goroutine
+// sends them in the order they were announced.
+type messageQueue struct {
+	sync.Mutex
+	pending []*PNMessage
+	wake    chan struct{}
+	stop    chan struct{}
+}
+
+func newMessageQueue() *messageQueue {
+	return &messageQueue{
+		wake: make(chan struct{}, 1),
+		stop: make(chan struct{}),
+	}
+}
+
+func (q *messageQueue) push(message *PNMessage) {
+	q.Lock()
+	q.pending = append(q.pending, message)
+	q.Unlock()
+
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (q *messageQueue) pop() (*PNMessage, bool) {
+	q.Lock()
+	defer q.Unlock()
+
+	if len(q.pending) == 0 {
+		return nil, false
+	}
+	message := q.pending[0]
+	q.pending[0] = nil
+	q.pending = q.pending[1:]
+
+	return message, true
+}
+
 func (m *ListenerManager) addListener(listener *Listener) {
 	m.Lock()
 
 	m.listeners[listener] = true
+	if _, ok := m.messageQueues[listener]; !ok {
+		queue := newMessageQueue()
+		m.messageQueues[listener] = queue
+		go m.deliverMessages(listener, queue, m.exitListenerAnnounce)
+	}
 	m.Unlock()
 }
 
+// removeListener is called with the lock held.
 func (m *ListenerManager) removeListener(listener *Listener) {
 	delete(m.listeners, listener)
+	if queue, ok := m.messageQueues[listener]; ok {
+		close(queue.stop)
+		delete(m.messageQueues, listener)
+	}
 }
 
 func (m *ListenerManager) removeAllListeners() {
 	m.Lock()
 	m.pubnub.Config.Log.Println("in removeAllListeners")
 	for l := range m.listeners {
-		delete(m.listeners, l)
+		m.removeListener(l)
 	}
 	m.Unlock()
 }
@@ -85,20 +142,63 @@ func (m *ListenerManager) announceStatus(status *PNStatus) {
 	}()
 }
 
+func (m *ListenerManager) addMessageFilter(filter func(PNMessage) bool) {
+	m.filterMutex.Lock()
+	m.messageFilters = append(m.messageFilters, filter)
+	m.filterMutex.Unlock()
+}
+
+// acceptMessage returns true when the message passes all the filters.
+func (m *ListenerManager) acceptMessage(message *PNMessage) bool {
+	m.filterMutex.RLock()
+	defer m.filterMutex.RUnlock()
+
+	for _, filter := range m.messageFilters {
+		if !filter(*message) {
+			return false
+		}
+	}
+
+	return true
+}
+
 func (m *ListenerManager) announceMessage(message *PNMessage) {
-	go func() {
-		m.RLock()
-	AnnounceMessageLabel:
-		for l := range m.listeners {
+	if !m.acceptMessage(message) {
+		m.pubnub.Config.Log.Println("announceMessage: message dropped by a filter")
+		return
+	}
+	m.RLock()
+	for l := range m.listeners {
+		m.messageQueues[l].push(message)
+	}
+	m.RUnlock()
+}
+
+// deliverMessages sends the queued messages to the listener one at a time, so that they
+// arrive in the order they were announced, until the listener is removed or the manager exits.
+func (m *ListenerManager) deliverMessages(l *Listener, queue *messageQueue, exit chan bool) {
+	for {
+		message, ok := queue.pop()
+		if !ok {
 			select {
-			case <-m.exitListenerAnnounce:
+			case <-exit:
 				m.pubnub.Config.Log.Println("announceMessage exitListenerAnnounce")
-				break AnnounceMessageLabel
-			case l.Message <- message:
+				return
+			case <-queue.stop:
+				return
+			case <-queue.wake:
 			}
+			continue
 		}
-		m.RUnlock()
-	}()
+		select {
+		case <-exit:
+			m.pubnub.Config.Log.Println("announceMessage exitListenerAnnounce")
+			return
+		case <-queue.stop:
+			return
+		case l.Message <- message:
+		}
+	}
 }
 
 func (m *ListenerManager) announceSignal(message *PNMessage) {
@@ -204,6 +304,7 @@ type PNStatus struct {
 	ClientRequest         interface{} // Should be same for non-google environment
 	AffectedChannels      []string
 	AffectedChannelGroups []string
+	CurrentTimetoken      int64 // Timetoken the subscribe loop resumes from, set on PNReconnectedCategory.
 }
 
 // PNMessage is the Message Response for Subscribe
@@ -216,6 +317,7 @@ type PNMessage struct {
 	Subscription      string
 	Publisher         string
 	Timetoken         int64
+	CustomMessageType string
 }
 
 // PNPresence is the Message Response for Presence
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"time"
 
 	"github.com/pubnub/go/pnerr"
 	"github.com/pubnub/go/utils"
@@ -72,12 +73,24 @@ func (b *messageCountsBuilder) QueryParam(queryParam map[string]string) *message
 	return b
 }
 
+// Timeout sets the timeout for the MessageCounts request.
+func (b *messageCountsBuilder) Timeout(timeout time.Duration) *messageCountsBuilder {
+	b.opts.Timeout = timeout
+
+	return b
+}
+
 // Transport sets the Transport for the MessageCounts request.
 func (b *messageCountsBuilder) Transport(tr http.RoundTripper) *messageCountsBuilder {
 	b.opts.Transport = tr
 	return b
 }
 
+// SignedURL returns the URL of the MessageCounts request without executing it.
+func (b *messageCountsBuilder) SignedURL() (string, error) {
+	return signedURL(b.opts)
+}
+
 // Execute runs the MessageCounts request.
 func (b *messageCountsBuilder) Execute() (*MessageCountsResponse, StatusResponse, error) {
 	rawJSON, status, err := executeRequest(b.opts)
@@ -96,6 +109,7 @@ type messageCountsOpts struct {
 	ChannelsTimetoken []int64
 
 	QueryParam map[string]string
+	Timeout    time.Duration
 
 	// nil hacks
 	Transport http.RoundTripper
@@ -136,7 +150,7 @@ func (o *messageCountsOpts) validate() error {
 }
 
 func (o *messageCountsOpts) buildPath() (string, error) {
-	channels := utils.JoinChannels(o.Channels)
+	channels := utils.JoinChannels(o.pubnub.Config.channelNames(o.Channels))
 
 	return fmt.Sprintf(messageCountsPath,
 		o.pubnub.Config.SubscribeKey,
@@ -144,7 +158,7 @@ func (o *messageCountsOpts) buildPath() (string, error) {
 }
 
 func (o *messageCountsOpts) buildQuery() (*url.Values, error) {
-	q := defaultQuery(o.pubnub.Config.UUID, o.pubnub.telemetryManager)
+	q := defaultQuery(o.pubnub.Config, o.pubnub.telemetryManager)
 
 	if (o.ChannelsTimetoken != nil) && (len(o.ChannelsTimetoken) == 1) {
 		q.Set("timetoken", strconv.FormatInt(o.ChannelsTimetoken[0], 10))
@@ -158,6 +172,7 @@ func (o *messageCountsOpts) buildQuery() (*url.Values, error) {
 		q.Set("channelsTimetoken", "")
 	}
 
+	o.pubnub.tokenManager.setAuthParamForChannels(q, o.Channels, nil)
 	SetQueryParam(q, o.QueryParam)
 
 	return q, nil
@@ -195,13 +210,25 @@ func (o *messageCountsOpts) telemetryManager() *TelemetryManager {
 	return o.pubnub.telemetryManager
 }
 
+func (o *messageCountsOpts) tokenManager() *TokenManager {
+	return o.pubnub.tokenManager
+}
+
+func (o *messageCountsOpts) timeout() time.Duration {
+	return o.Timeout
+}
+
+func (o *messageCountsOpts) transport() http.RoundTripper {
+	return o.Transport
+}
+
 // MessageCountsResponse is the response to MessageCounts request. It contains a map of type MessageCountsResponseItem
 type MessageCountsResponse struct {
 	Channels map[string]int
 }
 
-//http://ps.pndsn.com/v3/history/sub-key/demo/message-counts/my-channel,my-channel1?timestamp=1549982652&pnsdk=PubNub-Go/4.1.6&uuid=pn-82f145ea-adc3-4917-a11d-76a957347a82&timetoken=15499825804610610&channelsTimetoken=15499825804610610,15499925804610615&auth=akey&signature=pVDVge_suepcOlSMllpsXg_jpOjtEpW7B3HHFaViI4s=
-//{"status": 200, "error": false, "error_message": "", "channels": {"my-channel1":1,"my-channel":2}}
+// http://ps.pndsn.com/v3/history/sub-key/demo/message-counts/my-channel,my-channel1?timestamp=1549982652&pnsdk=PubNub-Go/4.1.6&uuid=pn-82f145ea-adc3-4917-a11d-76a957347a82&timetoken=15499825804610610&channelsTimetoken=15499825804610610,15499925804610615&auth=akey&signature=pVDVge_suepcOlSMllpsXg_jpOjtEpW7B3HHFaViI4s=
+// {"status": 200, "error": false, "error_message": "", "channels": {"my-channel1":1,"my-channel":2}}
 func newMessageCountsResponse(jsonBytes []byte, o *messageCountsOpts,
 	status StatusResponse) (*MessageCountsResponse, StatusResponse, error) {
 
@@ -1,16 +1,306 @@
 package pubnub
 
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pubnub/go/pnerr"
+	"github.com/pubnub/go/utils"
+)
+
+// objectsMaxLimit is the max Limit of the Objects list endpoints.
+const objectsMaxLimit = 100
+
+// validateObjectsLimit returns a validation error when the limit is not between 1 and objectsMaxLimit,
+// 0 is allowed and uses the server default.
+func validateObjectsLimit(o endpointOpts, limit int) error {
+	if limit < 0 || limit > objectsMaxLimit {
+		return newValidationError(o, fmt.Sprintf("%s %d: must be between 1 and %d", StrInvalidLimit, limit, objectsMaxLimit))
+	}
+
+	return nil
+}
+
+// objectsETagOnlyFields is the projection requested by ETagOnly on GetUser and GetSpace.
+const objectsETagOnlyFields = "id,eTag,updated"
+
+// objectsIDsOnlyFields is the projection requested by IDsOnly on GetUsers and GetSpaces.
+const objectsIDsOnlyFields = "id"
+
+// objectsIDsResponse is the list response parsed with IDsOnly, the objects are not deserialized.
+type objectsIDsResponse struct {
+	Data []struct {
+		ID string `json:"id"`
+	} `json:"data"`
+	TotalCount int    `json:"totalCount"`
+	Next       string `json:"next"`
+	Prev       string `json:"prev"`
+}
+
+func (r *objectsIDsResponse) ids() []string {
+	ids := make([]string, len(r.Data))
+	for i, d := range r.Data {
+		ids[i] = d.ID
+	}
+	return ids
+}
+
+var (
+	userInclude        = EnumArrayToStringArray([]PNUserSpaceInclude{PNUserSpaceCustom, PNUserMemberships, PNUserMembershipsSpace})
+	spaceInclude       = EnumArrayToStringArray([]PNUserSpaceInclude{PNUserSpaceCustom})
+	membersInclude     = EnumArrayToStringArray([]PNMembersInclude{PNMembersCustom, PNMembersUser, PNMembersUserCustom, PNMembersUserStatus, PNMembersUserType})
+	membershipsInclude = EnumArrayToStringArray([]PNMembershipsInclude{PNMembershipsCustom, PNMembershipsSpace, PNMembershipsSpaceCustom, PNMembershipsSpaceStatus, PNMembershipsSpaceType})
+)
+
+func stringInSlice(s string, list []string) bool {
+	for _, l := range list {
+		if l == s {
+			return true
+		}
+	}
+
+	return false
+}
+
+// validateObjectsInclude returns a validation error naming the first include which is not in known.
+func validateObjectsInclude(o endpointOpts, include, known []string) error {
+	for _, i := range include {
+		if !stringInSlice(i, known) {
+			return newValidationError(o, fmt.Sprintf("%s %s: must be one of %s", StrInvalidInclude, i, strings.Join(known, ", ")))
+		}
+	}
+
+	return nil
+}
+
+// PNObjectsChangeResult is the outcome of an add, update or remove of ManageMembers and ManageMemberships.
+// Applied is true when an added or updated ID is in the returned Data or a removed ID is not,
+// it is only conclusive when the response has no more pages.
+type PNObjectsChangeResult struct {
+	ID        string
+	Operation string
+	Applied   bool
+}
+
+// validateObjectsChanges returns a validation error when an ID is both removed and added or updated.
+func validateObjectsChanges(o endpointOpts, add, update, remove []string) error {
+	for _, id := range remove {
+		if stringInSlice(id, add) || stringInSlice(id, update) {
+			return newValidationError(o, fmt.Sprintf("%s %s: must not be both removed and added or updated", StrConflictingChange, id))
+		}
+	}
+
+	return nil
+}
+
+// objectsChangeResults returns the results of the changes, data holds the IDs of the returned Data.
+func objectsChangeResults(add, update, remove, data []string) []PNObjectsChangeResult {
+	results := make([]PNObjectsChangeResult, 0, len(add)+len(update)+len(remove))
+	for _, id := range add {
+		results = append(results, PNObjectsChangeResult{ID: id, Operation: "add", Applied: stringInSlice(id, data)})
+	}
+	for _, id := range update {
+		results = append(results, PNObjectsChangeResult{ID: id, Operation: "update", Applied: stringInSlice(id, data)})
+	}
+	for _, id := range remove {
+		results = append(results, PNObjectsChangeResult{ID: id, Operation: "remove", Applied: !stringInSlice(id, data)})
+	}
+	return results
+}
+
+// sortObjectsInclude returns the include without duplicates in the order of known.
+func sortObjectsInclude(include, known []string) []string {
+	if len(include) == 0 {
+		return include
+	}
+	sorted := []string{}
+	for _, k := range known {
+		if stringInSlice(k, include) {
+			sorted = append(sorted, k)
+		}
+	}
+
+	return sorted
+}
+
+// setObjectsListQuery sets the paging and include params shared by the Objects list endpoints.
+func setObjectsListQuery(q *url.Values, include []string, limit int, start, end string, count bool) {
+	if include != nil {
+		q.Set("include", string(utils.JoinChannels(include)))
+	}
+
+	if limit > 0 {
+		q.Set("limit", strconv.Itoa(limit))
+	}
+
+	if start != "" {
+		q.Set("start", start)
+	}
+
+	if count {
+		q.Set("count", "1")
+	} else {
+		q.Set("count", "0")
+	}
+
+	if end != "" {
+		q.Set("end", end)
+	}
+}
+
+// setObjectsSortQuery sets the sort param, with stableSort the unique id is appended
+// as the last sort key so the results with equal sort keys keep their order across the pages.
+func setObjectsSortQuery(q *url.Values, sort []string, stableSort bool) {
+	if len(sort) == 0 {
+		return
+	}
+	keys := append([]string{}, sort...)
+	if stableSort {
+		hasID := false
+		for _, k := range keys {
+			if strings.SplitN(k, ":", 2)[0] == "id" {
+				hasID = true
+			}
+		}
+		if !hasID {
+			keys = append(keys, "id:asc")
+		}
+	}
+	q.Set("sort", strings.Join(keys, ","))
+}
+
+// PNObjectsError is the error returned by the Objects requests when the server responds with an error body.
+type PNObjectsError struct {
+	*pnerr.ServerError
+	Message string
+	Source  string
+	Details []PNObjectsErrorDetails
+}
+
+// Unwrap returns the *pnerr.ServerError of the response, errors.As finds it through the PNObjectsError.
+func (e *PNObjectsError) Unwrap() error {
+	return e.ServerError
+}
+
+// PNObjectsErrorDetails names the request field which failed the server validation.
+type PNObjectsErrorDetails struct {
+	Message      string `json:"message"`
+	Location     string `json:"location"`
+	LocationType string `json:"locationType"`
+}
+
+// newObjectsError parses the error body of a *pnerr.ServerError into a *PNObjectsError,
+// other errors and bodies without an error envelope are returned as is.
+func newObjectsError(err error) error {
+	e, ok := err.(*pnerr.ServerError)
+	if !ok {
+		return err
+	}
+
+	var envelope struct {
+		Error *struct {
+			Message string                  `json:"message"`
+			Source  string                  `json:"source"`
+			Details []PNObjectsErrorDetails `json:"details"`
+		} `json:"error"`
+	}
+	if json.Unmarshal(e.Body, &envelope) != nil || envelope.Error == nil {
+		return err
+	}
+
+	return &PNObjectsError{
+		ServerError: e,
+		Message:     envelope.Error.Message,
+		Source:      envelope.Error.Source,
+		Details:     envelope.Error.Details,
+	}
+}
+
+// PNObjectsPreconditionError is the error returned by the Objects requests sent with IfMatchesETag
+// when the server responds 412, the object was changed since the ETag was read.
+type PNObjectsPreconditionError struct {
+	*pnerr.ServerError
+	ETag string
+}
+
+func (e *PNObjectsPreconditionError) Error() string {
+	return fmt.Sprintf("pubnub/objects: the eTag %s does not match the current version: %s", e.ETag, e.ServerError.Error())
+}
+
+// Unwrap returns the *pnerr.ServerError of the 412 response.
+func (e *PNObjectsPreconditionError) Unwrap() error {
+	return e.ServerError
+}
+
+// newObjectsPreconditionError maps a 412 response of a request sent with the If-Match eTag to a *PNObjectsPreconditionError,
+// the other errors are parsed by newObjectsError.
+func newObjectsPreconditionError(err error, eTag string) error {
+	if e, ok := err.(*pnerr.ServerError); ok && eTag != "" && e.StatusCode == http.StatusPreconditionFailed {
+		return &PNObjectsPreconditionError{ServerError: e, ETag: eTag}
+	}
+
+	return newObjectsError(err)
+}
+
+// objectsIfMatchHeaders returns the If-Match header of the conditional Objects requests.
+func objectsIfMatchHeaders(eTag string) map[string]string {
+	if eTag == "" {
+		return nil
+	}
+
+	return map[string]string{"If-Match": eTag}
+}
+
+// objectExists maps the error of a Get User or Get Space request to the existence of the object.
+func objectExists(err error) (bool, error) {
+	if err == nil {
+		return true, nil
+	}
+	if e, ok := err.(*pnerr.ServerError); ok && e.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+	if e, ok := err.(*PNObjectsError); ok && e.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+
+	return false, err
+}
+
+// parseObjectsTime parses the Created and Updated timestamps, e.g. 2019-08-20T13:26:19.140324Z.
+func parseObjectsTime(value string) (time.Time, error) {
+	return time.Parse(time.RFC3339Nano, value)
+}
+
 // PNUser is the Objects API user struct
 type PNUser struct {
-	ID         string                 `json:"id"`
-	Name       string                 `json:"name"`
-	ExternalID string                 `json:"externalId"`
-	ProfileURL string                 `json:"profileUrl"`
-	Email      string                 `json:"email"`
-	Created    string                 `json:"created"`
-	Updated    string                 `json:"updated"`
-	ETag       string                 `json:"eTag"`
-	Custom     map[string]interface{} `json:"custom"`
+	ID          string                 `json:"id"`
+	Name        string                 `json:"name"`
+	ExternalID  string                 `json:"externalId"`
+	ProfileURL  string                 `json:"profileUrl"`
+	Email       string                 `json:"email"`
+	Status      string                 `json:"status"`
+	Type        string                 `json:"type"`
+	Created     string                 `json:"created"`
+	Updated     string                 `json:"updated"`
+	ETag        string                 `json:"eTag"`
+	Custom      map[string]interface{} `json:"custom"`
+	Memberships []PNMemberships        `json:"memberships"`
+}
+
+// CreatedTime returns the Created timestamp of the user parsed as RFC3339 with the server nanoseconds.
+func (u *PNUser) CreatedTime() (time.Time, error) {
+	return parseObjectsTime(u.Created)
+}
+
+// UpdatedTime returns the Updated timestamp of the user parsed as RFC3339 with the server nanoseconds.
+func (u *PNUser) UpdatedTime() (time.Time, error) {
+	return parseObjectsTime(u.Updated)
 }
 
 // PNSpace is the Objects API space struct
@@ -18,12 +308,24 @@ type PNSpace struct {
 	ID          string                 `json:"id"`
 	Name        string                 `json:"name"`
 	Description string                 `json:"description"`
+	Status      string                 `json:"status"`
+	Type        string                 `json:"type"`
 	Created     string                 `json:"created"`
 	Updated     string                 `json:"updated"`
 	ETag        string                 `json:"eTag"`
 	Custom      map[string]interface{} `json:"custom"`
 }
 
+// CreatedTime returns the Created timestamp of the space parsed as RFC3339 with the server nanoseconds.
+func (s *PNSpace) CreatedTime() (time.Time, error) {
+	return parseObjectsTime(s.Created)
+}
+
+// UpdatedTime returns the Updated timestamp of the space parsed as RFC3339 with the server nanoseconds.
+func (s *PNSpace) UpdatedTime() (time.Time, error) {
+	return parseObjectsTime(s.Updated)
+}
+
 // PNMembers is the Objects API Members struct
 type PNMembers struct {
 	ID      string                 `json:"id"`
@@ -34,6 +336,21 @@ type PNMembers struct {
 	Custom  map[string]interface{} `json:"custom"`
 }
 
+// UUID returns the User of the member, it names the User with the UUID vocabulary of the Objects v2 API.
+func (m *PNMembers) UUID() *PNUser {
+	return &m.User
+}
+
+// CreatedTime returns the Created timestamp of the member parsed as RFC3339 with the server nanoseconds.
+func (m *PNMembers) CreatedTime() (time.Time, error) {
+	return parseObjectsTime(m.Created)
+}
+
+// UpdatedTime returns the Updated timestamp of the member parsed as RFC3339 with the server nanoseconds.
+func (m *PNMembers) UpdatedTime() (time.Time, error) {
+	return parseObjectsTime(m.Updated)
+}
+
 // PNMemberships is the Objects API Memberships struct
 type PNMemberships struct {
 	ID      string                 `json:"id"`
@@ -44,6 +361,21 @@ type PNMemberships struct {
 	Custom  map[string]interface{} `json:"custom"`
 }
 
+// Channel returns the Space of the membership, it names the Space with the Channel vocabulary of the Objects v2 API.
+func (m *PNMemberships) Channel() *PNSpace {
+	return &m.Space
+}
+
+// CreatedTime returns the Created timestamp of the membership parsed as RFC3339 with the server nanoseconds.
+func (m *PNMemberships) CreatedTime() (time.Time, error) {
+	return parseObjectsTime(m.Created)
+}
+
+// UpdatedTime returns the Updated timestamp of the membership parsed as RFC3339 with the server nanoseconds.
+func (m *PNMemberships) UpdatedTime() (time.Time, error) {
+	return parseObjectsTime(m.Updated)
+}
+
 // PNMembersInput is the Objects API Members input struct used to add members
 type PNMembersInput struct {
 	ID     string                 `json:"id"`
@@ -84,3 +416,50 @@ type PNObjectsResponse struct {
 	Custom      map[string]interface{} `json:"custom"`
 	Data        map[string]interface{} `json:"data"`
 }
+
+// objectsFilterKey matches the custom keys which can be used in a filter expression.
+var objectsFilterKey = regexp.MustCompile(`^[A-Za-z0-9_]+$`)
+
+// customEqualsFilter returns the filter expression matching the custom field key with value,
+// the other values than numbers and bools are quoted with their backslashes and quotes escaped.
+func customEqualsFilter(key string, value interface{}) string {
+	switch v := value.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64, bool:
+		return fmt.Sprintf("custom.%s == %v", key, v)
+	default:
+		quoted := strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(fmt.Sprint(v))
+		return fmt.Sprintf(`custom.%s == "%s"`, key, quoted)
+	}
+}
+
+// joinObjectsFilters combines the filter expressions with &&, each one is wrapped in parentheses when there are
+// several so that an expression using || only applies to its own clauses.
+func joinObjectsFilters(filters []string) string {
+	if len(filters) == 1 {
+		return filters[0]
+	}
+	clauses := make([]string, len(filters))
+	for i, filter := range filters {
+		clauses[i] = "(" + filter + ")"
+	}
+	return strings.Join(clauses, " && ")
+}
+
+// objectsUpdatedSinceSort orders the objects of UpdatedSince by their update, id breaks the ties.
+var objectsUpdatedSinceSort = []string{"updated:asc"}
+
+// updatedSinceFilter returns the filter expression matching the objects updated at or after since.
+func updatedSinceFilter(since time.Time) string {
+	return fmt.Sprintf("updated >= \"%s\"", since.UTC().Format(time.RFC3339Nano))
+}
+
+// validateObjectsFilterKeys returns a validation error naming the first custom key which can't be used in a filter.
+func validateObjectsFilterKeys(o endpointOpts, keys []string) error {
+	for _, key := range keys {
+		if !objectsFilterKey.MatchString(key) {
+			return newValidationError(o, fmt.Sprintf("%s %s: custom keys may only contain letters, digits and underscores", StrInvalidFilter, key))
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,40 @@
+package pubnub
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObjectsListLimit(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+
+	endpoints := []struct {
+		name string
+		opts func(limit int) endpointOpts
+	}{
+		{"Get Users", func(limit int) endpointOpts { return newGetUsersBuilder(pn).Limit(limit).opts }},
+		{"Get Spaces", func(limit int) endpointOpts { return newGetSpacesBuilder(pn).Limit(limit).opts }},
+		{"Get Members", func(limit int) endpointOpts { return newGetMembersBuilder(pn).Limit(limit).opts }},
+		{"Get Memberships", func(limit int) endpointOpts { return newGetMembershipsBuilder(pn).Limit(limit).opts }},
+	}
+
+	for _, e := range endpoints {
+		o := e.opts(0)
+		assert.Nil(o.validate(), e.name)
+		u, _ := o.buildQuery()
+		assert.Equal("", u.Get("limit"), e.name)
+
+		o = e.opts(100)
+		assert.Nil(o.validate(), e.name)
+		u, _ = o.buildQuery()
+		assert.Equal("100", u.Get("limit"), e.name)
+
+		for _, limit := range []int{101, -1} {
+			err := e.opts(limit).validate()
+			assert.Equal(fmt.Sprintf("pubnub/validation: pubnub: %s: Invalid Limit %d: must be between 1 and 100", e.name, limit), err.Error())
+		}
+	}
+}
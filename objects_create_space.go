@@ -7,6 +7,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"time"
 
 	"github.com/pubnub/go/pnerr"
 	"github.com/pubnub/go/utils"
@@ -90,17 +91,43 @@ func (b *createSpaceBuilder) QueryParam(queryParam map[string]string) *createSpa
 	return b
 }
 
+// Timeout sets the timeout for the createSpace request.
+func (b *createSpaceBuilder) Timeout(timeout time.Duration) *createSpaceBuilder {
+	b.opts.Timeout = timeout
+
+	return b
+}
+
+// RequestTimeout sets the timeout in seconds for the createSpace request.
+func (b *createSpaceBuilder) RequestTimeout(seconds int) *createSpaceBuilder {
+	b.opts.setRequestTimeout = true
+
+	return b.Timeout(time.Duration(seconds) * time.Second)
+}
+
+// ConnectTimeout sets the connect timeout in seconds for the createSpace request.
+func (b *createSpaceBuilder) ConnectTimeout(seconds int) *createSpaceBuilder {
+	b.opts.ConnectTimeout = seconds
+
+	return b
+}
+
 // Transport sets the Transport for the createSpace request.
 func (b *createSpaceBuilder) Transport(tr http.RoundTripper) *createSpaceBuilder {
 	b.opts.Transport = tr
 	return b
 }
 
+// SignedURL returns an error, the createSpace request sends a body which the URL doesn't carry.
+func (b *createSpaceBuilder) SignedURL() (string, error) {
+	return signedURL(b.opts)
+}
+
 // Execute runs the createSpace request.
 func (b *createSpaceBuilder) Execute() (*PNCreateSpaceResponse, StatusResponse, error) {
 	rawJSON, status, err := executeRequest(b.opts)
 	if err != nil {
-		return emptyPNCreateSpaceResponse, status, err
+		return emptyPNCreateSpaceResponse, status, newObjectsError(err)
 	}
 
 	return newPNCreateSpaceResponse(rawJSON, b.opts, status)
@@ -109,16 +136,21 @@ func (b *createSpaceBuilder) Execute() (*PNCreateSpaceResponse, StatusResponse,
 type createSpaceOpts struct {
 	pubnub *PubNub
 
-	Include     []string
-	ID          string
-	Name        string
-	Description string
-	Custom      map[string]interface{}
-	QueryParam  map[string]string
+	Include        []string
+	ID             string
+	Name           string
+	Description    string
+	Custom         map[string]interface{}
+	QueryParam     map[string]string
+	Timeout        time.Duration
+	ConnectTimeout int
 
 	Transport http.RoundTripper
 
 	ctx Context
+
+	// nil hacks
+	setRequestTimeout bool
 }
 
 func (o *createSpaceOpts) config() Config {
@@ -138,6 +170,14 @@ func (o *createSpaceOpts) validate() error {
 		return newValidationError(o, StrMissingSubKey)
 	}
 
+	if o.setRequestTimeout && o.Timeout <= 0 {
+		return newValidationError(o, fmt.Sprintf("%s %s: must be positive", StrInvalidTimeout, o.Timeout))
+	}
+
+	if err := validateObjectsInclude(o, o.Include, spaceInclude); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -148,10 +188,10 @@ func (o *createSpaceOpts) buildPath() (string, error) {
 
 func (o *createSpaceOpts) buildQuery() (*url.Values, error) {
 
-	q := defaultQuery(o.pubnub.Config.UUID, o.pubnub.telemetryManager)
+	q := defaultQuery(o.pubnub.Config, o.pubnub.telemetryManager)
 
 	if o.Include != nil {
-		q.Set("include", string(utils.JoinChannels(o.Include)))
+		q.Set("include", string(utils.JoinChannels(sortObjectsInclude(o.Include, spaceInclude))))
 	}
 	o.pubnub.tokenManager.SetAuthParan(q, o.ID, PNSpaces)
 	SetQueryParam(q, o.QueryParam)
@@ -194,6 +234,9 @@ func (o *createSpaceOpts) requestTimeout() int {
 }
 
 func (o *createSpaceOpts) connectTimeout() int {
+	if o.ConnectTimeout != 0 {
+		return o.ConnectTimeout
+	}
 	return o.pubnub.Config.ConnectTimeout
 }
 
@@ -205,6 +248,18 @@ func (o *createSpaceOpts) telemetryManager() *TelemetryManager {
 	return o.pubnub.telemetryManager
 }
 
+func (o *createSpaceOpts) tokenManager() *TokenManager {
+	return o.pubnub.tokenManager
+}
+
+func (o *createSpaceOpts) timeout() time.Duration {
+	return o.Timeout
+}
+
+func (o *createSpaceOpts) transport() http.RoundTripper {
+	return o.Transport
+}
+
 // PNCreateSpaceResponse is the Objects API Response for create space
 type PNCreateSpaceResponse struct {
 	status int     `json:"status"`
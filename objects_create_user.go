@@ -7,6 +7,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"time"
 
 	"github.com/pubnub/go/pnerr"
 	"github.com/pubnub/go/utils"
@@ -103,17 +104,43 @@ func (b *createUserBuilder) QueryParam(queryParam map[string]string) *createUser
 	return b
 }
 
+// Timeout sets the timeout for the createUser request.
+func (b *createUserBuilder) Timeout(timeout time.Duration) *createUserBuilder {
+	b.opts.Timeout = timeout
+
+	return b
+}
+
+// RequestTimeout sets the timeout in seconds for the createUser request.
+func (b *createUserBuilder) RequestTimeout(seconds int) *createUserBuilder {
+	b.opts.setRequestTimeout = true
+
+	return b.Timeout(time.Duration(seconds) * time.Second)
+}
+
+// ConnectTimeout sets the connect timeout in seconds for the createUser request.
+func (b *createUserBuilder) ConnectTimeout(seconds int) *createUserBuilder {
+	b.opts.ConnectTimeout = seconds
+
+	return b
+}
+
 // Transport sets the Transport for the createUser request.
 func (b *createUserBuilder) Transport(tr http.RoundTripper) *createUserBuilder {
 	b.opts.Transport = tr
 	return b
 }
 
+// SignedURL returns an error, the createUser request sends a body which the URL doesn't carry.
+func (b *createUserBuilder) SignedURL() (string, error) {
+	return signedURL(b.opts)
+}
+
 // Execute runs the createUser request.
 func (b *createUserBuilder) Execute() (*PNCreateUserResponse, StatusResponse, error) {
 	rawJSON, status, err := executeRequest(b.opts)
 	if err != nil {
-		return emptyPNCreateUserResponse, status, err
+		return emptyPNCreateUserResponse, status, newObjectsError(err)
 	}
 
 	return newPNCreateUserResponse(rawJSON, b.opts, status)
@@ -122,18 +149,23 @@ func (b *createUserBuilder) Execute() (*PNCreateUserResponse, StatusResponse, er
 type createUserOpts struct {
 	pubnub *PubNub
 
-	Include    []string
-	ID         string
-	Name       string
-	ExternalID string
-	ProfileURL string
-	Email      string
-	Custom     map[string]interface{}
-	QueryParam map[string]string
+	Include        []string
+	ID             string
+	Name           string
+	ExternalID     string
+	ProfileURL     string
+	Email          string
+	Custom         map[string]interface{}
+	QueryParam     map[string]string
+	Timeout        time.Duration
+	ConnectTimeout int
 
 	Transport http.RoundTripper
 
 	ctx Context
+
+	// nil hacks
+	setRequestTimeout bool
 }
 
 func (o *createUserOpts) config() Config {
@@ -153,6 +185,14 @@ func (o *createUserOpts) validate() error {
 		return newValidationError(o, StrMissingSubKey)
 	}
 
+	if o.setRequestTimeout && o.Timeout <= 0 {
+		return newValidationError(o, fmt.Sprintf("%s %s: must be positive", StrInvalidTimeout, o.Timeout))
+	}
+
+	if err := validateObjectsInclude(o, o.Include, userInclude); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -163,10 +203,10 @@ func (o *createUserOpts) buildPath() (string, error) {
 
 func (o *createUserOpts) buildQuery() (*url.Values, error) {
 
-	q := defaultQuery(o.pubnub.Config.UUID, o.pubnub.telemetryManager)
+	q := defaultQuery(o.pubnub.Config, o.pubnub.telemetryManager)
 
 	if o.Include != nil {
-		q.Set("include", string(utils.JoinChannels(o.Include)))
+		q.Set("include", string(utils.JoinChannels(sortObjectsInclude(o.Include, userInclude))))
 	}
 	o.pubnub.tokenManager.SetAuthParan(q, o.ID, PNUsers)
 
@@ -212,6 +252,9 @@ func (o *createUserOpts) requestTimeout() int {
 }
 
 func (o *createUserOpts) connectTimeout() int {
+	if o.ConnectTimeout != 0 {
+		return o.ConnectTimeout
+	}
 	return o.pubnub.Config.ConnectTimeout
 }
 
@@ -223,6 +266,18 @@ func (o *createUserOpts) telemetryManager() *TelemetryManager {
 	return o.pubnub.telemetryManager
 }
 
+func (o *createUserOpts) tokenManager() *TokenManager {
+	return o.pubnub.tokenManager
+}
+
+func (o *createUserOpts) timeout() time.Duration {
+	return o.Timeout
+}
+
+func (o *createUserOpts) transport() http.RoundTripper {
+	return o.Transport
+}
+
 // PNCreateUserResponse is the Objects API Response for create user
 type PNCreateUserResponse struct {
 	status int    `json:"status"`
@@ -2,6 +2,7 @@ package pubnub
 
 import (
 	"fmt"
+	"net/http"
 	"testing"
 
 	h "github.com/pubnub/go/tests/helpers"
@@ -109,3 +110,19 @@ func TestCreateUserResponseValuePass(t *testing.T) {
 
 	assert.Nil(err)
 }
+
+func TestCreateUserObjectsError(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+	body := `{"status":400,"error":{"message":"Invalid request input.","source":"objects","details":[{"message":"Must be a non-empty string.","location":"id","locationType":"body"}]}}`
+	pn.SetClient(&http.Client{Transport: newStubTransport(400, body)})
+
+	_, _, err := pn.CreateUser().ID("").Name("name").Execute()
+	e, ok := err.(*PNObjectsError)
+	assert.True(ok)
+	assert.Equal(400, e.StatusCode)
+	assert.Equal("Invalid request input.", e.Message)
+	assert.Equal("objects", e.Source)
+	assert.Equal([]PNObjectsErrorDetails{{Message: "Must be a non-empty string.", Location: "id", LocationType: "body"}}, e.Details)
+	assert.Equal(e.ServerError, e.Unwrap())
+}
@@ -7,6 +7,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"time"
 
 	"github.com/pubnub/go/pnerr"
 )
@@ -54,29 +55,88 @@ func (b *deleteSpaceBuilder) QueryParam(queryParam map[string]string) *deleteSpa
 	return b
 }
 
+// Timeout sets the timeout for the deleteSpace request.
+func (b *deleteSpaceBuilder) Timeout(timeout time.Duration) *deleteSpaceBuilder {
+	b.opts.Timeout = timeout
+
+	return b
+}
+
+// RequestTimeout sets the timeout in seconds for the deleteSpace request.
+func (b *deleteSpaceBuilder) RequestTimeout(seconds int) *deleteSpaceBuilder {
+	b.opts.setRequestTimeout = true
+
+	return b.Timeout(time.Duration(seconds) * time.Second)
+}
+
+// ConnectTimeout sets the connect timeout in seconds for the deleteSpace request.
+func (b *deleteSpaceBuilder) ConnectTimeout(seconds int) *deleteSpaceBuilder {
+	b.opts.ConnectTimeout = seconds
+
+	return b
+}
+
+// IncludeMemberships when true removes the members of the space before it is deleted.
+// The server does not cascade the delete, the members are removed with Manage Members requests.
+func (b *deleteSpaceBuilder) IncludeMemberships(include bool) *deleteSpaceBuilder {
+	b.opts.IncludeMemberships = include
+
+	return b
+}
+
 // Transport sets the Transport for the deleteSpace request.
 func (b *deleteSpaceBuilder) Transport(tr http.RoundTripper) *deleteSpaceBuilder {
 	b.opts.Transport = tr
 	return b
 }
 
+// SignedURL returns the URL of the deleteSpace request without executing it.
+func (b *deleteSpaceBuilder) SignedURL() (string, error) {
+	return signedURL(b.opts)
+}
+
 // Execute runs the deleteSpace request.
 func (b *deleteSpaceBuilder) Execute() (*PNDeleteSpaceResponse, StatusResponse, error) {
+	removed := 0
+	if b.opts.IncludeMemberships {
+		var status StatusResponse
+		var err error
+		removed, status, err = b.opts.removeMembers()
+		if err != nil {
+			return &PNDeleteSpaceResponse{MembershipsRemoved: removed}, status, err
+		}
+	}
+
 	rawJSON, status, err := executeRequest(b.opts)
 	if err != nil {
-		return emptyPNDeleteSpaceResponse, status, err
+		if b.opts.IncludeMemberships {
+			return &PNDeleteSpaceResponse{MembershipsRemoved: removed}, status, newObjectsError(err)
+		}
+		return emptyPNDeleteSpaceResponse, status, newObjectsError(err)
 	}
 
-	return newPNDeleteSpaceResponse(rawJSON, b.opts, status)
+	resp, status, err := newPNDeleteSpaceResponse(rawJSON, b.opts, status)
+	if err != nil {
+		return resp, status, err
+	}
+	resp.MembershipsRemoved = removed
+
+	return resp, status, nil
 }
 
 type deleteSpaceOpts struct {
-	pubnub     *PubNub
-	ID         string
-	QueryParam map[string]string
-	Transport  http.RoundTripper
+	pubnub             *PubNub
+	ID                 string
+	IncludeMemberships bool
+	QueryParam         map[string]string
+	Timeout            time.Duration
+	ConnectTimeout     int
+	Transport          http.RoundTripper
 
 	ctx Context
+
+	// nil hacks
+	setRequestTimeout bool
 }
 
 func (o *deleteSpaceOpts) config() Config {
@@ -96,6 +156,10 @@ func (o *deleteSpaceOpts) validate() error {
 		return newValidationError(o, StrMissingSubKey)
 	}
 
+	if o.setRequestTimeout && o.Timeout <= 0 {
+		return newValidationError(o, fmt.Sprintf("%s %s: must be positive", StrInvalidTimeout, o.Timeout))
+	}
+
 	return nil
 }
 
@@ -106,7 +170,7 @@ func (o *deleteSpaceOpts) buildPath() (string, error) {
 
 func (o *deleteSpaceOpts) buildQuery() (*url.Values, error) {
 
-	q := defaultQuery(o.pubnub.Config.UUID, o.pubnub.telemetryManager)
+	q := defaultQuery(o.pubnub.Config, o.pubnub.telemetryManager)
 	o.pubnub.tokenManager.SetAuthParan(q, o.ID, PNSpaces)
 	SetQueryParam(q, o.QueryParam)
 
@@ -135,6 +199,9 @@ func (o *deleteSpaceOpts) requestTimeout() int {
 }
 
 func (o *deleteSpaceOpts) connectTimeout() int {
+	if o.ConnectTimeout != 0 {
+		return o.ConnectTimeout
+	}
 	return o.pubnub.Config.ConnectTimeout
 }
 
@@ -146,10 +213,25 @@ func (o *deleteSpaceOpts) telemetryManager() *TelemetryManager {
 	return o.pubnub.telemetryManager
 }
 
+func (o *deleteSpaceOpts) tokenManager() *TokenManager {
+	return o.pubnub.tokenManager
+}
+
+func (o *deleteSpaceOpts) timeout() time.Duration {
+	return o.Timeout
+}
+
+func (o *deleteSpaceOpts) transport() http.RoundTripper {
+	return o.Transport
+}
+
 // PNDeleteSpaceResponse is the Objects API Response for delete space
 type PNDeleteSpaceResponse struct {
 	status int         `json:"status"`
 	Data   interface{} `json:"data"`
+	// MembershipsRemoved is the number of members removed with IncludeMemberships,
+	// it is also set when Execute fails after removing some of them.
+	MembershipsRemoved int `json:"-"`
 }
 
 func newPNDeleteSpaceResponse(jsonBytes []byte, o *deleteSpaceOpts,
@@ -167,3 +249,37 @@ func newPNDeleteSpaceResponse(jsonBytes []byte, o *deleteSpaceOpts,
 
 	return resp, status, nil
 }
+
+// removeMembers removes all the members of the space, it returns the number of members removed.
+func (o *deleteSpaceOpts) removeMembers() (int, StatusResponse, error) {
+	var removes []PNMembersRemove
+	start := ""
+	for {
+		res, status, err := newGetMembersBuilderWithContext(o.pubnub, o.ctx).SpaceID(o.ID).
+			Limit(objectsMaxLimit).Start(start).Timeout(o.Timeout).ConnectTimeout(o.ConnectTimeout).Transport(o.Transport).Execute()
+		if err != nil {
+			return 0, status, err
+		}
+		for _, m := range res.Data {
+			removes = append(removes, PNMembersRemove{ID: m.ID})
+		}
+		if res.Next == "" || len(res.Data) == 0 {
+			break
+		}
+		start = res.Next
+	}
+
+	for i := 0; i < len(removes); i += objectsMaxLimit {
+		end := i + objectsMaxLimit
+		if end > len(removes) {
+			end = len(removes)
+		}
+		_, status, err := newManageMembersBuilderWithContext(o.pubnub, o.ctx).SpaceID(o.ID).
+			Remove(removes[i:end]).Timeout(o.Timeout).ConnectTimeout(o.ConnectTimeout).Transport(o.Transport).Execute()
+		if err != nil {
+			return i, status, err
+		}
+	}
+
+	return len(removes), StatusResponse{}, nil
+}
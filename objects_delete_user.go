@@ -7,6 +7,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"time"
 
 	"github.com/pubnub/go/pnerr"
 )
@@ -54,30 +55,89 @@ func (b *deleteUserBuilder) QueryParam(queryParam map[string]string) *deleteUser
 	return b
 }
 
+// Timeout sets the timeout for the deleteUser request.
+func (b *deleteUserBuilder) Timeout(timeout time.Duration) *deleteUserBuilder {
+	b.opts.Timeout = timeout
+
+	return b
+}
+
+// RequestTimeout sets the timeout in seconds for the deleteUser request.
+func (b *deleteUserBuilder) RequestTimeout(seconds int) *deleteUserBuilder {
+	b.opts.setRequestTimeout = true
+
+	return b.Timeout(time.Duration(seconds) * time.Second)
+}
+
+// ConnectTimeout sets the connect timeout in seconds for the deleteUser request.
+func (b *deleteUserBuilder) ConnectTimeout(seconds int) *deleteUserBuilder {
+	b.opts.ConnectTimeout = seconds
+
+	return b
+}
+
+// IncludeMemberships when true removes the memberships of the user before it is deleted.
+// The server does not cascade the delete, the memberships are removed with Manage Memberships requests.
+func (b *deleteUserBuilder) IncludeMemberships(include bool) *deleteUserBuilder {
+	b.opts.IncludeMemberships = include
+
+	return b
+}
+
 // Transport sets the Transport for the deleteUser request.
 func (b *deleteUserBuilder) Transport(tr http.RoundTripper) *deleteUserBuilder {
 	b.opts.Transport = tr
 	return b
 }
 
+// SignedURL returns the URL of the deleteUser request without executing it.
+func (b *deleteUserBuilder) SignedURL() (string, error) {
+	return signedURL(b.opts)
+}
+
 // Execute runs the deleteUser request.
 func (b *deleteUserBuilder) Execute() (*PNDeleteUserResponse, StatusResponse, error) {
+	removed := 0
+	if b.opts.IncludeMemberships {
+		var status StatusResponse
+		var err error
+		removed, status, err = b.opts.removeMemberships()
+		if err != nil {
+			return &PNDeleteUserResponse{MembershipsRemoved: removed}, status, err
+		}
+	}
+
 	rawJSON, status, err := executeRequest(b.opts)
 	if err != nil {
-		return emptyPNDeleteUserResponse, status, err
+		if b.opts.IncludeMemberships {
+			return &PNDeleteUserResponse{MembershipsRemoved: removed}, status, newObjectsError(err)
+		}
+		return emptyPNDeleteUserResponse, status, newObjectsError(err)
 	}
 
-	return newPNDeleteUserResponse(rawJSON, b.opts, status)
+	resp, status, err := newPNDeleteUserResponse(rawJSON, b.opts, status)
+	if err != nil {
+		return resp, status, err
+	}
+	resp.MembershipsRemoved = removed
+
+	return resp, status, nil
 }
 
 type deleteUserOpts struct {
-	pubnub     *PubNub
-	ID         string
-	QueryParam map[string]string
+	pubnub             *PubNub
+	ID                 string
+	IncludeMemberships bool
+	QueryParam         map[string]string
+	Timeout            time.Duration
+	ConnectTimeout     int
 
 	Transport http.RoundTripper
 
 	ctx Context
+
+	// nil hacks
+	setRequestTimeout bool
 }
 
 func (o *deleteUserOpts) config() Config {
@@ -97,6 +157,10 @@ func (o *deleteUserOpts) validate() error {
 		return newValidationError(o, StrMissingSubKey)
 	}
 
+	if o.setRequestTimeout && o.Timeout <= 0 {
+		return newValidationError(o, fmt.Sprintf("%s %s: must be positive", StrInvalidTimeout, o.Timeout))
+	}
+
 	return nil
 }
 
@@ -107,7 +171,7 @@ func (o *deleteUserOpts) buildPath() (string, error) {
 
 func (o *deleteUserOpts) buildQuery() (*url.Values, error) {
 
-	q := defaultQuery(o.pubnub.Config.UUID, o.pubnub.telemetryManager)
+	q := defaultQuery(o.pubnub.Config, o.pubnub.telemetryManager)
 	o.pubnub.tokenManager.SetAuthParan(q, o.ID, PNUsers)
 	SetQueryParam(q, o.QueryParam)
 
@@ -136,6 +200,9 @@ func (o *deleteUserOpts) requestTimeout() int {
 }
 
 func (o *deleteUserOpts) connectTimeout() int {
+	if o.ConnectTimeout != 0 {
+		return o.ConnectTimeout
+	}
 	return o.pubnub.Config.ConnectTimeout
 }
 
@@ -147,10 +214,25 @@ func (o *deleteUserOpts) telemetryManager() *TelemetryManager {
 	return o.pubnub.telemetryManager
 }
 
+func (o *deleteUserOpts) tokenManager() *TokenManager {
+	return o.pubnub.tokenManager
+}
+
+func (o *deleteUserOpts) timeout() time.Duration {
+	return o.Timeout
+}
+
+func (o *deleteUserOpts) transport() http.RoundTripper {
+	return o.Transport
+}
+
 // PNDeleteUserResponse is the Objects API Response for delete user
 type PNDeleteUserResponse struct {
 	status int         `json:"status"`
 	Data   interface{} `json:"data"`
+	// MembershipsRemoved is the number of memberships removed with IncludeMemberships,
+	// it is also set when Execute fails after removing some of them.
+	MembershipsRemoved int `json:"-"`
 }
 
 func newPNDeleteUserResponse(jsonBytes []byte, o *deleteUserOpts,
@@ -168,3 +250,37 @@ func newPNDeleteUserResponse(jsonBytes []byte, o *deleteUserOpts,
 
 	return resp, status, nil
 }
+
+// removeMemberships removes all the memberships of the user, it returns the number of memberships removed.
+func (o *deleteUserOpts) removeMemberships() (int, StatusResponse, error) {
+	var removes []PNMembershipsRemove
+	start := ""
+	for {
+		res, status, err := newGetMembershipsBuilderWithContext(o.pubnub, o.ctx).UserID(o.ID).
+			Limit(objectsMaxLimit).Start(start).Timeout(o.Timeout).ConnectTimeout(o.ConnectTimeout).Transport(o.Transport).Execute()
+		if err != nil {
+			return 0, status, err
+		}
+		for _, m := range res.Data {
+			removes = append(removes, PNMembershipsRemove{ID: m.ID})
+		}
+		if res.Next == "" || len(res.Data) == 0 {
+			break
+		}
+		start = res.Next
+	}
+
+	for i := 0; i < len(removes); i += objectsMaxLimit {
+		end := i + objectsMaxLimit
+		if end > len(removes) {
+			end = len(removes)
+		}
+		_, status, err := newManageMembershipsBuilderWithContext(o.pubnub, o.ctx).UserID(o.ID).
+			Remove(removes[i:end]).Timeout(o.Timeout).ConnectTimeout(o.ConnectTimeout).Transport(o.Transport).Execute()
+		if err != nil {
+			return i, status, err
+		}
+	}
+
+	return len(removes), StatusResponse{}, nil
+}
@@ -1,7 +1,11 @@
 package pubnub
 
 import (
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
 	"testing"
 
 	h "github.com/pubnub/go/tests/helpers"
@@ -81,3 +85,84 @@ func TestDeleteUserResponseValuePass(t *testing.T) {
 
 	assert.Nil(err)
 }
+
+func TestDeleteUserIncludeMemberships(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+	// the memberships of the user are served from memory
+	memberships := []string{"space1", "space2"}
+	deleted := false
+	tr := newTestTransport(func(req *http.Request) (*http.Response, error) {
+		body := `{"status":200,"data":null}`
+		switch {
+		case req.Method == "GET" && strings.HasSuffix(req.URL.Opaque, "/spaces"):
+			data := []PNMemberships{}
+			for _, id := range memberships {
+				data = append(data, PNMemberships{ID: id})
+			}
+			b, _ := json.Marshal(map[string]interface{}{"status": 200, "data": data})
+			body = string(b)
+		case req.Method == "PATCH":
+			var changeSet PNMembershipsInputChangeSet
+			b, _ := ioutil.ReadAll(req.Body)
+			json.Unmarshal(b, &changeSet)
+			for _, r := range changeSet.Remove {
+				for i, id := range memberships {
+					if id == r.ID {
+						memberships = append(memberships[:i], memberships[i+1:]...)
+						break
+					}
+				}
+			}
+		case req.Method == "DELETE":
+			deleted = true
+		}
+
+		return stubResponse(req, 200, body), nil
+	})
+	pn.SetClient(&http.Client{Transport: tr})
+
+	res, _, err := pn.DeleteUser().ID("user1").IncludeMemberships(true).Execute()
+	assert.Nil(err)
+	assert.Equal(2, res.MembershipsRemoved)
+	assert.Empty(memberships)
+	assert.True(deleted)
+
+	res2, _, err := pn.GetMemberships().UserID("user1").Execute()
+	assert.Nil(err)
+	assert.Empty(res2.Data)
+}
+
+func TestDeleteUserIncludeMembershipsPartialFailure(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+	data := make([]PNMemberships, objectsMaxLimit+50)
+	for i := range data {
+		data[i] = PNMemberships{ID: fmt.Sprintf("space%d", i)}
+	}
+	memberships, _ := json.Marshal(map[string]interface{}{"status": 200, "data": data})
+	// the second Manage Memberships request fails
+	patches := 0
+	deleted := false
+	tr := newTestTransport(func(req *http.Request) (*http.Response, error) {
+		switch req.Method {
+		case "GET":
+			return stubResponse(req, 200, string(memberships)), nil
+		case "PATCH":
+			patches++
+			if patches == 2 {
+				return stubResponse(req, 500, `{"status":500,"error":{"message":"Internal error."}}`), nil
+			}
+		case "DELETE":
+			deleted = true
+		}
+
+		return stubResponse(req, 200, `{"status":200,"data":null}`), nil
+	})
+	pn.SetClient(&http.Client{Transport: tr})
+
+	res, _, err := pn.DeleteUser().ID("user1").IncludeMemberships(true).Execute()
+	assert.NotNil(err)
+	assert.Equal(objectsMaxLimit, res.MembershipsRemoved)
+	assert.False(deleted)
+}
@@ -7,10 +7,9 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/url"
-	"strconv"
+	"time"
 
 	"github.com/pubnub/go/pnerr"
-	"github.com/pubnub/go/utils"
 )
 
 var emptyGetMembersResponse *PNGetMembersResponse
@@ -30,6 +29,7 @@ func newGetMembersBuilder(pubnub *PubNub) *getMembersBuilder {
 		},
 	}
 	builder.opts.Limit = membersLimit
+	builder.opts.StableSort = true
 
 	return &builder
 }
@@ -42,6 +42,7 @@ func newGetMembersBuilderWithContext(pubnub *PubNub,
 			ctx:    context,
 		},
 	}
+	builder.opts.StableSort = true
 
 	return &builder
 }
@@ -85,6 +86,28 @@ func (b *getMembersBuilder) Count(count bool) *getMembersBuilder {
 	return b
 }
 
+// Sort sets the sort keys of the results, as field or field:asc or field:desc.
+func (b *getMembersBuilder) Sort(sort []string) *getMembersBuilder {
+	b.opts.Sort = sort
+
+	return b
+}
+
+// StableSort appends id:asc to the sort keys when they don't include the unique id, on by default.
+// Without it the order of the results with equal sort keys may change between the pages.
+func (b *getMembersBuilder) StableSort(stableSort bool) *getMembersBuilder {
+	b.opts.StableSort = stableSort
+
+	return b
+}
+
+// CountOnly requests only the TotalCount, the Data in the response is empty.
+func (b *getMembersBuilder) CountOnly() *getMembersBuilder {
+	b.opts.CountOnly = true
+
+	return b
+}
+
 // QueryParam accepts a map, the keys and values of the map are passed as the query string parameters of the URL called by the API.
 func (b *getMembersBuilder) QueryParam(queryParam map[string]string) *getMembersBuilder {
 	b.opts.QueryParam = queryParam
@@ -92,35 +115,69 @@ func (b *getMembersBuilder) QueryParam(queryParam map[string]string) *getMembers
 	return b
 }
 
+// Timeout sets the timeout for the getMembers request.
+func (b *getMembersBuilder) Timeout(timeout time.Duration) *getMembersBuilder {
+	b.opts.Timeout = timeout
+
+	return b
+}
+
+// RequestTimeout sets the timeout in seconds for the getMembers request.
+func (b *getMembersBuilder) RequestTimeout(seconds int) *getMembersBuilder {
+	b.opts.setRequestTimeout = true
+
+	return b.Timeout(time.Duration(seconds) * time.Second)
+}
+
+// ConnectTimeout sets the connect timeout in seconds for the getMembers request.
+func (b *getMembersBuilder) ConnectTimeout(seconds int) *getMembersBuilder {
+	b.opts.ConnectTimeout = seconds
+
+	return b
+}
+
 // Transport sets the Transport for the getMembers request.
 func (b *getMembersBuilder) Transport(tr http.RoundTripper) *getMembersBuilder {
 	b.opts.Transport = tr
 	return b
 }
 
+// SignedURL returns the URL of the getMembers request without executing it.
+func (b *getMembersBuilder) SignedURL() (string, error) {
+	return signedURL(b.opts)
+}
+
 // Execute runs the getMembers request.
 func (b *getMembersBuilder) Execute() (*PNGetMembersResponse, StatusResponse, error) {
 	rawJSON, status, err := executeRequest(b.opts)
 	if err != nil {
-		return emptyGetMembersResponse, status, err
+		return emptyGetMembersResponse, status, newObjectsError(err)
 	}
 
 	return newPNGetMembersResponse(rawJSON, b.opts, status)
 }
 
 type getMembersOpts struct {
-	pubnub     *PubNub
-	ID         string
-	Limit      int
-	Include    []string
-	Start      string
-	End        string
-	Count      bool
-	QueryParam map[string]string
+	pubnub         *PubNub
+	ID             string
+	Limit          int
+	Include        []string
+	Start          string
+	End            string
+	Count          bool
+	CountOnly      bool
+	Sort           []string
+	StableSort     bool
+	QueryParam     map[string]string
+	Timeout        time.Duration
+	ConnectTimeout int
 
 	Transport http.RoundTripper
 
 	ctx Context
+
+	// nil hacks
+	setRequestTimeout bool
 }
 
 func (o *getMembersOpts) config() Config {
@@ -140,6 +197,18 @@ func (o *getMembersOpts) validate() error {
 		return newValidationError(o, StrMissingSubKey)
 	}
 
+	if o.setRequestTimeout && o.Timeout <= 0 {
+		return newValidationError(o, fmt.Sprintf("%s %s: must be positive", StrInvalidTimeout, o.Timeout))
+	}
+
+	if err := validateObjectsInclude(o, o.Include, membersInclude); err != nil {
+		return err
+	}
+
+	if err := validateObjectsLimit(o, o.Limit); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -150,27 +219,14 @@ func (o *getMembersOpts) buildPath() (string, error) {
 
 func (o *getMembersOpts) buildQuery() (*url.Values, error) {
 
-	q := defaultQuery(o.pubnub.Config.UUID, o.pubnub.telemetryManager)
+	q := defaultQuery(o.pubnub.Config, o.pubnub.telemetryManager)
 
-	if o.Include != nil {
-		q.Set("include", string(utils.JoinChannels(o.Include)))
-	}
-
-	q.Set("limit", strconv.Itoa(o.Limit))
-
-	if o.Start != "" {
-		q.Set("start", o.Start)
-	}
-
-	if o.Count {
+	setObjectsListQuery(q, sortObjectsInclude(o.Include, membersInclude), o.Limit, o.Start, o.End, o.Count)
+	if o.CountOnly {
+		q.Set("limit", "0")
 		q.Set("count", "1")
-	} else {
-		q.Set("count", "0")
-	}
-
-	if o.End != "" {
-		q.Set("end", o.End)
 	}
+	setObjectsSortQuery(q, o.Sort, o.StableSort)
 	o.pubnub.tokenManager.SetAuthParan(q, o.ID, PNSpaces)
 
 	SetQueryParam(q, o.QueryParam)
@@ -199,6 +255,9 @@ func (o *getMembersOpts) requestTimeout() int {
 }
 
 func (o *getMembersOpts) connectTimeout() int {
+	if o.ConnectTimeout != 0 {
+		return o.ConnectTimeout
+	}
 	return o.pubnub.Config.ConnectTimeout
 }
 
@@ -210,6 +269,18 @@ func (o *getMembersOpts) telemetryManager() *TelemetryManager {
 	return o.pubnub.telemetryManager
 }
 
+func (o *getMembersOpts) tokenManager() *TokenManager {
+	return o.pubnub.tokenManager
+}
+
+func (o *getMembersOpts) timeout() time.Duration {
+	return o.Timeout
+}
+
+func (o *getMembersOpts) transport() http.RoundTripper {
+	return o.Transport
+}
+
 // PNGetMembersResponse is the Objects API Response for Get Members
 type PNGetMembersResponse struct {
 	status     int         `json:"status"`
@@ -219,6 +290,16 @@ type PNGetMembersResponse struct {
 	Prev       string      `json:"prev"`
 }
 
+// HasMore returns true when the response has a Next cursor, pass it to Start to fetch the next page of members.
+func (r *PNGetMembersResponse) HasMore() bool {
+	return r.Next != ""
+}
+
+// IsEmpty returns true when the response has no members, Data is an empty slice rather than nil in that case.
+func (r *PNGetMembersResponse) IsEmpty() bool {
+	return len(r.Data) == 0
+}
+
 func newPNGetMembersResponse(jsonBytes []byte, o *getMembersOpts,
 	status StatusResponse) (*PNGetMembersResponse, StatusResponse, error) {
 
@@ -232,5 +313,9 @@ func newPNGetMembersResponse(jsonBytes []byte, o *getMembersOpts,
 		return emptyGetMembersResponse, status, e
 	}
 
+	if o.CountOnly || resp.Data == nil {
+		resp.Data = []PNMembers{}
+	}
+
 	return resp, status, nil
 }
@@ -121,3 +121,96 @@ func TestGetMembersResponseValuePass(t *testing.T) {
 
 	assert.Nil(err)
 }
+
+func TestGetMembersUUID(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+	opts := &getMembersOpts{
+		pubnub: pn,
+	}
+	jsonBytes := []byte(`{"status":200,"data":[{"id":"id0","user":{"id":"id0","name":"name"}}],"totalCount":1}`)
+
+	r, _, err := newPNGetMembersResponse(jsonBytes, opts, StatusResponse{})
+	assert.Nil(err)
+	assert.True(r.Data[0].UUID() == &r.Data[0].User)
+	assert.Equal("name", r.Data[0].UUID().Name)
+}
+
+func TestGetMembersCountOnly(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+
+	o := newGetMembersBuilderWithContext(pn, backgroundContext)
+
+	o.SpaceID("id0")
+	o.CountOnly()
+
+	u, err := o.opts.buildQuery()
+	assert.Nil(err)
+	assert.Equal("0", u.Get("limit"))
+	assert.Equal("1", u.Get("count"))
+
+	jsonBytes := []byte(`{"status":200,"data":[],"totalCount":5}`)
+
+	r, _, err := newPNGetMembersResponse(jsonBytes, o.opts, StatusResponse{})
+	assert.Nil(err)
+	assert.Empty(r.Data)
+	assert.Equal(5, r.TotalCount)
+}
+
+func TestGetMembersEmpty(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+
+	o := newGetMembersBuilder(pn)
+	o.SpaceID("id0")
+	o.Count(true)
+
+	jsonBytes := []byte(`{"status":200,"totalCount":0}`)
+
+	r, _, err := newPNGetMembersResponse(jsonBytes, o.opts, StatusResponse{})
+	assert.Nil(err)
+	assert.NotNil(r.Data)
+	assert.Empty(r.Data)
+	assert.True(r.IsEmpty())
+	assert.Equal(0, r.TotalCount)
+	assert.False(r.HasMore())
+}
+
+func TestGetMembersInclude(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+
+	o := newGetMembersBuilder(pn)
+	o.SpaceID("id0")
+	o.Include([]PNMembersInclude{PNMembersUserCustom, PNMembersCustom, PNMembersUserCustom, PNMembersUser})
+	assert.Nil(o.opts.validate())
+
+	u, err := o.opts.buildQuery()
+	assert.Nil(err)
+	assert.Equal("custom,user,user.custom", u.Get("include"))
+
+	o.Include([]PNMembersInclude{PNMembersCustom, PNMembersInclude(9)})
+	assert.Equal("pubnub/validation: pubnub: Get Members: Invalid Include PNMembersInclude(9): must be one of custom, user, user.custom, user.status, user.type", o.opts.validate().Error())
+}
+
+func TestGetMembersIncludeUserStatusType(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+
+	o := newGetMembersBuilder(pn)
+	o.SpaceID("id0")
+	o.Include([]PNMembersInclude{PNMembersUserType, PNMembersUser, PNMembersUserStatus})
+	assert.Nil(o.opts.validate())
+
+	u, err := o.opts.buildQuery()
+	assert.Nil(err)
+	assert.Equal("user,user.status,user.type", u.Get("include"))
+
+	jsonBytes := []byte(`{"status":200,"data":[{"id":"userid0","user":{"id":"userid0","name":"name","status":"away","type":"admin"}}]}`)
+
+	r, _, err := newPNGetMembersResponse(jsonBytes, o.opts, StatusResponse{})
+	assert.Nil(err)
+	assert.Equal("away", r.Data[0].User.Status)
+	assert.Equal("admin", r.Data[0].User.Type)
+}
@@ -7,10 +7,9 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/url"
-	"strconv"
+	"time"
 
 	"github.com/pubnub/go/pnerr"
-	"github.com/pubnub/go/utils"
 )
 
 var emptyGetMembershipsResponse *PNGetMembershipsResponse
@@ -30,6 +29,7 @@ func newGetMembershipsBuilder(pubnub *PubNub) *getMembershipsBuilder {
 		},
 	}
 	builder.opts.Limit = spaceMembershipLimit
+	builder.opts.StableSort = true
 
 	return &builder
 }
@@ -42,6 +42,7 @@ func newGetMembershipsBuilderWithContext(pubnub *PubNub,
 			ctx:    context,
 		},
 	}
+	builder.opts.StableSort = true
 
 	return &builder
 }
@@ -85,6 +86,28 @@ func (b *getMembershipsBuilder) Count(count bool) *getMembershipsBuilder {
 	return b
 }
 
+// Sort sets the sort keys of the results, as field or field:asc or field:desc.
+func (b *getMembershipsBuilder) Sort(sort []string) *getMembershipsBuilder {
+	b.opts.Sort = sort
+
+	return b
+}
+
+// StableSort appends id:asc to the sort keys when they don't include the unique id, on by default.
+// Without it the order of the results with equal sort keys may change between the pages.
+func (b *getMembershipsBuilder) StableSort(stableSort bool) *getMembershipsBuilder {
+	b.opts.StableSort = stableSort
+
+	return b
+}
+
+// CountOnly requests only the TotalCount, the Data in the response is empty.
+func (b *getMembershipsBuilder) CountOnly() *getMembershipsBuilder {
+	b.opts.CountOnly = true
+
+	return b
+}
+
 // QueryParam accepts a map, the keys and values of the map are passed as the query string parameters of the URL called by the API.
 func (b *getMembershipsBuilder) QueryParam(queryParam map[string]string) *getMembershipsBuilder {
 	b.opts.QueryParam = queryParam
@@ -92,35 +115,69 @@ func (b *getMembershipsBuilder) QueryParam(queryParam map[string]string) *getMem
 	return b
 }
 
+// Timeout sets the timeout for the getMemberships request.
+func (b *getMembershipsBuilder) Timeout(timeout time.Duration) *getMembershipsBuilder {
+	b.opts.Timeout = timeout
+
+	return b
+}
+
+// RequestTimeout sets the timeout in seconds for the getMemberships request.
+func (b *getMembershipsBuilder) RequestTimeout(seconds int) *getMembershipsBuilder {
+	b.opts.setRequestTimeout = true
+
+	return b.Timeout(time.Duration(seconds) * time.Second)
+}
+
+// ConnectTimeout sets the connect timeout in seconds for the getMemberships request.
+func (b *getMembershipsBuilder) ConnectTimeout(seconds int) *getMembershipsBuilder {
+	b.opts.ConnectTimeout = seconds
+
+	return b
+}
+
 // Transport sets the Transport for the getMemberships request.
 func (b *getMembershipsBuilder) Transport(tr http.RoundTripper) *getMembershipsBuilder {
 	b.opts.Transport = tr
 	return b
 }
 
+// SignedURL returns the URL of the getMemberships request without executing it.
+func (b *getMembershipsBuilder) SignedURL() (string, error) {
+	return signedURL(b.opts)
+}
+
 // Execute runs the getMemberships request.
 func (b *getMembershipsBuilder) Execute() (*PNGetMembershipsResponse, StatusResponse, error) {
 	rawJSON, status, err := executeRequest(b.opts)
 	if err != nil {
-		return emptyGetMembershipsResponse, status, err
+		return emptyGetMembershipsResponse, status, newObjectsError(err)
 	}
 
 	return newPNGetMembershipsResponse(rawJSON, b.opts, status)
 }
 
 type getMembershipsOpts struct {
-	pubnub     *PubNub
-	ID         string
-	Limit      int
-	Include    []string
-	Start      string
-	End        string
-	Count      bool
-	QueryParam map[string]string
+	pubnub         *PubNub
+	ID             string
+	Limit          int
+	Include        []string
+	Start          string
+	End            string
+	Count          bool
+	CountOnly      bool
+	Sort           []string
+	StableSort     bool
+	QueryParam     map[string]string
+	Timeout        time.Duration
+	ConnectTimeout int
 
 	Transport http.RoundTripper
 
 	ctx Context
+
+	// nil hacks
+	setRequestTimeout bool
 }
 
 func (o *getMembershipsOpts) config() Config {
@@ -140,6 +197,18 @@ func (o *getMembershipsOpts) validate() error {
 		return newValidationError(o, StrMissingSubKey)
 	}
 
+	if o.setRequestTimeout && o.Timeout <= 0 {
+		return newValidationError(o, fmt.Sprintf("%s %s: must be positive", StrInvalidTimeout, o.Timeout))
+	}
+
+	if err := validateObjectsInclude(o, o.Include, membershipsInclude); err != nil {
+		return err
+	}
+
+	if err := validateObjectsLimit(o, o.Limit); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -150,27 +219,14 @@ func (o *getMembershipsOpts) buildPath() (string, error) {
 
 func (o *getMembershipsOpts) buildQuery() (*url.Values, error) {
 
-	q := defaultQuery(o.pubnub.Config.UUID, o.pubnub.telemetryManager)
+	q := defaultQuery(o.pubnub.Config, o.pubnub.telemetryManager)
 
-	if o.Include != nil {
-		q.Set("include", string(utils.JoinChannels(o.Include)))
-	}
-
-	q.Set("limit", strconv.Itoa(o.Limit))
-
-	if o.Start != "" {
-		q.Set("start", o.Start)
-	}
-
-	if o.Count {
+	setObjectsListQuery(q, sortObjectsInclude(o.Include, membershipsInclude), o.Limit, o.Start, o.End, o.Count)
+	if o.CountOnly {
+		q.Set("limit", "0")
 		q.Set("count", "1")
-	} else {
-		q.Set("count", "0")
-	}
-
-	if o.End != "" {
-		q.Set("end", o.End)
 	}
+	setObjectsSortQuery(q, o.Sort, o.StableSort)
 	o.pubnub.tokenManager.SetAuthParan(q, o.ID, PNUsers)
 	SetQueryParam(q, o.QueryParam)
 
@@ -198,6 +254,9 @@ func (o *getMembershipsOpts) requestTimeout() int {
 }
 
 func (o *getMembershipsOpts) connectTimeout() int {
+	if o.ConnectTimeout != 0 {
+		return o.ConnectTimeout
+	}
 	return o.pubnub.Config.ConnectTimeout
 }
 
@@ -209,6 +268,18 @@ func (o *getMembershipsOpts) telemetryManager() *TelemetryManager {
 	return o.pubnub.telemetryManager
 }
 
+func (o *getMembershipsOpts) tokenManager() *TokenManager {
+	return o.pubnub.tokenManager
+}
+
+func (o *getMembershipsOpts) timeout() time.Duration {
+	return o.Timeout
+}
+
+func (o *getMembershipsOpts) transport() http.RoundTripper {
+	return o.Transport
+}
+
 // PNGetMembershipsResponse is the Objects API Response for Get Memberships
 type PNGetMembershipsResponse struct {
 	status     int             `json:"status"`
@@ -218,6 +289,16 @@ type PNGetMembershipsResponse struct {
 	Prev       string          `json:"prev"`
 }
 
+// HasMore returns true when the response has a Next cursor, pass it to Start to fetch the next page of memberships.
+func (r *PNGetMembershipsResponse) HasMore() bool {
+	return r.Next != ""
+}
+
+// IsEmpty returns true when the response has no memberships, Data is an empty slice rather than nil in that case.
+func (r *PNGetMembershipsResponse) IsEmpty() bool {
+	return len(r.Data) == 0
+}
+
 func newPNGetMembershipsResponse(jsonBytes []byte, o *getMembershipsOpts,
 	status StatusResponse) (*PNGetMembershipsResponse, StatusResponse, error) {
 
@@ -231,5 +312,9 @@ func newPNGetMembershipsResponse(jsonBytes []byte, o *getMembershipsOpts,
 		return emptyGetMembershipsResponse, status, e
 	}
 
+	if o.CountOnly || resp.Data == nil {
+		resp.Data = []PNMemberships{}
+	}
+
 	return resp, status, nil
 }
@@ -2,8 +2,12 @@ package pubnub
 
 import (
 	"fmt"
+	"math/rand"
+	"net/http"
 	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	h "github.com/pubnub/go/tests/helpers"
 	"github.com/pubnub/go/utils"
@@ -119,3 +123,206 @@ func TestGetMembershipsResponseValuePass(t *testing.T) {
 
 	assert.Nil(err)
 }
+
+func TestGetMembershipsCountOnly(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+
+	o := newGetMembershipsBuilderWithContext(pn, backgroundContext)
+
+	o.UserID("id0")
+	o.CountOnly()
+
+	u, err := o.opts.buildQuery()
+	assert.Nil(err)
+	assert.Equal("0", u.Get("limit"))
+	assert.Equal("1", u.Get("count"))
+
+	jsonBytes := []byte(`{"status":200,"data":[],"totalCount":5}`)
+
+	r, _, err := newPNGetMembershipsResponse(jsonBytes, o.opts, StatusResponse{})
+	assert.Nil(err)
+	assert.Empty(r.Data)
+	assert.Equal(5, r.TotalCount)
+}
+
+func TestGetMembershipsEmpty(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+
+	o := newGetMembershipsBuilder(pn)
+	o.UserID("id0")
+	o.Count(true)
+
+	jsonBytes := []byte(`{"status":200,"totalCount":0}`)
+
+	r, _, err := newPNGetMembershipsResponse(jsonBytes, o.opts, StatusResponse{})
+	assert.Nil(err)
+	assert.NotNil(r.Data)
+	assert.Empty(r.Data)
+	assert.True(r.IsEmpty())
+	assert.Equal(0, r.TotalCount)
+	assert.False(r.HasMore())
+}
+
+func TestGetMembershipsPaging(t *testing.T) {
+	assert := assert.New(t)
+	transport := newTestTransport(func(req *http.Request) (*http.Response, error) {
+		if req.URL.Query().Get("start") == "Mg" {
+			return stubResponse(req, 200, `{"status":200,"data":[{"id":"space2"}],"totalCount":3,"prev":"Mg"}`), nil
+		}
+		return stubResponse(req, 200, `{"status":200,"data":[{"id":"space0"},{"id":"space1"}],"totalCount":3,"next":"Mg"}`), nil
+	})
+	pn := NewPubNub(NewDemoConfig())
+	pn.SetClient(&http.Client{Transport: transport})
+
+	var ids []string
+	start := ""
+	for {
+		res, _, err := pn.GetMemberships().UserID("user0").Limit(2).Start(start).Execute()
+		assert.Nil(err)
+		assert.Equal(3, res.TotalCount)
+		for _, m := range res.Data {
+			ids = append(ids, m.ID)
+		}
+		if res.Next == "" {
+			assert.Equal("Mg", res.Prev)
+			break
+		}
+		start = res.Next
+	}
+
+	assert.Equal([]string{"", "Mg"}, transport.queries("start"))
+	assert.Equal([]string{"space0", "space1", "space2"}, ids)
+}
+
+func TestGetMembershipsStableSort(t *testing.T) {
+	assert := assert.New(t)
+	// the memberships share the same updated value, ties are returned in a random order unless id is one of the sort keys
+	transport := newTestTransport(func(req *http.Request) (*http.Response, error) {
+		ids := []string{"space0", "space1", "space2", "space3", "space4"}
+		if !strings.Contains(req.URL.Query().Get("sort"), "id:asc") {
+			shuffled := make([]string, len(ids))
+			for i, p := range rand.Perm(len(ids)) {
+				shuffled[i] = ids[p]
+			}
+			ids = shuffled
+		}
+
+		start, _ := strconv.Atoi(req.URL.Query().Get("start"))
+		limit, _ := strconv.Atoi(req.URL.Query().Get("limit"))
+		end := start + limit
+		next := strconv.Itoa(end)
+		if end >= len(ids) {
+			end = len(ids)
+			next = ""
+		}
+
+		data := []string{}
+		for _, id := range ids[start:end] {
+			data = append(data, fmt.Sprintf(`{"id":"%s","updated":"2019-08-20T13:26:24.07832Z"}`, id))
+		}
+		return stubResponse(req, 200, fmt.Sprintf(`{"status":200,"data":[%s],"next":"%s"}`, strings.Join(data, ","), next)), nil
+	})
+	pn := NewPubNub(NewDemoConfig())
+	pn.SetClient(&http.Client{Transport: transport})
+
+	fetchAll := func() []string {
+		var ids []string
+		start := ""
+		for {
+			res, _, err := pn.GetMemberships().UserID("user0").Limit(2).Start(start).Sort([]string{"updated:desc"}).Execute()
+			assert.Nil(err)
+			for _, m := range res.Data {
+				ids = append(ids, m.ID)
+			}
+			if res.Next == "" {
+				return ids
+			}
+			start = res.Next
+		}
+	}
+
+	first := fetchAll()
+	assert.Equal([]string{"space0", "space1", "space2", "space3", "space4"}, first)
+	assert.Equal(first, fetchAll())
+	assert.Equal("updated:desc,id:asc", transport.queries("sort")[0])
+
+	o := newGetMembershipsBuilder(pn)
+	o.Sort([]string{"updated:desc", "id:desc"})
+	u, _ := o.opts.buildQuery()
+	assert.Equal("updated:desc,id:desc", u.Get("sort"))
+
+	o.Sort([]string{"updated"}).StableSort(false)
+	u, _ = o.opts.buildQuery()
+	assert.Equal("updated", u.Get("sort"))
+
+	o.Sort(nil)
+	u, _ = o.opts.buildQuery()
+	assert.Equal("", u.Get("sort"))
+}
+
+func TestGetMembershipsChannel(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+	opts := &getMembershipsOpts{
+		pubnub: pn,
+	}
+	jsonBytes := []byte(`{"status":200,"data":[{"id":"id0","space":{"id":"id0","name":"name"}}],"totalCount":1}`)
+
+	r, _, err := newPNGetMembershipsResponse(jsonBytes, opts, StatusResponse{})
+	assert.Nil(err)
+	assert.True(r.Data[0].Channel() == &r.Data[0].Space)
+	assert.Equal("name", r.Data[0].Channel().Name)
+}
+
+func TestGetMembershipsTime(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+
+	o := newGetMembershipsBuilder(pn)
+	jsonBytes := []byte(`{"status":200,"data":[{"id":"spaceid0","space":{"id":"spaceid0","created":"2019-08-20T13:26:08.341297Z","updated":"2019-08-21T13:26:08Z"},"created":"2019-08-23T10:34:43.985462Z","updated":"2019-08-23T10:34:43.985462123Z"}]}`)
+
+	r, _, err := newPNGetMembershipsResponse(jsonBytes, o.opts, StatusResponse{})
+	assert.Nil(err)
+
+	created, err := r.Data[0].CreatedTime()
+	assert.Nil(err)
+	assert.Equal(time.Date(2019, 8, 23, 10, 34, 43, 985462000, time.UTC), created)
+
+	updated, err := r.Data[0].UpdatedTime()
+	assert.Nil(err)
+	assert.Equal(time.Date(2019, 8, 23, 10, 34, 43, 985462123, time.UTC), updated)
+
+	created, err = r.Data[0].Space.CreatedTime()
+	assert.Nil(err)
+	assert.Equal(time.Date(2019, 8, 20, 13, 26, 8, 341297000, time.UTC), created)
+
+	updated, err = r.Data[0].Space.UpdatedTime()
+	assert.Nil(err)
+	assert.Equal(time.Date(2019, 8, 21, 13, 26, 8, 0, time.UTC), updated)
+
+	_, err = (&PNUser{Created: "not a time"}).CreatedTime()
+	assert.NotNil(err)
+}
+
+func TestGetMembershipsIncludeSpaceStatusType(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+
+	o := newGetMembershipsBuilder(pn)
+	o.UserID("id0")
+	o.Include([]PNMembershipsInclude{PNMembershipsSpace, PNMembershipsSpaceStatus, PNMembershipsSpaceType})
+	assert.Nil(o.opts.validate())
+
+	u, err := o.opts.buildQuery()
+	assert.Nil(err)
+	assert.Equal("space,space.status,space.type", u.Get("include"))
+
+	jsonBytes := []byte(`{"status":200,"data":[{"id":"spaceid0","space":{"id":"spaceid0","name":"name","status":"archived","type":"public"}}]}`)
+
+	r, _, err := newPNGetMembershipsResponse(jsonBytes, o.opts, StatusResponse{})
+	assert.Nil(err)
+	assert.Equal("archived", r.Data[0].Space.Status)
+	assert.Equal("public", r.Data[0].Space.Type)
+}
@@ -7,6 +7,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"time"
 
 	"github.com/pubnub/go/pnerr"
 	"github.com/pubnub/go/utils"
@@ -55,6 +56,14 @@ func (b *getSpaceBuilder) ID(id string) *getSpaceBuilder {
 	return b
 }
 
+// ETagOnly requests only the ID, ETag and Updated fields of the space, the Include is not sent.
+// The other fields of Data, including Custom, are left zero-valued.
+func (b *getSpaceBuilder) ETagOnly() *getSpaceBuilder {
+	b.opts.ETagOnly = true
+
+	return b
+}
+
 // QueryParam accepts a map, the keys and values of the map are passed as the query string parameters of the URL called by the API.
 func (b *getSpaceBuilder) QueryParam(queryParam map[string]string) *getSpaceBuilder {
 	b.opts.QueryParam = queryParam
@@ -62,31 +71,63 @@ func (b *getSpaceBuilder) QueryParam(queryParam map[string]string) *getSpaceBuil
 	return b
 }
 
+// Timeout sets the timeout for the getSpace request.
+func (b *getSpaceBuilder) Timeout(timeout time.Duration) *getSpaceBuilder {
+	b.opts.Timeout = timeout
+
+	return b
+}
+
+// RequestTimeout sets the timeout in seconds for the getSpace request.
+func (b *getSpaceBuilder) RequestTimeout(seconds int) *getSpaceBuilder {
+	b.opts.setRequestTimeout = true
+
+	return b.Timeout(time.Duration(seconds) * time.Second)
+}
+
+// ConnectTimeout sets the connect timeout in seconds for the getSpace request.
+func (b *getSpaceBuilder) ConnectTimeout(seconds int) *getSpaceBuilder {
+	b.opts.ConnectTimeout = seconds
+
+	return b
+}
+
 // Transport sets the Transport for the getSpace request.
 func (b *getSpaceBuilder) Transport(tr http.RoundTripper) *getSpaceBuilder {
 	b.opts.Transport = tr
 	return b
 }
 
+// SignedURL returns the URL of the getSpace request without executing it.
+func (b *getSpaceBuilder) SignedURL() (string, error) {
+	return signedURL(b.opts)
+}
+
 // Execute runs the getSpace request.
 func (b *getSpaceBuilder) Execute() (*PNGetSpaceResponse, StatusResponse, error) {
 	rawJSON, status, err := executeRequest(b.opts)
 	if err != nil {
-		return emptyPNGetSpaceResponse, status, err
+		return emptyPNGetSpaceResponse, status, newObjectsError(err)
 	}
 
 	return newPNGetSpaceResponse(rawJSON, b.opts, status)
 }
 
 type getSpaceOpts struct {
-	pubnub     *PubNub
-	ID         string
-	Include    []string
-	QueryParam map[string]string
+	pubnub         *PubNub
+	ID             string
+	Include        []string
+	ETagOnly       bool
+	QueryParam     map[string]string
+	Timeout        time.Duration
+	ConnectTimeout int
 
 	Transport http.RoundTripper
 
 	ctx Context
+
+	// nil hacks
+	setRequestTimeout bool
 }
 
 func (o *getSpaceOpts) config() Config {
@@ -106,6 +147,14 @@ func (o *getSpaceOpts) validate() error {
 		return newValidationError(o, StrMissingSubKey)
 	}
 
+	if o.setRequestTimeout && o.Timeout <= 0 {
+		return newValidationError(o, fmt.Sprintf("%s %s: must be positive", StrInvalidTimeout, o.Timeout))
+	}
+
+	if err := validateObjectsInclude(o, o.Include, spaceInclude); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -116,10 +165,12 @@ func (o *getSpaceOpts) buildPath() (string, error) {
 
 func (o *getSpaceOpts) buildQuery() (*url.Values, error) {
 
-	q := defaultQuery(o.pubnub.Config.UUID, o.pubnub.telemetryManager)
+	q := defaultQuery(o.pubnub.Config, o.pubnub.telemetryManager)
 
-	if o.Include != nil {
-		q.Set("include", string(utils.JoinChannels(o.Include)))
+	if o.ETagOnly {
+		q.Set("fields", objectsETagOnlyFields)
+	} else if o.Include != nil {
+		q.Set("include", string(utils.JoinChannels(sortObjectsInclude(o.Include, spaceInclude))))
 	}
 	o.pubnub.tokenManager.SetAuthParan(q, o.ID, PNSpaces)
 	SetQueryParam(q, o.QueryParam)
@@ -148,6 +199,9 @@ func (o *getSpaceOpts) requestTimeout() int {
 }
 
 func (o *getSpaceOpts) connectTimeout() int {
+	if o.ConnectTimeout != 0 {
+		return o.ConnectTimeout
+	}
 	return o.pubnub.Config.ConnectTimeout
 }
 
@@ -159,6 +213,18 @@ func (o *getSpaceOpts) telemetryManager() *TelemetryManager {
 	return o.pubnub.telemetryManager
 }
 
+func (o *getSpaceOpts) tokenManager() *TokenManager {
+	return o.pubnub.tokenManager
+}
+
+func (o *getSpaceOpts) timeout() time.Duration {
+	return o.Timeout
+}
+
+func (o *getSpaceOpts) transport() http.RoundTripper {
+	return o.Transport
+}
+
 // PNGetSpaceResponse is the Objects API Response for Get Space
 type PNGetSpaceResponse struct {
 	status int     `json:"status"`
@@ -178,5 +244,13 @@ func newPNGetSpaceResponse(jsonBytes []byte, o *getSpaceOpts,
 		return emptyPNGetSpaceResponse, status, e
 	}
 
+	if o.ETagOnly {
+		resp.Data = PNSpace{
+			ID:      resp.Data.ID,
+			Updated: resp.Data.Updated,
+			ETag:    resp.Data.ETag,
+		}
+	}
+
 	return resp, status, nil
 }
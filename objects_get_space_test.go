@@ -2,6 +2,7 @@ package pubnub
 
 import (
 	"fmt"
+	"net/http"
 	"testing"
 
 	h "github.com/pubnub/go/tests/helpers"
@@ -99,3 +100,69 @@ func TestGetSpaceResponseValuePass(t *testing.T) {
 
 	assert.Nil(err)
 }
+
+func TestGetSpaceETagOnly(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+
+	o := newGetSpaceBuilder(pn)
+	o.ID("id0")
+	o.Include([]PNUserSpaceInclude{PNUserSpaceCustom})
+	o.ETagOnly()
+
+	u, err := o.opts.buildQuery()
+	assert.Nil(err)
+	assert.Equal("id,eTag,updated", u.Get("fields"))
+	assert.Equal("", u.Get("include"))
+
+	jsonBytes := []byte(`{"status":200,"data":{"id":"id0","updated":"2019-08-20T13:26:19.140324Z","eTag":"AbyT4v2p6K7fpQE"}}`)
+
+	r, _, err := newPNGetSpaceResponse(jsonBytes, o.opts, StatusResponse{})
+	assert.Nil(err)
+	assert.Equal("id0", r.Data.ID)
+	assert.Equal("2019-08-20T13:26:19.140324Z", r.Data.Updated)
+	assert.Equal("AbyT4v2p6K7fpQE", r.Data.ETag)
+	assert.Equal("", r.Data.Name)
+	assert.Nil(r.Data.Custom)
+}
+
+func TestGetSpaceInclude(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+
+	o := newGetSpaceBuilder(pn)
+	o.ID("id0")
+	o.Include([]PNUserSpaceInclude{PNUserSpaceCustom, PNUserSpaceCustom})
+	assert.Nil(o.opts.validate())
+
+	u, err := o.opts.buildQuery()
+	assert.Nil(err)
+	assert.Equal("custom", u.Get("include"))
+
+	o.Include([]PNUserSpaceInclude{PNUserSpaceInclude(0)})
+	assert.Contains(o.opts.validate().Error(), "Invalid Include PNUserSpaceInclude(0)")
+
+	// the memberships are only expanded on the Users
+	o.Include([]PNUserSpaceInclude{PNUserMemberships})
+	assert.Equal("pubnub/validation: pubnub: Get Space: Invalid Include memberships: must be one of custom", o.opts.validate().Error())
+}
+
+func TestSpaceExists(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+
+	pn.SetClient(&http.Client{Transport: newStubTransport(200, `{"status":200,"data":{"id":"id0"}}`)})
+	exists, err := pn.SpaceExists("id0")
+	assert.Nil(err)
+	assert.True(exists)
+
+	pn.SetClient(&http.Client{Transport: newStubTransport(404, `{"status":404,"error":{"message":"Requested object was not found."}}`)})
+	exists, err = pn.SpaceExists("id0")
+	assert.Nil(err)
+	assert.False(exists)
+
+	pn.SetClient(&http.Client{Transport: newStubTransport(500, `{"status":500}`)})
+	exists, err = pn.SpaceExists("id0")
+	assert.Contains(err.Error(), "500")
+	assert.False(exists)
+}
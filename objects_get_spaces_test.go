@@ -2,8 +2,10 @@ package pubnub
 
 import (
 	"fmt"
+	"net/http"
 	"strconv"
 	"testing"
+	"time"
 
 	h "github.com/pubnub/go/tests/helpers"
 	"github.com/pubnub/go/utils"
@@ -111,3 +113,116 @@ func TestGetSpacesResponseValuePass(t *testing.T) {
 
 	assert.Nil(err)
 }
+
+func TestGetSpacesIfNoneMatch(t *testing.T) {
+	assert := assert.New(t)
+	transport := newETagTransport()
+	pn := NewPubNub(NewDemoConfig())
+	pn.SetClient(&http.Client{Transport: transport})
+
+	res, _, err := pn.GetSpaces().Execute()
+	assert.Nil(err)
+	req, _ := transport.last()
+	assert.Equal("", req.Header.Get("If-None-Match"))
+	assert.False(res.NotModified)
+	assert.Equal("AbyT4v2p6K7fpQE", res.ETag)
+	assert.Equal(1, len(res.Data))
+
+	res, status, err := pn.GetSpaces().IfNoneMatch(res.ETag).Execute()
+	assert.Nil(err)
+	req, _ = transport.last()
+	assert.Equal("AbyT4v2p6K7fpQE", req.Header.Get("If-None-Match"))
+	assert.Equal(304, status.StatusCode)
+	assert.True(res.NotModified)
+	assert.Equal(0, len(res.Data))
+}
+
+func TestGetSpacesIDsOnly(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+
+	o := newGetSpacesBuilder(pn)
+	o.Include([]PNUserSpaceInclude{PNUserSpaceCustom})
+	o.Count(true)
+	o.IDsOnly()
+
+	u, err := o.opts.buildQuery()
+	assert.Nil(err)
+	assert.Equal("id", u.Get("fields"))
+	assert.Equal("", u.Get("include"))
+	assert.Equal("1", u.Get("count"))
+
+	jsonBytes := []byte(`{"status":200,"data":[{"id":"id0","custom":{"a":"b"}},{"id":"id1"}],"totalCount":2,"next":"Mg"}`)
+
+	r, _, err := newPNGetSpacesResponse(jsonBytes, o.opts, StatusResponse{})
+	assert.Nil(err)
+	assert.Equal([]string{"id0", "id1"}, r.IDs)
+	assert.Empty(r.Data)
+	assert.Equal(2, r.TotalCount)
+	assert.Equal("Mg", r.Next)
+}
+
+func TestGetSpacesReturnRaw(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+	body := `{"status":200,"data":[{"id":"id0","extra":{"a":"b"}}],"unknown":true}`
+	pn.SetClient(&http.Client{Transport: newStubTransport(200, body)})
+
+	res, _, err := pn.GetSpaces().ReturnRaw().Execute()
+	assert.Nil(err)
+	assert.Equal([]byte(body), res.RawJSON)
+	assert.Equal("id0", res.Data[0].ID)
+
+	res, _, err = pn.GetSpaces().Execute()
+	assert.Nil(err)
+	assert.Nil(res.RawJSON)
+}
+
+func TestGetSpacesQueryParity(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+
+	users := newGetUsersBuilder(pn)
+	users.Include([]PNUserSpaceInclude{PNUserSpaceCustom}).Limit(20).Start("MQ").End("Mg").Count(true)
+	spaces := newGetSpacesBuilder(pn)
+	spaces.Include([]PNUserSpaceInclude{PNUserSpaceCustom}).Limit(20).Start("MQ").End("Mg").Count(true)
+
+	u, err := users.opts.buildQuery()
+	assert.Nil(err)
+	s, err := spaces.opts.buildQuery()
+	assert.Nil(err)
+
+	for _, param := range []string{"include", "limit", "start", "end", "count"} {
+		assert.Equal(u.Get(param), s.Get(param), param)
+	}
+	assert.Equal("custom", s.Get("include"))
+	assert.Equal("20", s.Get("limit"))
+	assert.Equal("1", s.Get("count"))
+}
+
+func TestGetSpacesWhereCustomEquals(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+
+	o := pn.GetSpaces().WhereCustomEquals("public", true).WhereCustomEquals("owner", "o'neil")
+	assert.Equal(`(custom.public == true) && (custom.owner == "o'neil")`, o.opts.Filter)
+	assert.Nil(o.opts.validate())
+}
+
+func TestSpacesUpdatedSince(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+
+	since := time.Date(2019, 8, 20, 15, 26, 8, 341297000, time.FixedZone("CEST", 2*60*60))
+	o := pn.GetSpaces().WhereCustomEquals("a", "b").UpdatedSince(since)
+	assert.Equal(`(custom.a == "b") && (updated >= "2019-08-20T13:26:08.341297Z")`, o.opts.Filter)
+
+	query, err := o.opts.buildQuery()
+	assert.Nil(err)
+	assert.Equal(utils.URLEncode(o.opts.Filter), query.Get("filter"))
+	assert.Equal("updated:asc,id:asc", query.Get("sort"))
+
+	query, err = pn.GetSpaces().opts.buildQuery()
+	assert.Nil(err)
+	assert.Equal("", query.Get("sort"))
+}
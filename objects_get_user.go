@@ -7,6 +7,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"time"
 
 	"github.com/pubnub/go/pnerr"
 	"github.com/pubnub/go/utils"
@@ -55,6 +56,14 @@ func (b *getUserBuilder) ID(id string) *getUserBuilder {
 	return b
 }
 
+// ETagOnly requests only the ID, ETag and Updated fields of the user, the Include is not sent.
+// The other fields of Data, including Custom, are left zero-valued.
+func (b *getUserBuilder) ETagOnly() *getUserBuilder {
+	b.opts.ETagOnly = true
+
+	return b
+}
+
 // QueryParam accepts a map, the keys and values of the map are passed as the query string parameters of the URL called by the API.
 func (b *getUserBuilder) QueryParam(queryParam map[string]string) *getUserBuilder {
 	b.opts.QueryParam = queryParam
@@ -62,31 +71,63 @@ func (b *getUserBuilder) QueryParam(queryParam map[string]string) *getUserBuilde
 	return b
 }
 
+// Timeout sets the timeout for the getUser request.
+func (b *getUserBuilder) Timeout(timeout time.Duration) *getUserBuilder {
+	b.opts.Timeout = timeout
+
+	return b
+}
+
+// RequestTimeout sets the timeout in seconds for the getUser request.
+func (b *getUserBuilder) RequestTimeout(seconds int) *getUserBuilder {
+	b.opts.setRequestTimeout = true
+
+	return b.Timeout(time.Duration(seconds) * time.Second)
+}
+
+// ConnectTimeout sets the connect timeout in seconds for the getUser request.
+func (b *getUserBuilder) ConnectTimeout(seconds int) *getUserBuilder {
+	b.opts.ConnectTimeout = seconds
+
+	return b
+}
+
 // Transport sets the Transport for the getUser request.
 func (b *getUserBuilder) Transport(tr http.RoundTripper) *getUserBuilder {
 	b.opts.Transport = tr
 	return b
 }
 
+// SignedURL returns the URL of the getUser request without executing it.
+func (b *getUserBuilder) SignedURL() (string, error) {
+	return signedURL(b.opts)
+}
+
 // Execute runs the getUser request.
 func (b *getUserBuilder) Execute() (*PNGetUserResponse, StatusResponse, error) {
 	rawJSON, status, err := executeRequest(b.opts)
 	if err != nil {
-		return emptyPNGetUserResponse, status, err
+		return emptyPNGetUserResponse, status, newObjectsError(err)
 	}
 
 	return newPNGetUserResponse(rawJSON, b.opts, status)
 }
 
 type getUserOpts struct {
-	pubnub     *PubNub
-	ID         string
-	Include    []string
-	QueryParam map[string]string
+	pubnub         *PubNub
+	ID             string
+	Include        []string
+	ETagOnly       bool
+	QueryParam     map[string]string
+	Timeout        time.Duration
+	ConnectTimeout int
 
 	Transport http.RoundTripper
 
 	ctx Context
+
+	// nil hacks
+	setRequestTimeout bool
 }
 
 func (o *getUserOpts) config() Config {
@@ -106,6 +147,14 @@ func (o *getUserOpts) validate() error {
 		return newValidationError(o, StrMissingSubKey)
 	}
 
+	if o.setRequestTimeout && o.Timeout <= 0 {
+		return newValidationError(o, fmt.Sprintf("%s %s: must be positive", StrInvalidTimeout, o.Timeout))
+	}
+
+	if err := validateObjectsInclude(o, o.Include, userInclude); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -116,10 +165,12 @@ func (o *getUserOpts) buildPath() (string, error) {
 
 func (o *getUserOpts) buildQuery() (*url.Values, error) {
 
-	q := defaultQuery(o.pubnub.Config.UUID, o.pubnub.telemetryManager)
+	q := defaultQuery(o.pubnub.Config, o.pubnub.telemetryManager)
 
-	if o.Include != nil {
-		q.Set("include", string(utils.JoinChannels(o.Include)))
+	if o.ETagOnly {
+		q.Set("fields", objectsETagOnlyFields)
+	} else if o.Include != nil {
+		q.Set("include", string(utils.JoinChannels(sortObjectsInclude(o.Include, userInclude))))
 	}
 	o.pubnub.tokenManager.SetAuthParan(q, o.ID, PNUsers)
 
@@ -149,6 +200,9 @@ func (o *getUserOpts) requestTimeout() int {
 }
 
 func (o *getUserOpts) connectTimeout() int {
+	if o.ConnectTimeout != 0 {
+		return o.ConnectTimeout
+	}
 	return o.pubnub.Config.ConnectTimeout
 }
 
@@ -160,6 +214,18 @@ func (o *getUserOpts) telemetryManager() *TelemetryManager {
 	return o.pubnub.telemetryManager
 }
 
+func (o *getUserOpts) tokenManager() *TokenManager {
+	return o.pubnub.tokenManager
+}
+
+func (o *getUserOpts) timeout() time.Duration {
+	return o.Timeout
+}
+
+func (o *getUserOpts) transport() http.RoundTripper {
+	return o.Transport
+}
+
 // PNGetUserResponse is the Objects API Response for Get User
 type PNGetUserResponse struct {
 	status int    `json:"status"`
@@ -179,5 +245,13 @@ func newPNGetUserResponse(jsonBytes []byte, o *getUserOpts,
 		return emptyPNGetUserResponse, status, e
 	}
 
+	if o.ETagOnly {
+		resp.Data = PNUser{
+			ID:      resp.Data.ID,
+			Updated: resp.Data.Updated,
+			ETag:    resp.Data.ETag,
+		}
+	}
+
 	return resp, status, nil
 }
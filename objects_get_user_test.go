@@ -2,6 +2,7 @@ package pubnub
 
 import (
 	"fmt"
+	"net/http"
 	"testing"
 
 	h "github.com/pubnub/go/tests/helpers"
@@ -102,3 +103,72 @@ func TestGetUserResponseValuePass(t *testing.T) {
 
 	assert.Nil(err)
 }
+
+func TestGetUserIncludeMemberships(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+
+	o := newGetUserBuilder(pn)
+	o.ID("id0")
+	o.Include([]PNUserSpaceInclude{PNUserSpaceCustom, PNUserMemberships, PNUserMembershipsSpace})
+
+	u, err := o.opts.buildQuery()
+	assert.Nil(err)
+	assert.Equal("custom,memberships,memberships.space", u.Get("include"))
+
+	jsonBytes := []byte(`{"status":200,"data":{"id":"id0","name":"name","memberships":[{"id":"spaceid0","custom":{"a":"b"},"space":{"id":"spaceid0","name":"spacename","description":"desc","eTag":"Aee9zsKNndXlHw"},"created":"2019-08-23T10:41:17.156491Z","updated":"2019-08-23T10:41:17.156491Z","eTag":"AamrnoXdpdmzjwE"}]}}`)
+
+	r, _, err := newPNGetUserResponse(jsonBytes, o.opts, StatusResponse{})
+	assert.Nil(err)
+	assert.Equal(1, len(r.Data.Memberships))
+	assert.Equal("spaceid0", r.Data.Memberships[0].ID)
+	assert.Equal("b", r.Data.Memberships[0].Custom["a"])
+	assert.Equal("AamrnoXdpdmzjwE", r.Data.Memberships[0].ETag)
+	assert.Equal("spacename", r.Data.Memberships[0].Space.Name)
+	assert.Equal("Aee9zsKNndXlHw", r.Data.Memberships[0].Space.ETag)
+}
+
+func TestGetUserETagOnly(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+
+	o := newGetUserBuilder(pn)
+	o.ID("id0")
+	o.Include([]PNUserSpaceInclude{PNUserSpaceCustom})
+	o.ETagOnly()
+
+	u, err := o.opts.buildQuery()
+	assert.Nil(err)
+	assert.Equal("id,eTag,updated", u.Get("fields"))
+	assert.Equal("", u.Get("include"))
+
+	jsonBytes := []byte(`{"status":200,"data":{"id":"id0","updated":"2019-08-20T13:26:19.140324Z","eTag":"AbyT4v2p6K7fpQE"}}`)
+
+	r, _, err := newPNGetUserResponse(jsonBytes, o.opts, StatusResponse{})
+	assert.Nil(err)
+	assert.Equal("id0", r.Data.ID)
+	assert.Equal("2019-08-20T13:26:19.140324Z", r.Data.Updated)
+	assert.Equal("AbyT4v2p6K7fpQE", r.Data.ETag)
+	assert.Equal("", r.Data.Name)
+	assert.Nil(r.Data.Custom)
+}
+
+func TestUserExists(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+
+	pn.SetClient(&http.Client{Transport: newStubTransport(200, `{"status":200,"data":{"id":"id0"}}`)})
+	exists, err := pn.UserExists("id0")
+	assert.Nil(err)
+	assert.True(exists)
+
+	pn.SetClient(&http.Client{Transport: newStubTransport(404, `{"status":404,"error":{"message":"Requested object was not found."}}`)})
+	exists, err = pn.UserExists("id0")
+	assert.Nil(err)
+	assert.False(exists)
+
+	pn.SetClient(&http.Client{Transport: newStubTransport(500, `{"status":500}`)})
+	exists, err = pn.UserExists("id0")
+	assert.Contains(err.Error(), "500")
+	assert.False(exists)
+}
@@ -7,7 +7,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/url"
-	"strconv"
+	"time"
 
 	"github.com/pubnub/go/pnerr"
 	"github.com/pubnub/go/utils"
@@ -79,6 +79,54 @@ func (b *getUsersBuilder) Count(count bool) *getUsersBuilder {
 	return b
 }
 
+// IDsOnly requests only the IDs of the users, they are returned in the IDs of the response and Data is empty.
+func (b *getUsersBuilder) IDsOnly() *getUsersBuilder {
+	b.opts.IDsOnly = true
+
+	return b
+}
+
+// ReturnRaw keeps the unparsed body of the response in its RawJSON, along with the parsed users.
+func (b *getUsersBuilder) ReturnRaw() *getUsersBuilder {
+	b.opts.ReturnRaw = true
+
+	return b
+}
+
+// IfNoneMatch sets the ETag of a previous response, a response flagged NotModified is returned when the users are unchanged.
+func (b *getUsersBuilder) IfNoneMatch(eTag string) *getUsersBuilder {
+	b.opts.IfNoneMatch = eTag
+
+	return b
+}
+
+// Filter adds a filter expression of the users, the Filter and WhereCustomEquals calls are combined with &&.
+func (b *getUsersBuilder) Filter(filter string) *getUsersBuilder {
+	b.opts.filters = append(b.opts.filters, filter)
+	b.opts.Filter = joinObjectsFilters(b.opts.filters)
+
+	return b
+}
+
+// WhereCustomEquals adds a filter expression matching the users whose custom field key equals value.
+func (b *getUsersBuilder) WhereCustomEquals(key string, value interface{}) *getUsersBuilder {
+	b.opts.filters = append(b.opts.filters, customEqualsFilter(key, value))
+	b.opts.Filter = joinObjectsFilters(b.opts.filters)
+	b.opts.filterKeys = append(b.opts.filterKeys, key)
+
+	return b
+}
+
+// UpdatedSince adds a filter expression matching the users updated at or after since, sorted by their update
+// so a sync can resume from the Updated of the last user received.
+func (b *getUsersBuilder) UpdatedSince(since time.Time) *getUsersBuilder {
+	b.opts.filters = append(b.opts.filters, updatedSinceFilter(since))
+	b.opts.Filter = joinObjectsFilters(b.opts.filters)
+	b.opts.sort = objectsUpdatedSinceSort
+
+	return b
+}
+
 // QueryParam accepts a map, the keys and values of the map are passed as the query string parameters of the URL called by the API.
 func (b *getUsersBuilder) QueryParam(queryParam map[string]string) *getUsersBuilder {
 	b.opts.QueryParam = queryParam
@@ -86,17 +134,51 @@ func (b *getUsersBuilder) QueryParam(queryParam map[string]string) *getUsersBuil
 	return b
 }
 
+// Timeout sets the timeout for the getUsers request.
+func (b *getUsersBuilder) Timeout(timeout time.Duration) *getUsersBuilder {
+	b.opts.Timeout = timeout
+
+	return b
+}
+
+// RequestTimeout sets the timeout in seconds for the getUsers request.
+func (b *getUsersBuilder) RequestTimeout(seconds int) *getUsersBuilder {
+	b.opts.setRequestTimeout = true
+
+	return b.Timeout(time.Duration(seconds) * time.Second)
+}
+
+// ConnectTimeout sets the connect timeout in seconds for the getUsers request.
+func (b *getUsersBuilder) ConnectTimeout(seconds int) *getUsersBuilder {
+	b.opts.ConnectTimeout = seconds
+
+	return b
+}
+
 // Transport sets the Transport for the getUsers request.
 func (b *getUsersBuilder) Transport(tr http.RoundTripper) *getUsersBuilder {
 	b.opts.Transport = tr
 	return b
 }
 
+// SignedURL returns the URL of the getUsers request without executing it.
+func (b *getUsersBuilder) SignedURL() (string, error) {
+	return signedURL(b.opts)
+}
+
 // Execute runs the getUsers request.
 func (b *getUsersBuilder) Execute() (*PNGetUsersResponse, StatusResponse, error) {
 	rawJSON, status, err := executeRequest(b.opts)
 	if err != nil {
-		return emptyPNGetUsersResponse, status, err
+		return emptyPNGetUsersResponse, status, newObjectsError(err)
+	}
+
+	if status.StatusCode == http.StatusNotModified {
+		return &PNGetUsersResponse{
+			Data:        []PNUser{},
+			ETag:        status.ETag,
+			NotModified: true,
+		}, status, nil
 	}
 
 	return newPNGetUsersResponse(rawJSON, b.opts, status)
@@ -105,16 +187,29 @@ func (b *getUsersBuilder) Execute() (*PNGetUsersResponse, StatusResponse, error)
 type getUsersOpts struct {
 	pubnub *PubNub
 
-	Limit      int
-	Include    []string
-	Start      string
-	End        string
-	Count      bool
-	QueryParam map[string]string
+	Limit          int
+	Include        []string
+	Start          string
+	End            string
+	Count          bool
+	IDsOnly        bool
+	ReturnRaw      bool
+	IfNoneMatch    string
+	Filter         string
+	QueryParam     map[string]string
+	Timeout        time.Duration
+	ConnectTimeout int
 
 	Transport http.RoundTripper
 
+	filters    []string
+	filterKeys []string
+	sort       []string
+
 	ctx Context
+
+	// nil hacks
+	setRequestTimeout bool
 }
 
 func (o *getUsersOpts) config() Config {
@@ -134,6 +229,22 @@ func (o *getUsersOpts) validate() error {
 		return newValidationError(o, StrMissingSubKey)
 	}
 
+	if o.setRequestTimeout && o.Timeout <= 0 {
+		return newValidationError(o, fmt.Sprintf("%s %s: must be positive", StrInvalidTimeout, o.Timeout))
+	}
+
+	if err := validateObjectsInclude(o, o.Include, userInclude); err != nil {
+		return err
+	}
+
+	if err := validateObjectsLimit(o, o.Limit); err != nil {
+		return err
+	}
+
+	if err := validateObjectsFilterKeys(o, o.filterKeys); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -144,27 +255,18 @@ func (o *getUsersOpts) buildPath() (string, error) {
 
 func (o *getUsersOpts) buildQuery() (*url.Values, error) {
 
-	q := defaultQuery(o.pubnub.Config.UUID, o.pubnub.telemetryManager)
-
-	if o.Include != nil {
-		q.Set("include", string(utils.JoinChannels(o.Include)))
-	}
-
-	q.Set("limit", strconv.Itoa(o.Limit))
-
-	if o.Start != "" {
-		q.Set("start", o.Start)
-	}
+	q := defaultQuery(o.pubnub.Config, o.pubnub.telemetryManager)
 
-	if o.Count {
-		q.Set("count", "1")
-	} else {
-		q.Set("count", "0")
+	include := sortObjectsInclude(o.Include, userInclude)
+	if o.IDsOnly {
+		include = nil
+		q.Set("fields", objectsIDsOnlyFields)
 	}
-
-	if o.End != "" {
-		q.Set("end", o.End)
+	setObjectsListQuery(q, include, o.Limit, o.Start, o.End, o.Count)
+	if o.Filter != "" {
+		q.Set("filter", utils.URLEncode(o.Filter))
 	}
+	setObjectsSortQuery(q, o.sort, true)
 	o.pubnub.tokenManager.SetAuthParan(q, "", PNUsers)
 	SetQueryParam(q, o.QueryParam)
 
@@ -192,6 +294,9 @@ func (o *getUsersOpts) requestTimeout() int {
 }
 
 func (o *getUsersOpts) connectTimeout() int {
+	if o.ConnectTimeout != 0 {
+		return o.ConnectTimeout
+	}
 	return o.pubnub.Config.ConnectTimeout
 }
 
@@ -203,13 +308,42 @@ func (o *getUsersOpts) telemetryManager() *TelemetryManager {
 	return o.pubnub.telemetryManager
 }
 
+func (o *getUsersOpts) tokenManager() *TokenManager {
+	return o.pubnub.tokenManager
+}
+
+func (o *getUsersOpts) timeout() time.Duration {
+	return o.Timeout
+}
+
+func (o *getUsersOpts) transport() http.RoundTripper {
+	return o.Transport
+}
+
+func (o *getUsersOpts) requestHeaders() map[string]string {
+	if o.IfNoneMatch == "" {
+		return nil
+	}
+
+	return map[string]string{"If-None-Match": o.IfNoneMatch}
+}
+
 // PNGetUsersResponse is the Objects API Response for Get Users
 type PNGetUsersResponse struct {
-	status     int      `json:"status"`
-	Data       []PNUser `json:"data"`
-	TotalCount int      `json:"totalCount"`
-	Next       string   `json:"next"`
-	Prev       string   `json:"prev"`
+	status      int      `json:"status"`
+	Data        []PNUser `json:"data"`
+	TotalCount  int      `json:"totalCount"`
+	Next        string   `json:"next"`
+	Prev        string   `json:"prev"`
+	ETag        string   `json:"-"`
+	NotModified bool     `json:"-"`
+	IDs         []string `json:"-"`
+	RawJSON     []byte   `json:"-"`
+}
+
+// HasMore returns true when the response has a Next cursor, pass it to Start to fetch the next page of users.
+func (r *PNGetUsersResponse) HasMore() bool {
+	return r.Next != ""
 }
 
 func newPNGetUsersResponse(jsonBytes []byte, o *getUsersOpts,
@@ -217,13 +351,26 @@ func newPNGetUsersResponse(jsonBytes []byte, o *getUsersOpts,
 
 	resp := &PNGetUsersResponse{}
 
-	err := json.Unmarshal(jsonBytes, &resp)
+	var err error
+	if o.IDsOnly {
+		ids := &objectsIDsResponse{}
+		err = json.Unmarshal(jsonBytes, ids)
+		resp.Data = []PNUser{}
+		resp.IDs = ids.ids()
+		resp.TotalCount, resp.Next, resp.Prev = ids.TotalCount, ids.Next, ids.Prev
+	} else {
+		err = json.Unmarshal(jsonBytes, &resp)
+	}
 	if err != nil {
 		e := pnerr.NewResponseParsingError("Error unmarshalling response",
 			ioutil.NopCloser(bytes.NewBufferString(string(jsonBytes))), err)
 
 		return emptyPNGetUsersResponse, status, e
 	}
+	resp.ETag = status.ETag
+	if o.ReturnRaw {
+		resp.RawJSON = jsonBytes
+	}
 
 	return resp, status, nil
 }
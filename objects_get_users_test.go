@@ -2,8 +2,10 @@ package pubnub
 
 import (
 	"fmt"
+	"net/http"
 	"strconv"
 	"testing"
+	"time"
 
 	h "github.com/pubnub/go/tests/helpers"
 	"github.com/pubnub/go/utils"
@@ -114,3 +116,177 @@ func TestGetUsersResponseValuePass(t *testing.T) {
 
 	assert.Nil(err)
 }
+
+func TestGetUsersHasMore(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+
+	pn.SetClient(&http.Client{Transport: newStubTransport(200, `{"status":200,"data":[{"id":"id0"}],"totalCount":2,"next":"MQ"}`)})
+	r, _, err := pn.GetUsers().Limit(1).Count(true).Execute()
+	assert.Nil(err)
+	assert.Equal(2, r.TotalCount)
+	assert.True(r.HasMore())
+
+	pn.SetClient(&http.Client{Transport: newStubTransport(200, `{"status":200,"data":[{"id":"id1"}],"totalCount":2,"prev":"MQ"}`)})
+	r, _, err = pn.GetUsers().Limit(1).Count(true).Start("MQ").Execute()
+	assert.Nil(err)
+	assert.False(r.HasMore())
+}
+
+func TestGetUsersWhereCustomEquals(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+
+	o := pn.GetUsers().WhereCustomEquals("team", `a"b\c`).WhereCustomEquals("level", 3).Filter(`name like "j*"`)
+	assert.Equal(`(custom.team == "a\"b\\c") && (custom.level == 3) && (name like "j*")`, o.opts.Filter)
+
+	query, err := o.opts.buildQuery()
+	assert.Nil(err)
+	assert.Equal(utils.URLEncode(o.opts.Filter), query.Get("filter"))
+	assert.Nil(o.opts.validate())
+
+	o = pn.GetUsers().WhereCustomEquals(`team == "x" || true`, "a")
+	assert.Contains(o.opts.validate().Error(), StrInvalidFilter)
+}
+
+func TestGetUsersFilterWithOr(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+
+	o := pn.GetUsers().Filter(`name like "j*" || name like "k*"`)
+	assert.Equal(`name like "j*" || name like "k*"`, o.opts.Filter)
+
+	o.WhereCustomEquals("team", "a")
+	assert.Equal(`(name like "j*" || name like "k*") && (custom.team == "a")`, o.opts.Filter)
+	assert.Nil(o.opts.validate())
+}
+
+// newETagTransport returns a transport answering with the ETag AbyT4v2p6K7fpQE, or with a 304 when it is matched.
+func newETagTransport() *testTransport {
+	return newTestTransport(func(req *http.Request) (*http.Response, error) {
+		if req.Header.Get("If-None-Match") == "AbyT4v2p6K7fpQE" {
+			return stubResponse(req, 304, ""), nil
+		}
+		resp := stubResponse(req, 200, `{"status":200,"data":[{"id":"id0","name":"name"}],"next":"MQ"}`)
+		resp.Header = http.Header{"Etag": {"AbyT4v2p6K7fpQE"}}
+		return resp, nil
+	})
+}
+
+func TestGetUsersIfNoneMatch(t *testing.T) {
+	assert := assert.New(t)
+	transport := newETagTransport()
+	pn := NewPubNub(NewDemoConfig())
+	pn.SetClient(&http.Client{Transport: transport})
+
+	res, _, err := pn.GetUsers().Execute()
+	assert.Nil(err)
+	req, _ := transport.last()
+	assert.Equal("", req.Header.Get("If-None-Match"))
+	assert.False(res.NotModified)
+	assert.Equal("AbyT4v2p6K7fpQE", res.ETag)
+	assert.Equal(1, len(res.Data))
+
+	res, status, err := pn.GetUsers().IfNoneMatch(res.ETag).Execute()
+	assert.Nil(err)
+	req, _ = transport.last()
+	assert.Equal("AbyT4v2p6K7fpQE", req.Header.Get("If-None-Match"))
+	assert.Equal(304, status.StatusCode)
+	assert.True(res.NotModified)
+	assert.Equal(0, len(res.Data))
+}
+
+func TestGetUsersIDsOnly(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+
+	o := newGetUsersBuilder(pn)
+	o.Include([]PNUserSpaceInclude{PNUserSpaceCustom})
+	o.Count(true)
+	o.IDsOnly()
+
+	u, err := o.opts.buildQuery()
+	assert.Nil(err)
+	assert.Equal("id", u.Get("fields"))
+	assert.Equal("", u.Get("include"))
+	assert.Equal("1", u.Get("count"))
+
+	jsonBytes := []byte(`{"status":200,"data":[{"id":"id0","custom":{"a":"b"}},{"id":"id1"}],"totalCount":2,"next":"Mg"}`)
+
+	r, _, err := newPNGetUsersResponse(jsonBytes, o.opts, StatusResponse{})
+	assert.Nil(err)
+	assert.Equal([]string{"id0", "id1"}, r.IDs)
+	assert.Empty(r.Data)
+	assert.Equal(2, r.TotalCount)
+	assert.Equal("Mg", r.Next)
+}
+
+func TestGetUsersIncludeMemberships(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+
+	o := newGetUsersBuilder(pn)
+	o.Include([]PNUserSpaceInclude{PNUserMemberships})
+
+	u, err := o.opts.buildQuery()
+	assert.Nil(err)
+	assert.Equal("memberships", u.Get("include"))
+
+	jsonBytes := []byte(`{"status":200,"data":[{"id":"id0","memberships":[{"id":"spaceid0"},{"id":"spaceid1"}]},{"id":"id1"}]}`)
+
+	r, _, err := newPNGetUsersResponse(jsonBytes, o.opts, StatusResponse{})
+	assert.Nil(err)
+	assert.Equal(2, len(r.Data[0].Memberships))
+	assert.Equal("spaceid1", r.Data[0].Memberships[1].ID)
+	assert.Equal(0, len(r.Data[1].Memberships))
+}
+
+func TestGetUsersInclude(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+
+	o := newGetUsersBuilder(pn)
+	o.Include([]PNUserSpaceInclude{PNUserMembershipsSpace, PNUserSpaceCustom, PNUserMembershipsSpace, PNUserMemberships})
+	assert.Nil(o.opts.validate())
+
+	u, err := o.opts.buildQuery()
+	assert.Nil(err)
+	assert.Equal("custom,memberships,memberships.space", u.Get("include"))
+
+	o.Include([]PNUserSpaceInclude{PNUserSpaceCustom, PNUserSpaceInclude(9)})
+	assert.Equal("pubnub/validation: pubnub: Get Users: Invalid Include PNUserSpaceInclude(9): must be one of custom, memberships, memberships.space", o.opts.validate().Error())
+}
+
+func TestGetUsersReturnRaw(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+	body := `{"status":200,"data":[{"id":"id0","extra":{"a":"b"}}],"unknown":true}`
+	pn.SetClient(&http.Client{Transport: newStubTransport(200, body)})
+
+	res, _, err := pn.GetUsers().ReturnRaw().Execute()
+	assert.Nil(err)
+	assert.Equal([]byte(body), res.RawJSON)
+	assert.Equal("id0", res.Data[0].ID)
+
+	res, _, err = pn.GetUsers().Execute()
+	assert.Nil(err)
+	assert.Nil(res.RawJSON)
+}
+
+func TestUsersUpdatedSince(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+
+	since := time.Date(2019, 8, 20, 15, 26, 8, 341297000, time.FixedZone("CEST", 2*60*60))
+	o := pn.GetUsers().WhereCustomEquals("a", "b").UpdatedSince(since)
+	assert.Equal(`(custom.a == "b") && (updated >= "2019-08-20T13:26:08.341297Z")`, o.opts.Filter)
+
+	query, err := o.opts.buildQuery()
+	assert.Nil(err)
+	assert.Equal(utils.URLEncode(o.opts.Filter), query.Get("filter"))
+	assert.Equal("updated:asc,id:asc", query.Get("sort"))
+
+	query, err = pn.GetUsers().opts.buildQuery()
+	assert.Nil(err)
+	assert.Equal("", query.Get("sort"))
+}
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"net/url"
 	"strconv"
+	"time"
 
 	"github.com/pubnub/go/pnerr"
 	"github.com/pubnub/go/utils"
@@ -104,6 +105,21 @@ func (b *manageMembersBuilder) Remove(membershipRemove []PNMembersRemove) *manag
 	return b
 }
 
+// RequireAll rejects the request without applying it when an ID is both removed and added or updated.
+func (b *manageMembersBuilder) RequireAll() *manageMembersBuilder {
+	b.opts.RequireAll = true
+
+	return b
+}
+
+// IfMatchesETag sets the ETag read with the members, the request fails with a *PNObjectsPreconditionError
+// when they were changed since, the server responds 412.
+func (b *manageMembersBuilder) IfMatchesETag(eTag string) *manageMembersBuilder {
+	b.opts.IfMatchesETag = eTag
+
+	return b
+}
+
 // QueryParam accepts a map, the keys and values of the map are passed as the query string parameters of the URL called by the API.
 func (b *manageMembersBuilder) QueryParam(queryParam map[string]string) *manageMembersBuilder {
 	b.opts.QueryParam = queryParam
@@ -111,17 +127,43 @@ func (b *manageMembersBuilder) QueryParam(queryParam map[string]string) *manageM
 	return b
 }
 
+// Timeout sets the timeout for the manageMembers request.
+func (b *manageMembersBuilder) Timeout(timeout time.Duration) *manageMembersBuilder {
+	b.opts.Timeout = timeout
+
+	return b
+}
+
+// RequestTimeout sets the timeout in seconds for the manageMembers request.
+func (b *manageMembersBuilder) RequestTimeout(seconds int) *manageMembersBuilder {
+	b.opts.setRequestTimeout = true
+
+	return b.Timeout(time.Duration(seconds) * time.Second)
+}
+
+// ConnectTimeout sets the connect timeout in seconds for the manageMembers request.
+func (b *manageMembersBuilder) ConnectTimeout(seconds int) *manageMembersBuilder {
+	b.opts.ConnectTimeout = seconds
+
+	return b
+}
+
 // Transport sets the Transport for the manageMembers request.
 func (b *manageMembersBuilder) Transport(tr http.RoundTripper) *manageMembersBuilder {
 	b.opts.Transport = tr
 	return b
 }
 
+// SignedURL returns an error, the manageMembers request sends a body which the URL doesn't carry.
+func (b *manageMembersBuilder) SignedURL() (string, error) {
+	return signedURL(b.opts)
+}
+
 // Execute runs the manageMembers request.
 func (b *manageMembersBuilder) Execute() (*PNManageMembersResponse, StatusResponse, error) {
 	rawJSON, status, err := executeRequest(b.opts)
 	if err != nil {
-		return emptyManageMembersResponse, status, err
+		return emptyManageMembersResponse, status, newObjectsPreconditionError(err, b.opts.IfMatchesETag)
 	}
 
 	return newPNManageMembersResponse(rawJSON, b.opts, status)
@@ -136,12 +178,19 @@ type manageMembersOpts struct {
 	End              string
 	Count            bool
 	QueryParam       map[string]string
+	Timeout          time.Duration
+	ConnectTimeout   int
 	MembershipRemove []PNMembersRemove
 	MembershipAdd    []PNMembersInput
 	MembershipUpdate []PNMembersInput
+	RequireAll       bool
+	IfMatchesETag    string
 	Transport        http.RoundTripper
 
 	ctx Context
+
+	// nil hacks
+	setRequestTimeout bool
 }
 
 func (o *manageMembersOpts) config() Config {
@@ -161,6 +210,20 @@ func (o *manageMembersOpts) validate() error {
 		return newValidationError(o, StrMissingSubKey)
 	}
 
+	if o.setRequestTimeout && o.Timeout <= 0 {
+		return newValidationError(o, fmt.Sprintf("%s %s: must be positive", StrInvalidTimeout, o.Timeout))
+	}
+
+	if err := validateObjectsInclude(o, o.Include, membersInclude); err != nil {
+		return err
+	}
+
+	if o.RequireAll {
+		if err := validateObjectsChanges(o, membersInputIDs(o.MembershipAdd), membersInputIDs(o.MembershipUpdate), membersRemoveIDs(o.MembershipRemove)); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -171,10 +234,10 @@ func (o *manageMembersOpts) buildPath() (string, error) {
 
 func (o *manageMembersOpts) buildQuery() (*url.Values, error) {
 
-	q := defaultQuery(o.pubnub.Config.UUID, o.pubnub.telemetryManager)
+	q := defaultQuery(o.pubnub.Config, o.pubnub.telemetryManager)
 
 	if o.Include != nil {
-		q.Set("include", string(utils.JoinChannels(o.Include)))
+		q.Set("include", string(utils.JoinChannels(sortObjectsInclude(o.Include, membersInclude))))
 	}
 
 	q.Set("limit", strconv.Itoa(o.Limit))
@@ -226,6 +289,10 @@ func (o *manageMembersOpts) buildBody() ([]byte, error) {
 
 }
 
+func (o *manageMembersOpts) requestHeaders() map[string]string {
+	return objectsIfMatchHeaders(o.IfMatchesETag)
+}
+
 func (o *manageMembersOpts) httpMethod() string {
 	return "PATCH"
 }
@@ -239,6 +306,9 @@ func (o *manageMembersOpts) requestTimeout() int {
 }
 
 func (o *manageMembersOpts) connectTimeout() int {
+	if o.ConnectTimeout != 0 {
+		return o.ConnectTimeout
+	}
 	return o.pubnub.Config.ConnectTimeout
 }
 
@@ -250,13 +320,31 @@ func (o *manageMembersOpts) telemetryManager() *TelemetryManager {
 	return o.pubnub.telemetryManager
 }
 
+func (o *manageMembersOpts) tokenManager() *TokenManager {
+	return o.pubnub.tokenManager
+}
+
+func (o *manageMembersOpts) timeout() time.Duration {
+	return o.Timeout
+}
+
+func (o *manageMembersOpts) transport() http.RoundTripper {
+	return o.Transport
+}
+
 // PNManageMembersResponse is the Objects API Response for ManageMembers
 type PNManageMembersResponse struct {
-	status     int         `json:"status"`
-	Data       []PNMembers `json:"data"`
-	TotalCount int         `json:"totalCount"`
-	Next       string      `json:"next"`
-	Prev       string      `json:"prev"`
+	status     int                     `json:"status"`
+	Data       []PNMembers             `json:"data"`
+	TotalCount int                     `json:"totalCount"`
+	Next       string                  `json:"next"`
+	Prev       string                  `json:"prev"`
+	Results    []PNObjectsChangeResult `json:"-"`
+}
+
+// HasMore returns true when the response has a Next cursor, pass it to Start to fetch the next page of members.
+func (r *PNManageMembersResponse) HasMore() bool {
+	return r.Next != ""
 }
 
 func newPNManageMembersResponse(jsonBytes []byte, o *manageMembersOpts,
@@ -272,5 +360,27 @@ func newPNManageMembersResponse(jsonBytes []byte, o *manageMembersOpts,
 		return emptyManageMembersResponse, status, e
 	}
 
+	ids := make([]string, len(resp.Data))
+	for i, d := range resp.Data {
+		ids[i] = d.ID
+	}
+	resp.Results = objectsChangeResults(membersInputIDs(o.MembershipAdd), membersInputIDs(o.MembershipUpdate), membersRemoveIDs(o.MembershipRemove), ids)
+
 	return resp, status, nil
 }
+
+func membersInputIDs(input []PNMembersInput) []string {
+	ids := make([]string, len(input))
+	for i, in := range input {
+		ids[i] = in.ID
+	}
+	return ids
+}
+
+func membersRemoveIDs(remove []PNMembersRemove) []string {
+	ids := make([]string, len(remove))
+	for i, r := range remove {
+		ids[i] = r.ID
+	}
+	return ids
+}
@@ -2,6 +2,7 @@ package pubnub
 
 import (
 	"fmt"
+	"net/http"
 	"strconv"
 	"testing"
 
@@ -162,3 +163,76 @@ func TestManageMembersResponseValuePass(t *testing.T) {
 
 	assert.Nil(err)
 }
+
+func TestManageMembersRequireAll(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+
+	o := newManageMembersBuilder(pn)
+	o.SpaceID("spaceid")
+	o.Add([]PNMembersInput{PNMembersInput{ID: "userid0"}, PNMembersInput{ID: "userid1"}})
+	o.Remove([]PNMembersRemove{PNMembersRemove{ID: "userid1"}})
+	assert.Nil(o.opts.validate())
+
+	o.RequireAll()
+	assert.Equal("pubnub/validation: pubnub: Manage Members: Conflicting Change userid1: must not be both removed and added or updated", o.opts.validate().Error())
+
+	_, _, err := o.Execute()
+	assert.Contains(err.Error(), "Conflicting Change userid1")
+}
+
+func TestManageMembersResults(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+
+	o := newManageMembersBuilder(pn)
+	o.SpaceID("spaceid")
+	o.Add([]PNMembersInput{PNMembersInput{ID: "userid0"}})
+	o.Update([]PNMembersInput{PNMembersInput{ID: "userid1"}})
+	o.Remove([]PNMembersRemove{PNMembersRemove{ID: "userid2"}, PNMembersRemove{ID: "userid3"}})
+
+	jsonBytes := []byte(`{"status":200,"data":[{"id":"userid0"},{"id":"userid3"}],"totalCount":2}`)
+
+	r, _, err := newPNManageMembersResponse(jsonBytes, o.opts, StatusResponse{})
+	assert.Nil(err)
+	assert.Equal([]PNObjectsChangeResult{
+		PNObjectsChangeResult{ID: "userid0", Operation: "add", Applied: true},
+		PNObjectsChangeResult{ID: "userid1", Operation: "update", Applied: false},
+		PNObjectsChangeResult{ID: "userid2", Operation: "remove", Applied: true},
+		PNObjectsChangeResult{ID: "userid3", Operation: "remove", Applied: false},
+	}, r.Results)
+}
+
+func TestManageMembersIfMatchesETag(t *testing.T) {
+	assert := assert.New(t)
+	eTag := "AamrnoXdpdmzjwE"
+	transport := newTestTransport(func(req *http.Request) (*http.Response, error) {
+		if ifMatch := req.Header.Get("If-Match"); ifMatch != "" && ifMatch != eTag {
+			return stubResponse(req, 412, `{"status":412,"error":{"message":"Precondition failed.","source":"objects"}}`), nil
+		}
+		return stubResponse(req, 200, fmt.Sprintf(`{"status":200,"data":[{"id":"userid0","custom":{"a":"b"},"eTag":"%s"}]}`, eTag)), nil
+	})
+	pn := NewPubNub(NewDemoConfig())
+	pn.SetClient(&http.Client{Transport: transport})
+
+	in := []PNMembersInput{{ID: "userid0", Custom: map[string]interface{}{"a": "b"}}}
+
+	res, _, err := pn.ManageMembers().SpaceID("spaceid").Update(in).Execute()
+	assert.Nil(err)
+	req, _ := transport.last()
+	assert.Equal("", req.Header.Get("If-Match"))
+	assert.Equal("AamrnoXdpdmzjwE", res.Data[0].ETag)
+
+	res, _, err = pn.ManageMembers().SpaceID("spaceid").Update(in).IfMatchesETag(res.Data[0].ETag).Execute()
+	assert.Nil(err)
+	req, _ = transport.last()
+	assert.Equal("AamrnoXdpdmzjwE", req.Header.Get("If-Match"))
+
+	eTag = "AYKH2s7ZlYKoJA"
+	_, _, err = pn.ManageMembers().SpaceID("spaceid").Update(in).IfMatchesETag(res.Data[0].ETag).Execute()
+	e, ok := err.(*PNObjectsPreconditionError)
+	assert.True(ok)
+	assert.Equal(412, e.StatusCode)
+	assert.Equal("AamrnoXdpdmzjwE", e.ETag)
+	assert.Equal(e.ServerError, e.Unwrap())
+}
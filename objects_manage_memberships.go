@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"net/url"
 	"strconv"
+	"time"
 
 	"github.com/pubnub/go/pnerr"
 	"github.com/pubnub/go/utils"
@@ -104,6 +105,21 @@ func (b *manageMembershipsBuilder) Remove(userMembershipRemove []PNMembershipsRe
 	return b
 }
 
+// RequireAll rejects the request without applying it when an ID is both removed and added or updated.
+func (b *manageMembershipsBuilder) RequireAll() *manageMembershipsBuilder {
+	b.opts.RequireAll = true
+
+	return b
+}
+
+// IfMatchesETag sets the ETag read with the memberships, the request fails with a *PNObjectsPreconditionError
+// when they were changed since, the server responds 412.
+func (b *manageMembershipsBuilder) IfMatchesETag(eTag string) *manageMembershipsBuilder {
+	b.opts.IfMatchesETag = eTag
+
+	return b
+}
+
 // QueryParam accepts a map, the keys and values of the map are passed as the query string parameters of the URL called by the API.
 func (b *manageMembershipsBuilder) QueryParam(queryParam map[string]string) *manageMembershipsBuilder {
 	b.opts.QueryParam = queryParam
@@ -111,17 +127,43 @@ func (b *manageMembershipsBuilder) QueryParam(queryParam map[string]string) *man
 	return b
 }
 
+// Timeout sets the timeout for the manageMemberships request.
+func (b *manageMembershipsBuilder) Timeout(timeout time.Duration) *manageMembershipsBuilder {
+	b.opts.Timeout = timeout
+
+	return b
+}
+
+// RequestTimeout sets the timeout in seconds for the manageMemberships request.
+func (b *manageMembershipsBuilder) RequestTimeout(seconds int) *manageMembershipsBuilder {
+	b.opts.setRequestTimeout = true
+
+	return b.Timeout(time.Duration(seconds) * time.Second)
+}
+
+// ConnectTimeout sets the connect timeout in seconds for the manageMemberships request.
+func (b *manageMembershipsBuilder) ConnectTimeout(seconds int) *manageMembershipsBuilder {
+	b.opts.ConnectTimeout = seconds
+
+	return b
+}
+
 // Transport sets the Transport for the manageMemberships request.
 func (b *manageMembershipsBuilder) Transport(tr http.RoundTripper) *manageMembershipsBuilder {
 	b.opts.Transport = tr
 	return b
 }
 
+// SignedURL returns an error, the manageMemberships request sends a body which the URL doesn't carry.
+func (b *manageMembershipsBuilder) SignedURL() (string, error) {
+	return signedURL(b.opts)
+}
+
 // Execute runs the manageMemberships request.
 func (b *manageMembershipsBuilder) Execute() (*PNManageMembershipsResponse, StatusResponse, error) {
 	rawJSON, status, err := executeRequest(b.opts)
 	if err != nil {
-		return emptyManageMembershipsResponse, status, err
+		return emptyManageMembershipsResponse, status, newObjectsPreconditionError(err, b.opts.IfMatchesETag)
 	}
 
 	return newPNManageMembershipsResponse(rawJSON, b.opts, status)
@@ -136,12 +178,19 @@ type manageMembershipsOpts struct {
 	End               string
 	Count             bool
 	QueryParam        map[string]string
+	Timeout           time.Duration
+	ConnectTimeout    int
 	MembershipsRemove []PNMembershipsRemove
 	MembershipsAdd    []PNMembershipsInput
 	MembershipsUpdate []PNMembershipsInput
+	RequireAll        bool
+	IfMatchesETag     string
 	Transport         http.RoundTripper
 
 	ctx Context
+
+	// nil hacks
+	setRequestTimeout bool
 }
 
 func (o *manageMembershipsOpts) config() Config {
@@ -161,6 +210,20 @@ func (o *manageMembershipsOpts) validate() error {
 		return newValidationError(o, StrMissingSubKey)
 	}
 
+	if o.setRequestTimeout && o.Timeout <= 0 {
+		return newValidationError(o, fmt.Sprintf("%s %s: must be positive", StrInvalidTimeout, o.Timeout))
+	}
+
+	if err := validateObjectsInclude(o, o.Include, membershipsInclude); err != nil {
+		return err
+	}
+
+	if o.RequireAll {
+		if err := validateObjectsChanges(o, membershipsInputIDs(o.MembershipsAdd), membershipsInputIDs(o.MembershipsUpdate), membershipsRemoveIDs(o.MembershipsRemove)); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -171,10 +234,10 @@ func (o *manageMembershipsOpts) buildPath() (string, error) {
 
 func (o *manageMembershipsOpts) buildQuery() (*url.Values, error) {
 
-	q := defaultQuery(o.pubnub.Config.UUID, o.pubnub.telemetryManager)
+	q := defaultQuery(o.pubnub.Config, o.pubnub.telemetryManager)
 
 	if o.Include != nil {
-		q.Set("include", string(utils.JoinChannels(o.Include)))
+		q.Set("include", string(utils.JoinChannels(sortObjectsInclude(o.Include, membershipsInclude))))
 	}
 	q.Set("limit", strconv.Itoa(o.Limit))
 
@@ -225,6 +288,10 @@ func (o *manageMembershipsOpts) buildBody() ([]byte, error) {
 	return jsonEncBytes, nil
 }
 
+func (o *manageMembershipsOpts) requestHeaders() map[string]string {
+	return objectsIfMatchHeaders(o.IfMatchesETag)
+}
+
 func (o *manageMembershipsOpts) httpMethod() string {
 	return "PATCH"
 }
@@ -238,6 +305,9 @@ func (o *manageMembershipsOpts) requestTimeout() int {
 }
 
 func (o *manageMembershipsOpts) connectTimeout() int {
+	if o.ConnectTimeout != 0 {
+		return o.ConnectTimeout
+	}
 	return o.pubnub.Config.ConnectTimeout
 }
 
@@ -249,13 +319,31 @@ func (o *manageMembershipsOpts) telemetryManager() *TelemetryManager {
 	return o.pubnub.telemetryManager
 }
 
+func (o *manageMembershipsOpts) tokenManager() *TokenManager {
+	return o.pubnub.tokenManager
+}
+
+func (o *manageMembershipsOpts) timeout() time.Duration {
+	return o.Timeout
+}
+
+func (o *manageMembershipsOpts) transport() http.RoundTripper {
+	return o.Transport
+}
+
 // PNManageMembershipsResponse is the Objects API Response for ManageMemberships
 type PNManageMembershipsResponse struct {
-	status     int             `json:"status"`
-	Data       []PNMemberships `json:"data"`
-	TotalCount int             `json:"totalCount"`
-	Next       string          `json:"next"`
-	Prev       string          `json:"prev"`
+	status     int                     `json:"status"`
+	Data       []PNMemberships         `json:"data"`
+	TotalCount int                     `json:"totalCount"`
+	Next       string                  `json:"next"`
+	Prev       string                  `json:"prev"`
+	Results    []PNObjectsChangeResult `json:"-"`
+}
+
+// HasMore returns true when the response has a Next cursor, pass it to Start to fetch the next page of memberships.
+func (r *PNManageMembershipsResponse) HasMore() bool {
+	return r.Next != ""
 }
 
 func newPNManageMembershipsResponse(jsonBytes []byte, o *manageMembershipsOpts,
@@ -271,5 +359,27 @@ func newPNManageMembershipsResponse(jsonBytes []byte, o *manageMembershipsOpts,
 		return emptyManageMembershipsResponse, status, e
 	}
 
+	ids := make([]string, len(resp.Data))
+	for i, d := range resp.Data {
+		ids[i] = d.ID
+	}
+	resp.Results = objectsChangeResults(membershipsInputIDs(o.MembershipsAdd), membershipsInputIDs(o.MembershipsUpdate), membershipsRemoveIDs(o.MembershipsRemove), ids)
+
 	return resp, status, nil
 }
+
+func membershipsInputIDs(input []PNMembershipsInput) []string {
+	ids := make([]string, len(input))
+	for i, in := range input {
+		ids[i] = in.ID
+	}
+	return ids
+}
+
+func membershipsRemoveIDs(remove []PNMembershipsRemove) []string {
+	ids := make([]string, len(remove))
+	for i, r := range remove {
+		ids[i] = r.ID
+	}
+	return ids
+}
@@ -2,6 +2,7 @@ package pubnub
 
 import (
 	"fmt"
+	"net/http"
 	"strconv"
 	"testing"
 
@@ -160,3 +161,60 @@ func TestManageMembershipsResponseValuePass(t *testing.T) {
 
 	assert.Nil(err)
 }
+
+func TestManageMembershipsInclude(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+
+	o := newManageMembershipsBuilder(pn)
+	o.UserID("id0")
+	o.Include([]PNMembershipsInclude{PNMembershipsSpace, PNMembershipsSpace, PNMembershipsCustom})
+	assert.Nil(o.opts.validate())
+
+	u, err := o.opts.buildQuery()
+	assert.Nil(err)
+	assert.Equal("custom,space", u.Get("include"))
+
+	o.Include([]PNMembershipsInclude{PNMembershipsInclude(0)})
+	assert.Contains(o.opts.validate().Error(), "Invalid Include PNMembershipsInclude(0)")
+}
+
+func TestManageMembershipsRequireAll(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+
+	o := newManageMembershipsBuilder(pn)
+	o.UserID("userid")
+	o.Update([]PNMembershipsInput{PNMembershipsInput{ID: "spaceid0"}})
+	o.Remove([]PNMembershipsRemove{PNMembershipsRemove{ID: "spaceid0"}})
+	o.RequireAll()
+
+	assert.Equal("pubnub/validation: pubnub: Manage Memberships: Conflicting Change spaceid0: must not be both removed and added or updated", o.opts.validate().Error())
+}
+
+func TestManageMembershipsIfMatchesETag(t *testing.T) {
+	assert := assert.New(t)
+	transport := newTestTransport(func(req *http.Request) (*http.Response, error) {
+		if req.Header.Get("If-Match") != "AamrnoXdpdmzjwE" {
+			return stubResponse(req, 412, `{"status":412,"error":{"message":"Precondition failed.","source":"objects"}}`), nil
+		}
+		return stubResponse(req, 200, `{"status":200,"data":[{"id":"spaceid0","eTag":"AamrnoXdpdmzjwE"}]}`), nil
+	})
+	pn := NewPubNub(NewDemoConfig())
+	pn.SetClient(&http.Client{Transport: transport})
+
+	o := newManageMembershipsBuilder(pn)
+	o.IfMatchesETag("AamrnoXdpdmzjwE")
+	assert.Equal(map[string]string{"If-Match": "AamrnoXdpdmzjwE"}, o.opts.requestHeaders())
+
+	in := []PNMembershipsInput{{ID: "spaceid0"}}
+
+	_, _, err := pn.ManageMemberships().UserID("userid").Update(in).IfMatchesETag("AamrnoXdpdmzjwE").Execute()
+	assert.Nil(err)
+
+	_, _, err = pn.ManageMemberships().UserID("userid").Update(in).IfMatchesETag("AYKH2s7ZlYKoJA").Execute()
+	e, ok := err.(*PNObjectsPreconditionError)
+	assert.True(ok)
+	assert.Equal(412, e.StatusCode)
+	assert.Contains(e.Error(), "AYKH2s7ZlYKoJA")
+}
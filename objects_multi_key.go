@@ -0,0 +1,149 @@
+package pubnub
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// objectsMultiKeyConcurrency is the max number of subscribe keys read at once by GetUsersMultiKey and GetSpacesMultiKey.
+const objectsMultiKeyConcurrency = 4
+
+// PNTaggedUser is a user read by GetUsersMultiKey, tagged with the subscribe key of the PubNub instance it was read from.
+type PNTaggedUser struct {
+	PNUser
+	SubscribeKey string
+}
+
+// PNTaggedSpace is a space read by GetSpacesMultiKey, tagged with the subscribe key of the PubNub instance it was read from.
+type PNTaggedSpace struct {
+	PNSpace
+	SubscribeKey string
+}
+
+// PNMultiKeyInstanceError is the error of the PubNub instance at Index in the instances of GetUsersMultiKey or GetSpacesMultiKey.
+type PNMultiKeyInstanceError struct {
+	Index        int
+	SubscribeKey string
+	Err          error
+}
+
+func (e *PNMultiKeyInstanceError) Error() string {
+	return fmt.Sprintf("%d %s: %s", e.Index, e.SubscribeKey, e.Err.Error())
+}
+
+// PNMultiKeyError is the error of GetUsersMultiKey and GetSpacesMultiKey, Errors holds the error of each instance which failed,
+// in the order of the instances. Several instances may share a subscribe key.
+type PNMultiKeyError struct {
+	Errors []*PNMultiKeyInstanceError
+}
+
+func (e *PNMultiKeyError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		msgs[i] = err.Error()
+	}
+
+	return fmt.Sprintf("pubnub/objects: %d instances failed: %s", len(e.Errors), strings.Join(msgs, "; "))
+}
+
+// GetUsersMultiKey reads all the pages of users of each PubNub instance, at most 4 at once,
+// the users are merged in the order of the instances and tagged with their subscribe key.
+// The users of the instances which read all their pages are returned with a *PNMultiKeyError naming the instances which failed.
+func GetUsersMultiKey(instances []*PubNub, include []PNUserSpaceInclude) ([]PNTaggedUser, error) {
+	results := make([][]PNTaggedUser, len(instances))
+
+	err := runObjectsMultiKey(instances, func(i int, pn *PubNub) error {
+		// the pages are kept only when all of them are read
+		var users []PNTaggedUser
+		start := ""
+		for {
+			res, _, err := pn.GetUsers().Include(include).Start(start).Execute()
+			if err != nil {
+				return err
+			}
+			for _, user := range res.Data {
+				users = append(users, PNTaggedUser{PNUser: user, SubscribeKey: pn.Config.SubscribeKey})
+			}
+			if !res.HasMore() || len(res.Data) == 0 {
+				results[i] = users
+				return nil
+			}
+			start = res.Next
+		}
+	})
+
+	users := []PNTaggedUser{}
+	for _, r := range results {
+		users = append(users, r...)
+	}
+
+	return users, err
+}
+
+// GetSpacesMultiKey reads all the pages of spaces of each PubNub instance, at most 4 at once,
+// the spaces are merged in the order of the instances and tagged with their subscribe key.
+// The spaces of the instances which read all their pages are returned with a *PNMultiKeyError naming the instances which failed.
+func GetSpacesMultiKey(instances []*PubNub, include []PNUserSpaceInclude) ([]PNTaggedSpace, error) {
+	results := make([][]PNTaggedSpace, len(instances))
+
+	err := runObjectsMultiKey(instances, func(i int, pn *PubNub) error {
+		// the pages are kept only when all of them are read
+		var spaces []PNTaggedSpace
+		start := ""
+		for {
+			res, _, err := pn.GetSpaces().Include(include).Start(start).Execute()
+			if err != nil {
+				return err
+			}
+			for _, space := range res.Data {
+				spaces = append(spaces, PNTaggedSpace{PNSpace: space, SubscribeKey: pn.Config.SubscribeKey})
+			}
+			if !res.HasMore() || len(res.Data) == 0 {
+				results[i] = spaces
+				return nil
+			}
+			start = res.Next
+		}
+	})
+
+	spaces := []PNTaggedSpace{}
+	for _, r := range results {
+		spaces = append(spaces, r...)
+	}
+
+	return spaces, err
+}
+
+// runObjectsMultiKey runs read for each instance with at most objectsMultiKeyConcurrency running at once,
+// the errors are aggregated in the order of the instances.
+func runObjectsMultiKey(instances []*PubNub, read func(i int, pn *PubNub) error) error {
+	errs := make([]error, len(instances))
+	slots := make(chan struct{}, objectsMultiKeyConcurrency)
+
+	var wg sync.WaitGroup
+	for i, pn := range instances {
+		wg.Add(1)
+		slots <- struct{}{}
+		go func(i int, pn *PubNub) {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+			errs[i] = read(i, pn)
+		}(i, pn)
+	}
+	wg.Wait()
+
+	e := &PNMultiKeyError{}
+	for i, err := range errs {
+		if err != nil {
+			e.Errors = append(e.Errors, &PNMultiKeyInstanceError{Index: i, SubscribeKey: instances[i].Config.SubscribeKey, Err: err})
+		}
+	}
+	if len(e.Errors) == 0 {
+		return nil
+	}
+
+	return e
+}
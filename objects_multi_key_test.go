@@ -0,0 +1,109 @@
+package pubnub
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/pubnub/go/pnerr"
+	"github.com/stretchr/testify/assert"
+)
+
+// newMultiKeyPubNub returns a PubNub instance served the pages keyed by their start, the other pages fail.
+func newMultiKeyPubNub(subscribeKey string, pages map[string]string) *PubNub {
+	config := NewDemoConfig()
+	config.SubscribeKey = subscribeKey
+	pn := NewPubNub(config)
+	pn.SetClient(&http.Client{Transport: newTestTransport(func(req *http.Request) (*http.Response, error) {
+		body, ok := pages[req.URL.Query().Get("start")]
+		if !ok || strings.Contains(req.URL.Opaque, "/sub-broken/") {
+			return stubResponse(req, 500, `Internal Server Error`), nil
+		}
+		return stubResponse(req, 200, body), nil
+	})})
+
+	return pn
+}
+
+func TestGetUsersMultiKey(t *testing.T) {
+	assert := assert.New(t)
+
+	pn1 := newMultiKeyPubNub("sub-a", map[string]string{
+		"":   `{"status":200,"data":[{"id":"user0"},{"id":"user1"}],"next":"Mg"}`,
+		"Mg": `{"status":200,"data":[{"id":"user2"}]}`,
+	})
+	pn2 := newMultiKeyPubNub("sub-b", map[string]string{
+		"": `{"status":200,"data":[{"id":"user0","name":"b"}]}`,
+	})
+
+	users, err := GetUsersMultiKey([]*PubNub{pn1, pn2}, nil)
+	assert.Nil(err)
+	assert.Equal(4, len(users))
+	assert.Equal("user0", users[0].ID)
+	assert.Equal("sub-a", users[0].SubscribeKey)
+	assert.Equal("user2", users[2].ID)
+	assert.Equal("sub-a", users[2].SubscribeKey)
+	assert.Equal("user0", users[3].ID)
+	assert.Equal("b", users[3].Name)
+	assert.Equal("sub-b", users[3].SubscribeKey)
+
+	pn3 := newMultiKeyPubNub("sub-broken", nil)
+
+	users, err = GetUsersMultiKey([]*PubNub{pn1, pn3, pn2}, nil)
+	assert.Equal(4, len(users))
+	e, ok := err.(*PNMultiKeyError)
+	assert.True(ok)
+	assert.Equal(1, len(e.Errors))
+	assert.Equal(1, e.Errors[0].Index)
+	assert.Equal("sub-broken", e.Errors[0].SubscribeKey)
+	_, ok = e.Errors[0].Err.(*pnerr.ServerError)
+	assert.True(ok)
+	assert.Contains(e.Error(), "1 instances failed: 1 sub-broken:")
+}
+
+func TestGetUsersMultiKeyFailedPage(t *testing.T) {
+	assert := assert.New(t)
+
+	pn1 := newMultiKeyPubNub("sub-a", map[string]string{
+		"": `{"status":200,"data":[{"id":"user0"}]}`,
+	})
+	// the second page fails, the first one is dropped
+	pn2 := newMultiKeyPubNub("sub-a", map[string]string{
+		"": `{"status":200,"data":[{"id":"user1"}],"next":"Mg"}`,
+	})
+	pn3 := newMultiKeyPubNub("sub-broken", nil)
+	pn4 := newMultiKeyPubNub("sub-broken", nil)
+
+	users, err := GetUsersMultiKey([]*PubNub{pn1, pn2, pn3, pn4}, nil)
+	assert.Equal([]PNTaggedUser{{PNUser: PNUser{ID: "user0"}, SubscribeKey: "sub-a"}}, users)
+	e, ok := err.(*PNMultiKeyError)
+	assert.True(ok)
+	assert.Equal(3, len(e.Errors))
+	assert.Equal(1, e.Errors[0].Index)
+	assert.Equal("sub-a", e.Errors[0].SubscribeKey)
+	assert.Equal(2, e.Errors[1].Index)
+	assert.Equal(3, e.Errors[2].Index)
+	assert.Equal("sub-broken", e.Errors[2].SubscribeKey)
+}
+
+func TestGetSpacesMultiKey(t *testing.T) {
+	assert := assert.New(t)
+
+	pn1 := newMultiKeyPubNub("sub-a", map[string]string{
+		"": `{"status":200,"data":[{"id":"space0"}]}`,
+	})
+	pn2 := newMultiKeyPubNub("sub-b", map[string]string{
+		"": `{"status":200,"data":[{"id":"space1"}]}`,
+	})
+
+	spaces, err := GetSpacesMultiKey([]*PubNub{pn1, pn2}, []PNUserSpaceInclude{PNUserSpaceCustom})
+	assert.Nil(err)
+	assert.Equal([]PNTaggedSpace{
+		{PNSpace: PNSpace{ID: "space0"}, SubscribeKey: "sub-a"},
+		{PNSpace: PNSpace{ID: "space1"}, SubscribeKey: "sub-b"},
+	}, spaces)
+
+	spaces, err = GetSpacesMultiKey(nil, nil)
+	assert.Nil(err)
+	assert.Empty(spaces)
+}
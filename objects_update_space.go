@@ -9,6 +9,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"time"
 )
 
 var emptyPNUpdateSpaceResponse *PNUpdateSpaceResponse
@@ -89,34 +90,65 @@ func (b *updateSpaceBuilder) QueryParam(queryParam map[string]string) *updateSpa
 	return b
 }
 
+// Timeout sets the timeout for the updateSpace request.
+func (b *updateSpaceBuilder) Timeout(timeout time.Duration) *updateSpaceBuilder {
+	b.opts.Timeout = timeout
+
+	return b
+}
+
+// RequestTimeout sets the timeout in seconds for the updateSpace request.
+func (b *updateSpaceBuilder) RequestTimeout(seconds int) *updateSpaceBuilder {
+	b.opts.setRequestTimeout = true
+
+	return b.Timeout(time.Duration(seconds) * time.Second)
+}
+
+// ConnectTimeout sets the connect timeout in seconds for the updateSpace request.
+func (b *updateSpaceBuilder) ConnectTimeout(seconds int) *updateSpaceBuilder {
+	b.opts.ConnectTimeout = seconds
+
+	return b
+}
+
 // Transport sets the Transport for the updateSpace request.
 func (b *updateSpaceBuilder) Transport(tr http.RoundTripper) *updateSpaceBuilder {
 	b.opts.Transport = tr
 	return b
 }
 
+// SignedURL returns an error, the updateSpace request sends a body which the URL doesn't carry.
+func (b *updateSpaceBuilder) SignedURL() (string, error) {
+	return signedURL(b.opts)
+}
+
 // Execute runs the updateSpace request.
 func (b *updateSpaceBuilder) Execute() (*PNUpdateSpaceResponse, StatusResponse, error) {
 	rawJSON, status, err := executeRequest(b.opts)
 	if err != nil {
-		return emptyPNUpdateSpaceResponse, status, err
+		return emptyPNUpdateSpaceResponse, status, newObjectsError(err)
 	}
 
 	return newPNUpdateSpaceResponse(rawJSON, b.opts, status)
 }
 
 type updateSpaceOpts struct {
-	pubnub      *PubNub
-	Include     []string
-	ID          string
-	Name        string
-	Description string
-	Custom      map[string]interface{}
-	QueryParam  map[string]string
+	pubnub         *PubNub
+	Include        []string
+	ID             string
+	Name           string
+	Description    string
+	Custom         map[string]interface{}
+	QueryParam     map[string]string
+	Timeout        time.Duration
+	ConnectTimeout int
 
 	Transport http.RoundTripper
 
 	ctx Context
+
+	// nil hacks
+	setRequestTimeout bool
 }
 
 func (o *updateSpaceOpts) config() Config {
@@ -136,6 +168,14 @@ func (o *updateSpaceOpts) validate() error {
 		return newValidationError(o, StrMissingSubKey)
 	}
 
+	if o.setRequestTimeout && o.Timeout <= 0 {
+		return newValidationError(o, fmt.Sprintf("%s %s: must be positive", StrInvalidTimeout, o.Timeout))
+	}
+
+	if err := validateObjectsInclude(o, o.Include, spaceInclude); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -146,10 +186,10 @@ func (o *updateSpaceOpts) buildPath() (string, error) {
 
 func (o *updateSpaceOpts) buildQuery() (*url.Values, error) {
 
-	q := defaultQuery(o.pubnub.Config.UUID, o.pubnub.telemetryManager)
+	q := defaultQuery(o.pubnub.Config, o.pubnub.telemetryManager)
 
 	if o.Include != nil {
-		q.Set("include", string(utils.JoinChannels(o.Include)))
+		q.Set("include", string(utils.JoinChannels(sortObjectsInclude(o.Include, spaceInclude))))
 	}
 	o.pubnub.tokenManager.SetAuthParan(q, o.ID, PNSpaces)
 	SetQueryParam(q, o.QueryParam)
@@ -192,6 +232,9 @@ func (o *updateSpaceOpts) requestTimeout() int {
 }
 
 func (o *updateSpaceOpts) connectTimeout() int {
+	if o.ConnectTimeout != 0 {
+		return o.ConnectTimeout
+	}
 	return o.pubnub.Config.ConnectTimeout
 }
 
@@ -203,6 +246,18 @@ func (o *updateSpaceOpts) telemetryManager() *TelemetryManager {
 	return o.pubnub.telemetryManager
 }
 
+func (o *updateSpaceOpts) tokenManager() *TokenManager {
+	return o.pubnub.tokenManager
+}
+
+func (o *updateSpaceOpts) timeout() time.Duration {
+	return o.Timeout
+}
+
+func (o *updateSpaceOpts) transport() http.RoundTripper {
+	return o.Transport
+}
+
 // PNUpdateSpaceResponse is the Objects API Response for Update Space
 type PNUpdateSpaceResponse struct {
 	status int     `json:"status"`
@@ -7,6 +7,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"time"
 
 	"github.com/pubnub/go/pnerr"
 	"github.com/pubnub/go/utils"
@@ -104,36 +105,67 @@ func (b *updateUserBuilder) QueryParam(queryParam map[string]string) *updateUser
 	return b
 }
 
+// Timeout sets the timeout for the updateUser request.
+func (b *updateUserBuilder) Timeout(timeout time.Duration) *updateUserBuilder {
+	b.opts.Timeout = timeout
+
+	return b
+}
+
+// RequestTimeout sets the timeout in seconds for the updateUser request.
+func (b *updateUserBuilder) RequestTimeout(seconds int) *updateUserBuilder {
+	b.opts.setRequestTimeout = true
+
+	return b.Timeout(time.Duration(seconds) * time.Second)
+}
+
+// ConnectTimeout sets the connect timeout in seconds for the updateUser request.
+func (b *updateUserBuilder) ConnectTimeout(seconds int) *updateUserBuilder {
+	b.opts.ConnectTimeout = seconds
+
+	return b
+}
+
 // Transport sets the Transport for the updateUser request.
 func (b *updateUserBuilder) Transport(tr http.RoundTripper) *updateUserBuilder {
 	b.opts.Transport = tr
 	return b
 }
 
+// SignedURL returns an error, the updateUser request sends a body which the URL doesn't carry.
+func (b *updateUserBuilder) SignedURL() (string, error) {
+	return signedURL(b.opts)
+}
+
 // Execute runs the updateUser request.
 func (b *updateUserBuilder) Execute() (*PNUpdateUserResponse, StatusResponse, error) {
 	rawJSON, status, err := executeRequest(b.opts)
 	if err != nil {
-		return emptyPNUpdateUserResponse, status, err
+		return emptyPNUpdateUserResponse, status, newObjectsError(err)
 	}
 
 	return newPNUpdateUserResponse(rawJSON, b.opts, status)
 }
 
 type updateUserOpts struct {
-	pubnub     *PubNub
-	Include    []string
-	ID         string
-	Name       string
-	ExternalID string
-	ProfileURL string
-	Email      string
-	Custom     map[string]interface{}
-	QueryParam map[string]string
+	pubnub         *PubNub
+	Include        []string
+	ID             string
+	Name           string
+	ExternalID     string
+	ProfileURL     string
+	Email          string
+	Custom         map[string]interface{}
+	QueryParam     map[string]string
+	Timeout        time.Duration
+	ConnectTimeout int
 
 	Transport http.RoundTripper
 
 	ctx Context
+
+	// nil hacks
+	setRequestTimeout bool
 }
 
 func (o *updateUserOpts) config() Config {
@@ -153,6 +185,14 @@ func (o *updateUserOpts) validate() error {
 		return newValidationError(o, StrMissingSubKey)
 	}
 
+	if o.setRequestTimeout && o.Timeout <= 0 {
+		return newValidationError(o, fmt.Sprintf("%s %s: must be positive", StrInvalidTimeout, o.Timeout))
+	}
+
+	if err := validateObjectsInclude(o, o.Include, userInclude); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -163,10 +203,10 @@ func (o *updateUserOpts) buildPath() (string, error) {
 
 func (o *updateUserOpts) buildQuery() (*url.Values, error) {
 
-	q := defaultQuery(o.pubnub.Config.UUID, o.pubnub.telemetryManager)
+	q := defaultQuery(o.pubnub.Config, o.pubnub.telemetryManager)
 
 	if o.Include != nil {
-		q.Set("include", string(utils.JoinChannels(o.Include)))
+		q.Set("include", string(utils.JoinChannels(sortObjectsInclude(o.Include, userInclude))))
 	}
 	o.pubnub.tokenManager.SetAuthParan(q, o.ID, PNUsers)
 	SetQueryParam(q, o.QueryParam)
@@ -211,6 +251,9 @@ func (o *updateUserOpts) requestTimeout() int {
 }
 
 func (o *updateUserOpts) connectTimeout() int {
+	if o.ConnectTimeout != 0 {
+		return o.ConnectTimeout
+	}
 	return o.pubnub.Config.ConnectTimeout
 }
 
@@ -222,6 +265,18 @@ func (o *updateUserOpts) telemetryManager() *TelemetryManager {
 	return o.pubnub.telemetryManager
 }
 
+func (o *updateUserOpts) tokenManager() *TokenManager {
+	return o.pubnub.tokenManager
+}
+
+func (o *updateUserOpts) timeout() time.Duration {
+	return o.Timeout
+}
+
+func (o *updateUserOpts) transport() http.RoundTripper {
+	return o.Transport
+}
+
 // PNUpdateUserResponse is the Objects API Response for Update user
 type PNUpdateUserResponse struct {
 	status int    `json:"status"`
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"net/http"
 )
 
 // Error validating type or value of passed in params.
@@ -54,6 +55,7 @@ func NewBuildRequestError(msg string) *BuildRequestError {
 type ServerError struct {
 	StatusCode int
 	Body       []byte
+	Header     http.Header
 }
 
 func (e ServerError) Error() string {
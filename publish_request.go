@@ -2,11 +2,16 @@ package pubnub
 
 import (
 	"bytes"
+	"compress/gzip"
+	"crypto/aes"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"reflect"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/pubnub/go/pnerr"
 	"github.com/pubnub/go/utils"
@@ -18,6 +23,19 @@ import (
 const publishGetPath = "/publish/%s/%s/0/%s/%s/%s"
 const publishPostPath = "/publish/%s/%s/0/%s/%s"
 
+// publishMaxMessageSize is the max size of a published message accepted by the server.
+const publishMaxMessageSize = 32 * 1024
+
+// publishMaxGetMessageSize is the max URL encoded size of a message sent with GET when UsePost is not set,
+// the larger messages are sent with POST to keep the URL under the 8KB limit of the servers and proxies.
+const publishMaxGetMessageSize = 7 * 1024
+
+// publishMaxRetryAfter caps the Retry-After wait of a throttled Publish retried with Config.PublishRetryOnThrottle.
+const publishMaxRetryAfter = 60 * time.Second
+
+// publishMaxMetaSize is the max size of the serialized meta of a published message.
+const publishMaxMetaSize = 32 * 1024
+
 var emptyPublishResponse *PublishResponse
 
 type publishOpts struct {
@@ -32,7 +50,9 @@ type publishOpts struct {
 	ShouldStore    bool
 	Serialize      bool
 	DoNotReplicate bool
+	Compress       bool
 	QueryParam     map[string]string
+	Timeout        time.Duration
 
 	Transport http.RoundTripper
 
@@ -41,6 +61,10 @@ type publishOpts struct {
 	// nil hacks
 	setTTL         bool
 	setShouldStore bool
+	setUsePost     bool
+
+	// the sequence number is kept across the retries so the server drops the duplicates
+	seqn int
 }
 
 // PublishResponse is the response after the execution on Publish and Fire operations.
@@ -124,34 +148,46 @@ func (b *publishBuilder) Message(msg interface{}) *publishBuilder {
 	return b
 }
 
-// Meta sets the Meta Payload for the Publish request.
+// Meta sets the Meta Payload for the Publish request, a JSON serializable value, usually a map, of at most 32KB serialized.
+// The meta is used for stream filtering by the FilterExpression of the subscribers, it is not part of the stored message.
 func (b *publishBuilder) Meta(meta interface{}) *publishBuilder {
 	b.opts.Meta = meta
 
 	return b
 }
 
-// UsePost sends the Publish request using HTTP POST.
+// UsePost sends the Publish request using HTTP POST, or GET when false.
+// When it is not called POST is used for the messages whose URL encoding is larger than 7KB, GET otherwise.
 func (b *publishBuilder) UsePost(post bool) *publishBuilder {
 	b.opts.UsePost = post
+	b.opts.setUsePost = true
+
+	return b
+}
+
+// Compress gzips the body of a POST Publish and sends it with Content-Encoding: gzip, it has no effect on GET.
+func (b *publishBuilder) Compress(compress bool) *publishBuilder {
+	b.opts.Compress = compress
 
 	return b
 }
 
-// ShouldStore if true the messages are stored in History
+// ShouldStore sends store=1 or store=0, when it is not called the store param is omitted and the key's History setting applies.
 func (b *publishBuilder) ShouldStore(store bool) *publishBuilder {
 	b.opts.ShouldStore = store
-	if store {
-		b.opts.setShouldStore = true
-	} else {
-		b.opts.setShouldStore = false
-	}
+	b.opts.setShouldStore = true
 
 	return b
 }
 
-// Serialize when true (default) serializes the payload before publish.
-// Set to false if pre serialized payload is being used.
+// StoreInHistory is an alias of ShouldStore.
+func (b *publishBuilder) StoreInHistory(store bool) *publishBuilder {
+	return b.ShouldStore(store)
+}
+
+// Serialize when true (default) the Message is JSON serialized before publish.
+// Set to false if pre serialized payload is being used, the Message must then be a JSON string or []byte.
+// It is sent as is without JSON wrapping, URL encoded in the path or in the body when UsePost is set.
 func (b *publishBuilder) Serialize(serialize bool) *publishBuilder {
 	b.opts.Serialize = serialize
 
@@ -179,9 +215,37 @@ func (b *publishBuilder) QueryParam(queryParam map[string]string) *publishBuilde
 	return b
 }
 
+// Timeout sets the timeout for the Publish request.
+func (b *publishBuilder) Timeout(timeout time.Duration) *publishBuilder {
+	b.opts.Timeout = timeout
+
+	return b
+}
+
+// SignedURL returns the URL of the Publish request without executing it, it returns an error when the Publish
+// is sent with POST.
+func (b *publishBuilder) SignedURL() (string, error) {
+	return signedURL(b.opts)
+}
+
 // Execute runs the Publish request.
 func (b *publishBuilder) Execute() (*PublishResponse, StatusResponse, error) {
+	// each Execute publishes with the next seqn, the throttle retries below keep it
+	b.opts.seqn = 0
 	rawJSON, status, err := executeRequest(b.opts)
+
+	retries := b.opts.pubnub.Config.MaximumReconnectionRetries
+	for retry := 0; err != nil && b.opts.pubnub.Config.PublishRetryOnThrottle && (retries == -1 || retry < retries); retry++ {
+		wait, throttled := publishRetryAfter(err)
+		if !throttled {
+			break
+		}
+		b.opts.pubnub.Config.Log.Printf("pubnub: publish throttled, retry %d after %s\n", retry+1, wait)
+		if !sleepContext(b.opts.ctx, wait) {
+			break
+		}
+		rawJSON, status, err = executeRequest(b.opts)
+	}
 	if err != nil {
 		return emptyPublishResponse, status, err
 	}
@@ -189,6 +253,49 @@ func (b *publishBuilder) Execute() (*PublishResponse, StatusResponse, error) {
 	return newPublishResponse(rawJSON, status)
 }
 
+// publishRetryAfter returns the wait of a 429 response, read from its Retry-After seconds or date, 1s when it is missing.
+func publishRetryAfter(err error) (time.Duration, bool) {
+	e, ok := err.(*pnerr.ServerError)
+	if !ok || e.StatusCode != http.StatusTooManyRequests {
+		return 0, false
+	}
+
+	wait := time.Second
+	retryAfter := e.Header.Get("Retry-After")
+	if seconds, err := strconv.Atoi(retryAfter); err == nil {
+		wait = time.Duration(seconds) * time.Second
+	} else if date, err := http.ParseTime(retryAfter); err == nil {
+		wait = date.Sub(time.Now())
+	}
+
+	if wait < 0 {
+		wait = 0
+	}
+	if wait > publishMaxRetryAfter {
+		wait = publishMaxRetryAfter
+	}
+
+	return wait, true
+}
+
+// sleepContext waits for d, it returns false when ctx is done first.
+func sleepContext(ctx Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	if ctx == nil {
+		<-timer.C
+		return true
+	}
+
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
 func (o *publishOpts) config() Config {
 	return *o.pubnub.Config
 }
@@ -214,34 +321,118 @@ func (o *publishOpts) validate() error {
 		return newValidationError(o, StrMissingChannel)
 	}
 
+	if strings.Contains(o.Channel, ",") {
+		return newValidationError(o, fmt.Sprintf("%s %s: Publish sends to a single channel, call Publish once for each channel", StrInvalidChannel, o.Channel))
+	}
+
+	if err := validateChannelNames(o, o.Channel); err != nil {
+		return err
+	}
+
 	if o.Message == nil {
 		return newValidationError(o, StrMissingMessage)
 	}
 
+	if !o.Serialize {
+		switch o.Message.(type) {
+		case string, []byte:
+		default:
+			return newValidationError(o, StrMessageNotSerialized)
+		}
+	}
+
+	if size := o.messageSize(o.usePost()); size > publishMaxMessageSize {
+		return newValidationError(o, fmt.Sprintf("%s: %d bytes, max %d", StrMessageTooLarge, size, publishMaxMessageSize))
+	}
+
+	if o.Meta != nil {
+		meta, err := utils.ValueAsString(o.Meta)
+		if err != nil {
+			return newValidationError(o, fmt.Sprintf("%s: %s", StrInvalidMeta, err.Error()))
+		}
+		if size := len(meta); size > publishMaxMetaSize {
+			return newValidationError(o, fmt.Sprintf("%s: %d bytes, max %d", StrMetaTooLarge, size, publishMaxMetaSize))
+		}
+	}
+
 	return nil
 }
 
+// usePost returns true when the Publish is sent with POST, UsePost when it is set or else
+// when the message is too large for a GET.
+func (o *publishOpts) usePost() bool {
+	if o.setUsePost || o.UsePost {
+		return o.UsePost
+	}
+
+	return o.messageSize(false) > publishMaxGetMessageSize
+}
+
+// messageSize estimates the size of the message as sent, the message is URL encoded
+// in a GET publish and expanded by the encryption when a CipherKey is set.
+// Messages which can't be serialized return 0 and fail when the request is built.
+func (o *publishOpts) messageSize(post bool) int {
+	var msg string
+	if o.Serialize {
+		jsonEncBytes, errEnc := json.Marshal(o.Message)
+		if errEnc != nil {
+			return 0
+		}
+		msg = string(jsonEncBytes)
+	} else {
+		msg, _ = o.message().(string)
+	}
+
+	if o.pubnub.Config.CipherKey != "" {
+		encrypted := (len(msg)/aes.BlockSize + 1) * aes.BlockSize
+		if o.pubnub.Config.UseRandomInitializationVector {
+			encrypted += aes.BlockSize
+		}
+		// the base64 ciphertext is sent as a JSON string, the quotes are URL encoded in a GET publish
+		size := base64.StdEncoding.EncodedLen(encrypted) + 2
+		if !post {
+			size += 4
+		}
+		return size
+	}
+
+	if post {
+		return len(msg)
+	}
+
+	return len(utils.URLEncode(msg))
+}
+
+// message returns the Message to publish, a pre serialized []byte Message is returned as a string.
+func (o *publishOpts) message() interface{} {
+	if b, ok := o.Message.([]byte); ok && !o.Serialize {
+		return string(b)
+	}
+	return o.Message
+}
+
 func (o *publishOpts) encryptProcessing(cipherKey string) (string, error) {
 	var msg string
 	var errJSONMarshal error
+	message := o.message()
 
-	o.pubnub.Config.Log.Println("EncryptString: encrypting", fmt.Sprintf("%s", o.Message))
+	o.pubnub.Config.Log.Println("EncryptString: encrypting", fmt.Sprintf("%s", message))
 	if o.pubnub.Config.DisablePNOtherProcessing {
-		if msg, errJSONMarshal = utils.SerializeEncryptAndSerialize(o.Message, cipherKey, o.Serialize); errJSONMarshal != nil {
+		if msg, errJSONMarshal = utils.SerializeEncryptAndSerializeWithIV(message, cipherKey, o.Serialize, o.pubnub.Config.UseRandomInitializationVector); errJSONMarshal != nil {
 			o.pubnub.Config.Log.Printf("error in serializing: %v\n", errJSONMarshal)
 			return "", errJSONMarshal
 		}
 	} else {
 		//encrypt pn_other only
-		o.pubnub.Config.Log.Println("encrypt pn_other only", "reflect.TypeOf(data).Kind()", reflect.TypeOf(o.Message).Kind(), o.Message)
-		switch v := o.Message.(type) {
+		o.pubnub.Config.Log.Println("encrypt pn_other only", "reflect.TypeOf(data).Kind()", reflect.TypeOf(message).Kind(), message)
+		switch v := message.(type) {
 		case map[string]interface{}:
 
 			msgPart, ok := v["pn_other"].(string)
 
 			if ok {
 				o.pubnub.Config.Log.Println(ok, msgPart)
-				encMsg, errJSONMarshal := utils.SerializeAndEncrypt(msgPart, cipherKey, o.Serialize)
+				encMsg, errJSONMarshal := utils.SerializeAndEncryptWithIV(msgPart, cipherKey, o.Serialize, o.pubnub.Config.UseRandomInitializationVector)
 				if errJSONMarshal != nil {
 					o.pubnub.Config.Log.Printf("error in serializing: %v\n", errJSONMarshal)
 					return "", errJSONMarshal
@@ -254,14 +445,14 @@ func (o *publishOpts) encryptProcessing(cipherKey string) (string, error) {
 				}
 				msg = string(jsonEncBytes)
 			} else {
-				if msg, errJSONMarshal = utils.SerializeEncryptAndSerialize(o.Message, cipherKey, o.Serialize); errJSONMarshal != nil {
+				if msg, errJSONMarshal = utils.SerializeEncryptAndSerializeWithIV(message, cipherKey, o.Serialize, o.pubnub.Config.UseRandomInitializationVector); errJSONMarshal != nil {
 					o.pubnub.Config.Log.Printf("error in serializing: %v\n", errJSONMarshal)
 					return "", errJSONMarshal
 				}
 			}
 			break
 		default:
-			if msg, errJSONMarshal = utils.SerializeEncryptAndSerialize(o.Message, cipherKey, o.Serialize); errJSONMarshal != nil {
+			if msg, errJSONMarshal = utils.SerializeEncryptAndSerializeWithIV(message, cipherKey, o.Serialize, o.pubnub.Config.UseRandomInitializationVector); errJSONMarshal != nil {
 				o.pubnub.Config.Log.Printf("error in serializing: %v\n", errJSONMarshal)
 				return "", errJSONMarshal
 			}
@@ -273,11 +464,11 @@ func (o *publishOpts) encryptProcessing(cipherKey string) (string, error) {
 }
 
 func (o *publishOpts) buildPath() (string, error) {
-	if o.UsePost == true {
+	if o.usePost() {
 		return fmt.Sprintf(publishPostPath,
 			o.pubnub.Config.PublishKey,
 			o.pubnub.Config.SubscribeKey,
-			utils.URLEncode(o.Channel),
+			utils.URLEncode(o.pubnub.Config.channelName(o.Channel)),
 			"0"), nil
 	}
 
@@ -299,7 +490,7 @@ func (o *publishOpts) buildPath() (string, error) {
 			}
 			msg = string(jsonEncBytes)
 		} else {
-			if serializedMsg, ok := o.Message.(string); ok {
+			if serializedMsg, ok := o.message().(string); ok {
 				msg = serializedMsg
 			} else {
 				return "", pnerr.NewBuildRequestError("buildpath: Message is not JSON serialized.")
@@ -310,13 +501,13 @@ func (o *publishOpts) buildPath() (string, error) {
 	return fmt.Sprintf(publishGetPath,
 		o.pubnub.Config.PublishKey,
 		o.pubnub.Config.SubscribeKey,
-		utils.URLEncode(o.Channel),
+		utils.URLEncode(o.pubnub.Config.channelName(o.Channel)),
 		"0",
 		utils.URLEncode(msg)), nil
 }
 
 func (o *publishOpts) buildQuery() (*url.Values, error) {
-	q := defaultQuery(o.pubnub.Config.UUID, o.pubnub.telemetryManager)
+	q := defaultQuery(o.pubnub.Config, o.pubnub.telemetryManager)
 
 	if o.Meta != nil {
 		meta, err := utils.ValueAsString(o.Meta)
@@ -341,16 +532,19 @@ func (o *publishOpts) buildQuery() (*url.Values, error) {
 		}
 	}
 
-	seqn := strconv.Itoa(o.pubnub.getPublishSequence())
+	if o.seqn == 0 {
+		o.seqn = o.pubnub.getPublishSequence()
+	}
+	seqn := strconv.Itoa(o.seqn)
 	o.pubnub.Config.Log.Println("seqn:", seqn)
 	q.Set("seqn", seqn)
 
+	o.pubnub.tokenManager.SetAuthParan(q, o.Channel, PNChannels)
 	SetQueryParam(q, o.QueryParam)
 
 	if o.DoNotReplicate == true {
 		q.Set("norep", "true")
 	}
-	o.pubnub.Config.Log.Println(q)
 
 	return q, nil
 }
@@ -360,7 +554,34 @@ func (o *publishOpts) jobQueue() chan *JobQItem {
 }
 
 func (o *publishOpts) buildBody() ([]byte, error) {
-	if o.UsePost {
+	body, err := o.postBody()
+	if err != nil || !o.Compress || !o.usePost() {
+		return body, err
+	}
+
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write(body); err != nil {
+		return []byte{}, err
+	}
+	if err := w.Close(); err != nil {
+		return []byte{}, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (o *publishOpts) requestHeaders() map[string]string {
+	if o.Compress && o.usePost() {
+		return map[string]string{"Content-Encoding": "gzip"}
+	}
+
+	return nil
+}
+
+// postBody returns the JSON body of a POST Publish, uncompressed.
+func (o *publishOpts) postBody() ([]byte, error) {
+	if o.usePost() {
 		if cipherKey := o.pubnub.Config.CipherKey; cipherKey != "" {
 			msg, errJSONMarshal := o.encryptProcessing(cipherKey)
 			if errJSONMarshal != nil {
@@ -376,7 +597,7 @@ func (o *publishOpts) buildBody() ([]byte, error) {
 			}
 			return jsonEncBytes, nil
 		}
-		serializedMsg, ok := o.Message.(string)
+		serializedMsg, ok := o.message().(string)
 		if ok {
 			return []byte(serializedMsg), nil
 		}
@@ -386,7 +607,7 @@ func (o *publishOpts) buildBody() ([]byte, error) {
 }
 
 func (o *publishOpts) httpMethod() string {
-	if o.UsePost {
+	if o.usePost() {
 		return "POST"
 	}
 	return "GET"
@@ -411,3 +632,15 @@ func (o *publishOpts) operationType() OperationType {
 func (o *publishOpts) telemetryManager() *TelemetryManager {
 	return o.pubnub.telemetryManager
 }
+
+func (o *publishOpts) tokenManager() *TokenManager {
+	return o.pubnub.tokenManager
+}
+
+func (o *publishOpts) timeout() time.Duration {
+	return o.Timeout
+}
+
+func (o *publishOpts) transport() http.RoundTripper {
+	return o.Transport
+}
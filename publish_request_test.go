@@ -1,11 +1,18 @@
 package pubnub
 
 import (
+	"compress/gzip"
 	"fmt"
+	"io/ioutil"
+	"net/http"
 	"net/url"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/pubnub/go/pnerr"
 	h "github.com/pubnub/go/tests/helpers"
+	"github.com/pubnub/go/utils"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -95,6 +102,7 @@ func AssertSuccessPublishGet2(t *testing.T, expectedString string, message inter
 	expected.Set("ttl", "10")
 	expected.Set("pnsdk", Version)
 	expected.Set("norep", "true")
+	expected.Set("store", "0")
 
 	h.AssertQueriesEqual(t, expected, query,
 		[]string{"seqn", "pnsdk", "uuid"}, []string{})
@@ -132,6 +140,7 @@ func AssertSuccessPublishGet3(t *testing.T, expectedString string, message inter
 	expected.Set("norep", "true")
 	expected.Set("q1", "v1")
 	expected.Set("q2", "v2")
+	expected.Set("store", "0")
 
 	h.AssertQueriesEqual(t, expected, query,
 		[]string{"seqn", "pnsdk", "uuid"}, []string{})
@@ -409,6 +418,28 @@ func TestPublishStore(t *testing.T) {
 	assert.Empty(body)
 }
 
+func TestPublishShouldStoreParam(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+
+	query, err := pn.Publish().Channel("ch").Message("hey").opts.buildQuery()
+	assert.Nil(err)
+	_, ok := (*query)["store"]
+	assert.False(ok)
+
+	query, err = pn.Publish().Channel("ch").Message("hey").ShouldStore(true).opts.buildQuery()
+	assert.Nil(err)
+	assert.Equal("1", query.Get("store"))
+
+	query, err = pn.Publish().Channel("ch").Message("hey").ShouldStore(false).opts.buildQuery()
+	assert.Nil(err)
+	assert.Equal("0", query.Get("store"))
+
+	query, err = pn.Publish().Channel("ch").Message("hey").StoreInHistory(false).opts.buildQuery()
+	assert.Nil(err)
+	assert.Equal("0", query.Get("store"))
+}
+
 func TestPublishEncrypt(t *testing.T) {
 	assert := assert.New(t)
 
@@ -539,5 +570,474 @@ func TestPublishValidateSubscribeKey(t *testing.T) {
 		pubnub: pn,
 	}
 
-	assert.Equal("pubnub/validation: pubnub: \x03: Missing Subscribe Key", opts.validate().Error())
+	assert.Equal("pubnub/validation: pubnub: Publish: Missing Subscribe Key", opts.validate().Error())
+}
+
+func TestPublishSerializeWirePayload(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+
+	o := newPublishBuilder(pn)
+	o.Channel("ch")
+	o.Message("{\"one\":\"hey\"}")
+
+	serializedPath, err := o.opts.buildPath()
+	assert.Nil(err)
+	assert.Equal("/publish/demo/demo/0/ch/0/%22%7B%5C%22one%5C%22%3A%5C%22hey%5C%22%7D%22", serializedPath)
+
+	o.Serialize(false)
+	assert.Nil(o.opts.validate())
+
+	rawPath, err := o.opts.buildPath()
+	assert.Nil(err)
+	assert.Equal("/publish/demo/demo/0/ch/0/%7B%22one%22%3A%22hey%22%7D", rawPath)
+	assert.NotEqual(serializedPath, rawPath)
+
+	o.Message([]byte("{\"one\":\"hey\"}"))
+	assert.Nil(o.opts.validate())
+
+	bytesPath, err := o.opts.buildPath()
+	assert.Nil(err)
+	assert.Equal(rawPath, bytesPath)
+
+	o.UsePost(true)
+	body, err := o.opts.buildBody()
+	assert.Nil(err)
+	assert.Equal("{\"one\":\"hey\"}", string(body))
+}
+
+func TestPublishDoNotSerializeValidation(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+
+	o := newPublishBuilder(pn)
+	o.Channel("ch")
+	o.Message(map[string]string{"one": "hey"})
+	o.Serialize(false)
+
+	assert.Equal("pubnub/validation: pubnub: Publish: Message is not JSON serialized", o.opts.validate().Error())
+}
+
+const publishSentBody = `[1,"Sent","14981595400555832"]`
+
+func TestPublishPostLargeMessage(t *testing.T) {
+	assert := assert.New(t)
+
+	transport := newStubTransport(200, publishSentBody)
+	pn := NewPubNub(NewDemoConfig())
+	pn.SetClient(&http.Client{Transport: transport})
+
+	message := strings.Repeat("a", 10*1024)
+
+	res, _, err := pn.Publish().Channel("ch").Message(message).UsePost(true).Execute()
+
+	assert.Nil(err)
+	assert.Equal(int64(14981595400555832), res.Timestamp)
+	req, body := transport.last()
+	assert.Equal("POST", req.Method)
+	assert.Equal("application/json", req.Header.Get("Content-Type"))
+	assert.True(strings.HasSuffix(req.URL.Opaque, "/publish/demo/demo/0/ch/0"))
+	assert.Equal(fmt.Sprintf("\"%s\"", message), body)
+}
+
+func TestPublishAutoPost(t *testing.T) {
+	assert := assert.New(t)
+
+	transport := newStubTransport(200, publishSentBody)
+	pn := NewPubNub(NewDemoConfig())
+	pn.SetClient(&http.Client{Transport: transport})
+
+	_, _, err := pn.Publish().Channel("ch").Message("hey").Execute()
+	assert.Nil(err)
+	req, _ := transport.last()
+	assert.Equal("GET", req.Method)
+
+	message := strings.Repeat("a b", 3*1024)
+
+	_, _, err = pn.Publish().Channel("ch").Message(message).Execute()
+	assert.Nil(err)
+	req, body := transport.last()
+	assert.Equal("POST", req.Method)
+	assert.Equal(fmt.Sprintf("\"%s\"", message), body)
+
+	_, _, err = pn.Publish().Channel("ch").Message(message).UsePost(false).Execute()
+	assert.Nil(err)
+	req, _ = transport.last()
+	assert.Equal("GET", req.Method)
+
+	// the method is derived from the message, validate leaves the opts as set
+	o := newPublishBuilder(pn)
+	o.Channel("ch")
+	o.Message(message)
+	assert.Nil(o.opts.validate())
+	assert.False(o.opts.UsePost)
+	assert.Equal("POST", o.opts.httpMethod())
+
+	o.Message("hey")
+	assert.Equal("GET", o.opts.httpMethod())
+}
+
+func TestPublishCompress(t *testing.T) {
+	assert := assert.New(t)
+
+	transport := newStubTransport(200, publishSentBody)
+	pn := NewPubNub(NewDemoConfig())
+	pn.SetClient(&http.Client{Transport: transport})
+
+	_, _, err := pn.Publish().Channel("ch").Message("hey").UsePost(true).Compress(true).Execute()
+	assert.Nil(err)
+	req, sent := transport.last()
+	assert.Equal("POST", req.Method)
+	assert.Equal("gzip", req.Header.Get("Content-Encoding"))
+
+	r, err := gzip.NewReader(strings.NewReader(sent))
+	assert.Nil(err)
+	body, err := ioutil.ReadAll(r)
+	assert.Nil(err)
+	assert.Equal(`"hey"`, string(body))
+
+	_, _, err = pn.Publish().Channel("ch").Message("hey").Compress(true).Execute()
+	assert.Nil(err)
+	req, sent = transport.last()
+	assert.Equal("GET", req.Method)
+	assert.Equal("", req.Header.Get("Content-Encoding"))
+	assert.Empty(sent)
+}
+
+func TestPublishPostSignatureV2(t *testing.T) {
+	assert := assert.New(t)
+
+	config := NewDemoConfig()
+	config.SecretKey = "secret"
+	config.UsePAMV3 = true
+	pn := NewPubNub(config)
+
+	o := newPublishBuilder(pn)
+	o.Channel("ch")
+	o.Message("hey")
+	o.UsePost(true)
+
+	u, err := buildURL(o.opts)
+	assert.Nil(err)
+
+	query, err := url.ParseQuery(u.RawQuery)
+	assert.Nil(err)
+	signature := query.Get("signature")
+	query.Del("signature")
+
+	body, err := o.opts.buildBody()
+	assert.Nil(err)
+
+	expected := createSignatureV2FromStrings("POST", config.PublishKey, config.SecretKey,
+		"/publish/demo/demo/0/ch/0", utils.PreparePamParams(&query), string(body), nil)
+
+	assert.Equal(expected, signature)
+	assert.NotEqual(createSignatureV2FromStrings("POST", config.PublishKey, config.SecretKey,
+		"/publish/demo/demo/0/ch/0", utils.PreparePamParams(&query), "", nil), signature)
+}
+
+func TestPublishPostSignatureV2RandomIV(t *testing.T) {
+	assert := assert.New(t)
+
+	config := NewDemoConfig()
+	config.SecretKey = "secret"
+	config.CipherKey = "enigma"
+	config.UseRandomInitializationVector = true
+	pn := NewPubNub(config)
+	transport := newStubTransport(200, publishSentBody)
+	pn.SetClient(&http.Client{Transport: transport})
+
+	_, _, err := pn.Publish().Channel("ch").Message("hey").UsePost(true).Execute()
+	assert.Nil(err)
+
+	// the signature covers the ciphertext which is sent
+	req, body := transport.last()
+	query, err := url.ParseQuery(req.URL.RawQuery)
+	assert.Nil(err)
+	signature := query.Get("signature")
+	query.Del("signature")
+	assert.Equal(createSignatureV2FromStrings("POST", config.PublishKey, config.SecretKey,
+		"/publish/demo/demo/0/ch/0", utils.PreparePamParams(&query), body, nil), signature)
+}
+
+func TestPublishValidateChannelName(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+
+	for _, c := range []string{"/", "?", "#"} {
+		o := newPublishBuilder(pn)
+		o.Channel("a" + c + "b")
+		o.Message("hey")
+
+		assert.Equal(fmt.Sprintf("pubnub/validation: pubnub: Publish: Invalid Channel a%sb: '%s' is not allowed", c, c), o.opts.validate().Error())
+	}
+
+	o := newPublishBuilder(pn)
+	o.Channel("-._~:[]@!$&'()*+;=`|")
+	o.Message("hey")
+
+	assert.Nil(o.opts.validate())
+}
+
+func TestPublishValidateSingleChannel(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+
+	_, _, err := pn.Publish().Channel("ch1,ch2").Message([]string{"hey1", "hey2"}).Execute()
+	assert.Equal("pubnub/validation: pubnub: Publish: Invalid Channel ch1,ch2: Publish sends to a single channel, call Publish once for each channel", err.Error())
+}
+
+// newSlowTransport returns a transport answering with body after the delay, unless the request is cancelled before.
+func newSlowTransport(delay time.Duration, body string) *testTransport {
+	return newTestTransport(func(req *http.Request) (*http.Response, error) {
+		select {
+		case <-time.After(delay):
+			return stubResponse(req, 200, body), nil
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+	})
+}
+
+func TestPublishTimeout(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+	pn.SetClient(&http.Client{Transport: newSlowTransport(2*time.Second, publishSentBody)})
+
+	start := time.Now()
+	_, _, err := pn.Publish().Channel("ch").Message("hey").Timeout(50 * time.Millisecond).Execute()
+
+	assert.NotNil(err)
+	assert.Contains(err.Error(), "context deadline exceeded")
+	assert.True(time.Since(start) < time.Second)
+}
+
+func TestPublishTimeoutWithContext(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+	pn.SetClient(&http.Client{Transport: newSlowTransport(2*time.Second, publishSentBody)})
+
+	ctx, cancel := contextWithTimeout(backgroundContext, 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, _, err := pn.PublishWithContext(ctx).Channel("ch").Message("hey").Timeout(5 * time.Second).Execute()
+
+	assert.NotNil(err)
+	assert.True(time.Since(start) < time.Second)
+
+	ctx, cancel = contextWithTimeout(backgroundContext, 5*time.Second)
+	defer cancel()
+
+	start = time.Now()
+	_, _, err = pn.PublishWithContext(ctx).Channel("ch").Message("hey").Timeout(50 * time.Millisecond).Execute()
+
+	assert.NotNil(err)
+	assert.True(time.Since(start) < time.Second)
+}
+
+func TestPublishTimeoutNotReached(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+	pn.SetClient(&http.Client{Transport: newSlowTransport(10*time.Millisecond, publishSentBody)})
+
+	res, _, err := pn.Publish().Channel("ch").Message("hey").Timeout(time.Second).Execute()
+
+	assert.Nil(err)
+	assert.Equal(int64(14981595400555832), res.Timestamp)
+}
+
+func TestPublishMessageTooLarge(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+
+	o := newPublishBuilder(pn)
+	o.Channel("ch")
+	o.UsePost(true)
+	o.Message(strings.Repeat("a", 39998))
+	assert.Equal("pubnub/validation: pubnub: Publish: Message too large: 40000 bytes, max 32768", o.opts.validate().Error())
+
+	_, _, err := o.Execute()
+	assert.Contains(err.Error(), StrMessageTooLarge)
+
+	o.Message(strings.Repeat("a", 30000))
+	assert.Nil(o.opts.validate())
+
+	// the URL encoding of a GET publish expands the message
+	o.UsePost(false)
+	o.Message(strings.Repeat(" ", 12000))
+	assert.Contains(o.opts.validate().Error(), "Message too large: 36006 bytes")
+}
+
+func TestPublishMessageTooLargeCipher(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+
+	o := newPublishBuilder(pn)
+	o.Channel("ch")
+	o.UsePost(true)
+	o.Message(strings.Repeat("a", 25000))
+	assert.Nil(o.opts.validate())
+
+	pn.Config.CipherKey = "enigma"
+	assert.Contains(o.opts.validate().Error(), StrMessageTooLarge)
+
+	body, err := o.opts.buildBody()
+	assert.Nil(err)
+	assert.Equal(o.opts.messageSize(true), len(body))
+}
+
+func TestPublishMetaSignature(t *testing.T) {
+	assert := assert.New(t)
+
+	config := NewDemoConfig()
+	config.SecretKey = "secret"
+	pn := NewPubNub(config)
+
+	o := newPublishBuilder(pn)
+	o.Channel("ch")
+	o.Message("hey")
+	o.Meta(map[string]string{"k": "a b!'"})
+
+	u, err := buildURL(o.opts)
+	assert.Nil(err)
+
+	// the server signs the params as received
+	query, err := url.ParseQuery(u.RawQuery)
+	assert.Nil(err)
+	assert.Equal(`{"k":"a b!'"}`, query.Get("meta"))
+	signature := query.Get("signature")
+	query.Del("signature")
+
+	path := "/publish/demo/demo/0/ch/0/%22hey%22"
+	assert.Equal(createSignatureV2FromStrings("GET", config.PublishKey, config.SecretKey,
+		path, utils.PreparePamParams(&query), "", nil), signature)
+
+	query.Set("meta", `{"k":"other"}`)
+	assert.NotEqual(createSignatureV2FromStrings("GET", config.PublishKey, config.SecretKey,
+		path, utils.PreparePamParams(&query), "", nil), signature)
+}
+
+func TestPublishValidateMeta(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+
+	o := newPublishBuilder(pn)
+	o.Channel("ch")
+	o.Message("hey")
+	o.Meta(map[string]interface{}{"region": "eu", "priority": 2})
+	assert.Nil(o.opts.validate())
+
+	o.Meta(make(chan int))
+	assert.Contains(o.opts.validate().Error(), StrInvalidMeta)
+
+	o.Meta(map[string]interface{}{"f": func() {}})
+	assert.Contains(o.opts.validate().Error(), StrInvalidMeta)
+
+	o.Meta(map[string]string{"k": strings.Repeat("a", publishMaxMetaSize)})
+	assert.Contains(o.opts.validate().Error(), StrMetaTooLarge)
+
+	_, _, err := o.Execute()
+	assert.Contains(err.Error(), StrMetaTooLarge)
+}
+
+// newThrottleTransport returns a transport answering with a 429 and the first Retry-After of retryAfter,
+// which is then removed, the requests are published once retryAfter is empty.
+func newThrottleTransport(retryAfter *[]string) *testTransport {
+	return newTestTransport(func(req *http.Request) (*http.Response, error) {
+		if len(*retryAfter) == 0 {
+			return stubResponse(req, 200, publishSentBody), nil
+		}
+		resp := stubResponse(req, 429, `{"status":429,"error":true,"message":"Too Many Requests"}`)
+		resp.Header = http.Header{"Retry-After": {(*retryAfter)[0]}}
+		*retryAfter = (*retryAfter)[1:]
+		return resp, nil
+	})
+}
+
+func TestPublishRetryOnThrottle(t *testing.T) {
+	assert := assert.New(t)
+	retryAfter := []string{"0", "0"}
+	transport := newThrottleTransport(&retryAfter)
+	config := NewDemoConfig()
+	config.PublishRetryOnThrottle = true
+	pn := NewPubNub(config)
+	pn.SetClient(&http.Client{Transport: transport})
+
+	res, _, err := pn.Publish().Channel("ch").Message("hey").Execute()
+	assert.Nil(err)
+	assert.Equal(int64(14981595400555832), res.Timestamp)
+	assert.Equal([]string{"1", "1", "1"}, transport.queries("seqn"))
+
+	pn.Config.MaximumReconnectionRetries = 1
+	retryAfter = []string{"0", "0"}
+	sent := transport.count()
+	_, status, err := pn.Publish().Channel("ch").Message("hey").Execute()
+	assert.NotNil(err)
+	assert.Equal(429, status.StatusCode)
+	assert.Equal([]string{"2", "2"}, transport.queries("seqn")[sent:])
+
+	pn.Config.PublishRetryOnThrottle = false
+	retryAfter = []string{"0"}
+	sent = transport.count()
+	_, _, err = pn.Publish().Channel("ch").Message("hey").Execute()
+	assert.NotNil(err)
+	assert.Equal(sent+1, transport.count())
+
+	// the same builder executed again publishes with a new seqn
+	pn.Config.PublishRetryOnThrottle = true
+	retryAfter = []string{"0"}
+	sent = transport.count()
+	builder := pn.Publish().Channel("ch").Message("hey")
+	_, _, err = builder.Execute()
+	assert.Nil(err)
+	_, _, err = builder.Execute()
+	assert.Nil(err)
+	assert.Equal([]string{"4", "4", "5"}, transport.queries("seqn")[sent:])
+}
+
+func TestPublishRetryOnThrottleContextCancel(t *testing.T) {
+	assert := assert.New(t)
+	retryAfter := []string{"30"}
+	transport := newThrottleTransport(&retryAfter)
+	config := NewDemoConfig()
+	config.PublishRetryOnThrottle = true
+	pn := NewPubNub(config)
+	pn.SetClient(&http.Client{Transport: transport})
+
+	ctx, cancel := contextWithCancel(backgroundContext)
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	start := time.Now()
+	_, _, err := pn.PublishWithContext(ctx).Channel("ch").Message("hey").Execute()
+	assert.NotNil(err)
+	assert.True(time.Since(start) < 5*time.Second)
+	assert.Equal(1, transport.count())
+}
+
+func TestPublishRetryAfter(t *testing.T) {
+	assert := assert.New(t)
+
+	throttled := func(retryAfter string) error {
+		return &pnerr.ServerError{StatusCode: 429, Header: http.Header{"Retry-After": {retryAfter}}}
+	}
+
+	wait, ok := publishRetryAfter(throttled("2"))
+	assert.True(ok)
+	assert.Equal(2*time.Second, wait)
+
+	wait, ok = publishRetryAfter(throttled("3600"))
+	assert.True(ok)
+	assert.Equal(publishMaxRetryAfter, wait)
+
+	wait, ok = publishRetryAfter(throttled(time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat)))
+	assert.True(ok)
+	assert.Equal(time.Duration(0), wait)
+
+	wait, ok = publishRetryAfter(throttled(""))
+	assert.True(ok)
+	assert.Equal(time.Second, wait)
+
+	_, ok = publishRetryAfter(&pnerr.ServerError{StatusCode: 500})
+	assert.False(ok)
 }
@@ -7,6 +7,8 @@ import (
 	"net/http"
 	"runtime"
 	"sync"
+
+	"github.com/pubnub/go/utils"
 )
 
 // Default constants
@@ -30,6 +32,8 @@ const (
 	StrMissingMessage = "Missing Message"
 	// StrMissingSecretKey shows Missing Secret Key message
 	StrMissingSecretKey = "Missing Secret Key"
+	// StrMissingSecretKeyPAM shows the Secret Key is required for PAM operations message
+	StrMissingSecretKeyPAM = "Secret Key is required for PAM operations"
 	// StrMissingUUID shows Missing UUID message
 	StrMissingUUID = "Missing UUID"
 	// StrMissingDeviceID shows Missing Device ID message
@@ -40,6 +44,38 @@ const (
 	StrChannelsTimetoken = "Missing Channels Timetoken"
 	// StrChannelsTimetokenLength shows Length of Channels Timetoken message
 	StrChannelsTimetokenLength = "Length of Channels Timetoken and Channels do not match"
+	// StrMessageNotSerialized shows Message is not JSON serialized message
+	StrMessageNotSerialized = "Message is not JSON serialized"
+	// StrInvalidMeta shows Invalid Meta message
+	StrInvalidMeta = "Invalid Meta"
+	// StrMetaTooLarge shows Meta Too Large message
+	StrMetaTooLarge = "Meta Too Large"
+	// StrInvalidTTL shows Invalid TTL message
+	StrInvalidTTL = "Invalid TTL"
+	// StrInvalidFilter shows Invalid Filter message
+	StrInvalidFilter = "Invalid Filter"
+	// StrGrantTooLarge shows Grant Too Large message
+	StrGrantTooLarge = "Grant Too Large"
+	// StrInvalidChannel shows Invalid Channel message
+	StrInvalidChannel = "Invalid Channel"
+	// StrInvalidLimit shows Invalid Limit message
+	StrInvalidLimit = "Invalid Limit"
+	// StrInvalidKey shows Invalid Key message
+	StrInvalidKey = "Invalid Key"
+	// StrInvalidInclude shows Invalid Include message
+	StrInvalidInclude = "Invalid Include"
+	// StrMessageTooLarge shows Message too large message
+	StrMessageTooLarge = "Message too large"
+	// StrInvalidTimeout shows Invalid Timeout message
+	StrInvalidTimeout = "Invalid Timeout"
+	// StrInvalidGroupPermissions shows Invalid Group Permissions message
+	StrInvalidGroupPermissions = "Invalid Group Permissions"
+	// StrConflictingChange shows Conflicting Change message
+	StrConflictingChange = "Conflicting Change"
+	// StrInvalidPattern shows Invalid Pattern message
+	StrInvalidPattern = "Invalid Pattern"
+	// StrStateTooLarge shows State too large message
+	StrStateTooLarge = "State too large"
 )
 
 // PubNub No server connection will be established when you create a new PubNub object.
@@ -49,6 +85,7 @@ type PubNub struct {
 
 	Config               *Config
 	nextPublishSequence  int
+	publishSequenceKey   string
 	publishSequenceMutex sync.RWMutex
 	subscriptionManager  *SubscriptionManager
 	telemetryManager     *TelemetryManager
@@ -60,6 +97,8 @@ type PubNub struct {
 	ctx                  Context
 	cancel               func()
 	tokenManager         *TokenManager
+	instanceID           string
+	stateCache           *stateCache
 }
 
 //
@@ -83,6 +122,12 @@ func (pn *PubNub) Subscribe() *subscribeBuilder {
 	return newSubscribeBuilder(pn)
 }
 
+// SubscribeWithContext ties the subscription to ctx, when it is done the channels and groups are unsubscribed,
+// their leave is sent and PNDisconnectedCategory is announced.
+func (pn *PubNub) SubscribeWithContext(ctx Context) *subscribeBuilder {
+	return newSubscribeBuilderWithContext(pn, ctx)
+}
+
 func (pn *PubNub) History() *historyBuilder {
 	return newHistoryBuilder(pn)
 }
@@ -131,6 +176,12 @@ func (pn *PubNub) GetUserWithContext(ctx Context) *getUserBuilder {
 	return newGetUserBuilderWithContext(pn, ctx)
 }
 
+// UserExists reports whether the User with the id exists, a 404 response is reported as false.
+func (pn *PubNub) UserExists(id string) (bool, error) {
+	_, _, err := pn.GetUser().ID(id).Execute()
+	return objectExists(err)
+}
+
 func (pn *PubNub) UpdateUser() *updateUserBuilder {
 	return newUpdateUserBuilder(pn)
 }
@@ -171,6 +222,12 @@ func (pn *PubNub) GetSpaceWithContext(ctx Context) *getSpaceBuilder {
 	return newGetSpaceBuilderWithContext(pn, ctx)
 }
 
+// SpaceExists reports whether the Space with the id exists, a 404 response is reported as false.
+func (pn *PubNub) SpaceExists(id string) (bool, error) {
+	_, _, err := pn.GetSpace().ID(id).Execute()
+	return objectExists(err)
+}
+
 func (pn *PubNub) UpdateSpace() *updateSpaceBuilder {
 	return newUpdateSpaceBuilder(pn)
 }
@@ -251,26 +308,55 @@ func (pn *PubNub) GrantTokenWithContext(ctx Context) *grantTokenBuilder {
 	return newGrantTokenBuilderWithContext(pn, ctx)
 }
 
+// SecureObjectsAndSubscribe issues the v3 token of the Objects requests on the user and the space
+// and the v2 grant of the auth key to subscribe to their channels, the client sets the Token with SetToken and the AuthKey.
+func (pn *PubNub) SecureObjectsAndSubscribe(userID, spaceID, authKey string) (*PNSecureObjectsResponse, error) {
+	return secureObjectsAndSubscribe(pn, userID, spaceID, authKey)
+}
+
+// SetToken sets the single active token, it is sent as the auth param on authed operations when AuthKey is not set.
 func (pn *PubNub) SetToken(token string) {
+	pn.tokenManager.SetAuthToken(token)
 	pn.tokenManager.StoreToken(token)
 }
 
+// GetAuthToken returns the active token set using SetToken, or the newest token stored using SetTokens.
+// GetToken returns the token of a resource instead.
+func (pn *PubNub) GetAuthToken() string {
+	return pn.tokenManager.GetAuthToken()
+}
+
+// SetTokens stores the tokens by the resources they grant, without changing the active token.
 func (pn *PubNub) SetTokens(tokens []string) {
 	pn.tokenManager.StoreTokens(tokens)
 }
 
+// GetTokens returns the stored tokens by resource.
 func (pn *PubNub) GetTokens() GrantResourcesWithPermissions {
 	return pn.tokenManager.GetAllTokens()
 }
 
+// GetTokensByResource returns the stored tokens of the resources of resourceType.
 func (pn *PubNub) GetTokensByResource(resourceType PNResourceType) GrantResourcesWithPermissions {
 	return pn.tokenManager.GetTokensByResource(resourceType)
 }
 
+// GetToken returns the stored token of the resource, GetAuthToken returns the active token.
 func (pn *PubNub) GetToken(resourceId string, resourceType PNResourceType) string {
 	return pn.tokenManager.GetToken(resourceId, resourceType)
 }
 
+// ParseToken decodes a PAMv3 token and returns its resources, patterns and meta.
+func (pn *PubNub) ParseToken(token string) (PNGrantTokenDecoded, error) {
+	return GetPermissions(token)
+}
+
+// RenewToken grants a new token with the resources, patterns, meta and authorized UUID of oldToken,
+// valid for the TTL of oldToken from now. It requires the SecretKey.
+func (pn *PubNub) RenewToken(oldToken string) (string, error) {
+	return renewToken(pn, oldToken)
+}
+
 func (pn *PubNub) Unsubscribe() *unsubscribeBuilder {
 	return newUnsubscribeBuilder(pn)
 }
@@ -279,6 +365,12 @@ func (pn *PubNub) AddListener(listener *Listener) {
 	pn.subscriptionManager.AddListener(listener)
 }
 
+// AddMessageFilter adds a filter consulted before a subscribe message is delivered to the listeners,
+// the message is dropped when any of the filters returns false.
+func (pn *PubNub) AddMessageFilter(filter func(PNMessage) bool) {
+	pn.subscriptionManager.listenerManager.addMessageFilter(filter)
+}
+
 func (pn *PubNub) RemoveListener(listener *Listener) {
 	pn.subscriptionManager.RemoveListener(listener)
 }
@@ -370,6 +462,18 @@ func (pn *PubNub) GetSubscribedGroups() []string {
 	return pn.subscriptionManager.getSubscribedGroups()
 }
 
+// Disconnect stops the subscribe loop without unsubscribing, e.g. when the app goes to the background.
+// The subscribed channels, the listeners and the last timetoken are kept, call Reconnect to resume.
+func (pn *PubNub) Disconnect() {
+	pn.subscriptionManager.stop()
+}
+
+// Reconnect restarts the subscribe loop stopped by Disconnect or after Config.SubscribeMaxReconnectionRetries failures,
+// the messages published meanwhile are received from the last timetoken.
+func (pn *PubNub) Reconnect() {
+	pn.subscriptionManager.reconnect()
+}
+
 func (pn *PubNub) UnsubscribeAll() {
 	pn.subscriptionManager.unsubscribeAll()
 }
@@ -511,10 +615,24 @@ func (pn *PubNub) Destroy() {
 
 }
 
+// ResetSequence restarts the seqn of the next publish at 1.
+// The sequence is also reset when the PublishKey of the Config changes.
+func (pn *PubNub) ResetSequence() {
+	pn.publishSequenceMutex.Lock()
+	defer pn.publishSequenceMutex.Unlock()
+
+	pn.nextPublishSequence = 0
+}
+
 func (pn *PubNub) getPublishSequence() int {
 	pn.publishSequenceMutex.Lock()
 	defer pn.publishSequenceMutex.Unlock()
 
+	if pn.publishSequenceKey != pn.Config.PublishKey {
+		pn.publishSequenceKey = pn.Config.PublishKey
+		pn.nextPublishSequence = 0
+	}
+
 	if pn.nextPublishSequence == MaxSequence {
 		pn.nextPublishSequence = 1
 	} else {
@@ -530,13 +648,15 @@ func NewPubNub(pnconf *Config) *PubNub {
 	if pnconf.Log == nil {
 		pnconf.Log = log.New(ioutil.Discard, "", log.Ldate|log.Ltime|log.Lshortfile)
 	}
-	pnconf.Log.Println(fmt.Sprintf("PubNub Go v4 SDK: %s\npnconf: %v\n%s\n%s\n%s", Version, pnconf, runtime.Version(), runtime.GOARCH, runtime.GOOS))
+	pnconf.Log.Println(fmt.Sprintf("PubNub Go v4 SDK: %s\npnconf: %v\n%s\n%s\n%s", Version, pnconf.redacted(), runtime.Version(), runtime.GOARCH, runtime.GOOS))
 
 	pn := &PubNub{
 		Config:              pnconf,
 		nextPublishSequence: 0,
 		ctx:                 ctx,
 		cancel:              cancel,
+		instanceID:          utils.UUID(),
+		stateCache:          newStateCache(),
 	}
 
 	pn.subscriptionManager = newSubscriptionManager(pn, ctx)
@@ -30,3 +30,24 @@ func TestDemoInitializer(t *testing.T) {
 	assert.Equal("demo", demo.Config.SubscribeKey)
 	assert.Equal("demo", demo.Config.SecretKey)
 }
+
+func TestPublishSequenceResetOnKeyChange(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+
+	seqn := func() string {
+		query, err := pn.Publish().Channel("ch").Message("hey").opts.buildQuery()
+		assert.Nil(err)
+		return query.Get("seqn")
+	}
+
+	assert.Equal("1", seqn())
+	assert.Equal("2", seqn())
+
+	pn.Config.PublishKey = "pub-c-new"
+	assert.Equal("1", seqn())
+	assert.Equal("2", seqn())
+
+	pn.ResetSequence()
+	assert.Equal("1", seqn())
+}
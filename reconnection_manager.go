@@ -3,6 +3,7 @@ package pubnub
 import (
 	"fmt"
 	"math"
+	"math/rand"
 	"sync"
 	"time"
 )
@@ -83,7 +84,7 @@ func (m *ReconnectionManager) startPolling() {
 
 func (m *ReconnectionManager) startHeartbeatTimer() {
 
-	timerInterval := reconnectionInterval
+	timerInterval := reconnectionInterval * time.Second
 
 	for {
 
@@ -91,10 +92,12 @@ func (m *ReconnectionManager) startHeartbeatTimer() {
 		m.hbRunning = true
 		failedCalls := m.FailedCalls
 		m.Unlock()
-		_, status, err := m.pubnub.Time().Execute()
+		timeBuilder := m.pubnub.Time()
+		timeBuilder.opts.internal = true
+		_, status, err := timeBuilder.Execute()
 		if status.Error == nil {
 			if failedCalls > 0 {
-				timerInterval = reconnectionInterval
+				timerInterval = reconnectionInterval * time.Second
 				m.Lock()
 				m.FailedCalls = 0
 				m.Unlock()
@@ -102,8 +105,10 @@ func (m *ReconnectionManager) startHeartbeatTimer() {
 				m.OnReconnection()
 			}
 		} else {
-			if m.pubnub.Config.PNReconnectionPolicy == PNExponentialPolicy {
-				timerInterval = m.getExponentialInterval()
+			if m.pubnub.Config.reconnectionBackoffMax > 0 {
+				timerInterval = m.getBackoffInterval(failedCalls)
+			} else if m.pubnub.Config.PNReconnectionPolicy == PNExponentialPolicy {
+				timerInterval = time.Duration(m.getExponentialInterval()) * time.Second
 			}
 			m.Lock()
 			m.FailedCalls++
@@ -124,7 +129,7 @@ func (m *ReconnectionManager) startHeartbeatTimer() {
 		}
 
 		select {
-		case <-time.After(time.Duration(timerInterval) * time.Second):
+		case <-time.After(timerInterval):
 		case <-m.pubnub.ctx.Done():
 			m.pubnub.Config.Log.Printf(fmt.Sprintf("pubnub.ctx.Done\n"))
 			m.Lock()
@@ -158,6 +163,26 @@ func (m *ReconnectionManager) getExponentialInterval() int {
 	return timerInterval
 }
 
+// getBackoffInterval returns the delay set by Config.SetReconnectionBackoff after failedCalls previous failures.
+func (m *ReconnectionManager) getBackoffInterval(failedCalls int) time.Duration {
+	min := m.pubnub.Config.reconnectionBackoffMin
+	max := m.pubnub.Config.reconnectionBackoffMax
+
+	interval := min
+	for i := 0; i < failedCalls && interval < max; i++ {
+		interval *= 2
+	}
+	if interval > max {
+		interval = max
+	}
+
+	if jitter := m.pubnub.Config.reconnectionBackoffJitter; jitter > 0 {
+		interval += time.Duration(float64(interval) * jitter * (2*rand.Float64() - 1))
+	}
+
+	return interval
+}
+
 func (m *ReconnectionManager) stopHeartbeatTimer() {
 	m.pubnub.Config.Log.Printf("stopHeartbeatTimer")
 	m.Lock()
@@ -96,3 +96,50 @@ func TestReconnect(t *testing.T) {
 	assert.True(reconnected)
 	r.stopHeartbeatTimer()
 }
+
+func TestReconnectionBackoffBounds(t *testing.T) {
+	assert := assert.New(t)
+
+	config := NewConfig()
+	config.SetReconnectionBackoff(time.Second, 8*time.Second, 0.25)
+	pn := NewPubNub(config)
+	r := newReconnectionManager(pn)
+
+	expected := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 8 * time.Second, 8 * time.Second}
+	for failedCalls, base := range expected {
+		seen := map[time.Duration]bool{}
+		for i := 0; i < 20; i++ {
+			interval := r.getBackoffInterval(failedCalls)
+			assert.True(interval >= base*3/4, "%v below %v", interval, base*3/4)
+			assert.True(interval <= base*5/4, "%v above %v", interval, base*5/4)
+			seen[interval] = true
+		}
+		assert.True(len(seen) > 1, "no jitter variance at %d failed calls", failedCalls)
+	}
+}
+
+func TestReconnectionBackoffWithoutJitter(t *testing.T) {
+	assert := assert.New(t)
+
+	config := NewConfig()
+	config.SetReconnectionBackoff(500*time.Millisecond, 3*time.Second, 0)
+	pn := NewPubNub(config)
+	r := newReconnectionManager(pn)
+
+	assert.Equal(500*time.Millisecond, r.getBackoffInterval(0))
+	assert.Equal(time.Second, r.getBackoffInterval(1))
+	assert.Equal(2*time.Second, r.getBackoffInterval(2))
+	assert.Equal(3*time.Second, r.getBackoffInterval(3))
+	assert.Equal(3*time.Second, r.getBackoffInterval(100))
+}
+
+func TestSetReconnectionBackoffSanitizes(t *testing.T) {
+	assert := assert.New(t)
+
+	config := NewConfig()
+	config.SetReconnectionBackoff(0, -time.Second, 2)
+
+	assert.Equal(time.Second, config.reconnectionBackoffMin)
+	assert.Equal(time.Second, config.reconnectionBackoffMax)
+	assert.Equal(float64(1), config.reconnectionBackoffJitter)
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"time"
 
 	"github.com/pubnub/go/utils"
 )
@@ -52,6 +53,12 @@ func (b *removeAllPushChannelsForDeviceBuilder) DeviceIDForPush(
 	return b
 }
 
+// Transport sets the Transport for the RemoveAllPushNotifications request.
+func (b *removeAllPushChannelsForDeviceBuilder) Transport(tr http.RoundTripper) *removeAllPushChannelsForDeviceBuilder {
+	b.opts.Transport = tr
+	return b
+}
+
 // QueryParam accepts a map, the keys and values of the map are passed as the query string parameters of the URL called by the API.
 func (b *removeAllPushChannelsForDeviceBuilder) QueryParam(queryParam map[string]string) *removeAllPushChannelsForDeviceBuilder {
 	b.opts.QueryParam = queryParam
@@ -59,6 +66,18 @@ func (b *removeAllPushChannelsForDeviceBuilder) QueryParam(queryParam map[string
 	return b
 }
 
+// Timeout sets the timeout for the RemoveAllPushNotifications request.
+func (b *removeAllPushChannelsForDeviceBuilder) Timeout(timeout time.Duration) *removeAllPushChannelsForDeviceBuilder {
+	b.opts.Timeout = timeout
+
+	return b
+}
+
+// SignedURL returns the URL of the RemoveAllPushNotifications request without executing it.
+func (b *removeAllPushChannelsForDeviceBuilder) SignedURL() (string, error) {
+	return signedURL(b.opts)
+}
+
 // Execute runs the RemoveAllPushNotifications request.
 func (b *removeAllPushChannelsForDeviceBuilder) Execute() (
 	*RemoveAllPushChannelsForDeviceResponse, StatusResponse, error) {
@@ -75,6 +94,7 @@ type removeAllPushChannelsForDeviceOpts struct {
 
 	PushType        PNPushType
 	QueryParam      map[string]string
+	Timeout         time.Duration
 	DeviceIDForPush string
 
 	Transport http.RoundTripper
@@ -120,7 +140,7 @@ func (o *removeAllPushChannelsForDeviceOpts) buildPath() (string, error) {
 }
 
 func (o *removeAllPushChannelsForDeviceOpts) buildQuery() (*url.Values, error) {
-	q := defaultQuery(o.pubnub.Config.UUID, o.pubnub.telemetryManager)
+	q := defaultQuery(o.pubnub.Config, o.pubnub.telemetryManager)
 	q.Set("type", o.PushType.String())
 	SetQueryParam(q, o.QueryParam)
 	return q, nil
@@ -151,9 +171,21 @@ func (o *removeAllPushChannelsForDeviceOpts) connectTimeout() int {
 }
 
 func (o *removeAllPushChannelsForDeviceOpts) operationType() OperationType {
-	return PNRemoveGroupOperation
+	return PNRemoveAllPushNotificationsOperation
 }
 
 func (o *removeAllPushChannelsForDeviceOpts) telemetryManager() *TelemetryManager {
 	return o.pubnub.telemetryManager
 }
+
+func (o *removeAllPushChannelsForDeviceOpts) tokenManager() *TokenManager {
+	return o.pubnub.tokenManager
+}
+
+func (o *removeAllPushChannelsForDeviceOpts) timeout() time.Duration {
+	return o.Timeout
+}
+
+func (o *removeAllPushChannelsForDeviceOpts) transport() http.RoundTripper {
+	return o.Transport
+}
@@ -138,5 +138,5 @@ func TestRemoveAllPushChannelsForDeviceOptsValidateSub(t *testing.T) {
 		pubnub:          pn,
 	}
 
-	assert.Equal("pubnub/validation: pubnub: \x0e: Missing Subscribe Key", opts.validate().Error())
+	assert.Equal("pubnub/validation: pubnub: Remove All Push Notifications: Missing Subscribe Key", opts.validate().Error())
 }
@@ -1,11 +1,16 @@
 package pubnub
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
+	"github.com/pubnub/go/pnerr"
 	"github.com/pubnub/go/utils"
 )
 
@@ -54,6 +59,12 @@ func (b *removeChannelFromChannelGroupBuilder) ChannelGroup(
 	return b
 }
 
+// Transport sets the Transport for the RemoveChannelFromChannelGroup request.
+func (b *removeChannelFromChannelGroupBuilder) Transport(tr http.RoundTripper) *removeChannelFromChannelGroupBuilder {
+	b.opts.Transport = tr
+	return b
+}
+
 // QueryParam accepts a map, the keys and values of the map are passed as the query string parameters of the URL called by the API.
 func (b *removeChannelFromChannelGroupBuilder) QueryParam(queryParam map[string]string) *removeChannelFromChannelGroupBuilder {
 	b.opts.QueryParam = queryParam
@@ -61,6 +72,18 @@ func (b *removeChannelFromChannelGroupBuilder) QueryParam(queryParam map[string]
 	return b
 }
 
+// Timeout sets the timeout for the RemoveChannelFromChannelGroup request.
+func (b *removeChannelFromChannelGroupBuilder) Timeout(timeout time.Duration) *removeChannelFromChannelGroupBuilder {
+	b.opts.Timeout = timeout
+
+	return b
+}
+
+// SignedURL returns the URL of the RemoveChannelFromChannelGroup request without executing it.
+func (b *removeChannelFromChannelGroupBuilder) SignedURL() (string, error) {
+	return signedURL(b.opts)
+}
+
 // Execute runs RemoveChannelFromChannelGroup request
 func (b *removeChannelFromChannelGroupBuilder) Execute() (
 	*RemoveChannelFromChannelGroupResponse, StatusResponse, error) {
@@ -77,6 +100,7 @@ type removeChannelOpts struct {
 
 	Channels     []string
 	QueryParam   map[string]string
+	Timeout      time.Duration
 	ChannelGroup string
 
 	Transport http.RoundTripper
@@ -119,7 +143,7 @@ func (o *removeChannelOpts) buildPath() (string, error) {
 }
 
 func (o *removeChannelOpts) buildQuery() (*url.Values, error) {
-	q := defaultQuery(o.pubnub.Config.UUID, o.pubnub.telemetryManager)
+	q := defaultQuery(o.pubnub.Config, o.pubnub.telemetryManager)
 
 	var channels []string
 
@@ -128,6 +152,7 @@ func (o *removeChannelOpts) buildQuery() (*url.Values, error) {
 	}
 
 	q.Set("remove", strings.Join(channels, ","))
+	o.pubnub.tokenManager.SetAuthParan(q, o.ChannelGroup, PNGroups)
 	SetQueryParam(q, o.QueryParam)
 	return q, nil
 }
@@ -164,12 +189,39 @@ func (o *removeChannelOpts) telemetryManager() *TelemetryManager {
 	return o.pubnub.telemetryManager
 }
 
-// RemoveChannelFromChannelGroupResponse is the struct returned when the Execute function of RemoveChannelFromChannelGroup is called.
+func (o *removeChannelOpts) tokenManager() *TokenManager {
+	return o.pubnub.tokenManager
+}
+
+func (o *removeChannelOpts) timeout() time.Duration {
+	return o.Timeout
+}
+
+func (o *removeChannelOpts) transport() http.RoundTripper {
+	return o.Transport
+}
+
+// RemoveChannelFromChannelGroupResponse is the struct returned when the Execute function of RemoveChannelFromChannelGroup is called,
+// it holds the status payload of the server confirming the removal.
 type RemoveChannelFromChannelGroupResponse struct {
+	Status  int    `json:"status"`
+	Message string `json:"message"`
+	Service string `json:"service"`
+	Error   bool   `json:"error"`
 }
 
 func newRemoveChannelFromChannelGroupResponse(jsonBytes []byte,
 	status StatusResponse) (*RemoveChannelFromChannelGroupResponse,
 	StatusResponse, error) {
-	return emptyRemoveChannelFromChannelGroupResponse, status, nil
+	resp := &RemoveChannelFromChannelGroupResponse{}
+
+	err := json.Unmarshal(jsonBytes, resp)
+	if err != nil {
+		e := pnerr.NewResponseParsingError("Error unmarshalling response",
+			ioutil.NopCloser(bytes.NewBufferString(string(jsonBytes))), err)
+
+		return emptyRemoveChannelFromChannelGroupResponse, status, e
+	}
+
+	return resp, status, nil
 }
@@ -2,6 +2,7 @@ package pubnub
 
 import (
 	"fmt"
+	"net/http"
 	"net/url"
 	"testing"
 
@@ -144,5 +145,21 @@ func TestRemChannelsFromCGValidateSubscribeKey(t *testing.T) {
 		pubnub: pn,
 	}
 
-	assert.Equal("pubnub/validation: pubnub: \r: Missing Subscribe Key", opts.validate().Error())
+	assert.Equal("pubnub/validation: pubnub: Remove Channel From Channel Group: Missing Subscribe Key", opts.validate().Error())
+}
+
+func TestRemoveChannelFromChannelGroupResponse(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+	pn.SetClient(&http.Client{Transport: newStubTransport(200, `{"status":200,"message":"OK","service":"channel-registry","error":false}`)})
+
+	res, _, err := pn.RemoveChannelFromChannelGroup().Channels([]string{"ch1"}).ChannelGroup("cg").Execute()
+	assert.Nil(err)
+	assert.Equal(200, res.Status)
+	assert.Equal("OK", res.Message)
+	assert.Equal("channel-registry", res.Service)
+	assert.False(res.Error)
+
+	_, _, err = newRemoveChannelFromChannelGroupResponse([]byte(`s`), StatusResponse{})
+	assert.Contains(err.Error(), "Error unmarshalling response")
 }
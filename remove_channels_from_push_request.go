@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/pubnub/go/utils"
 )
@@ -60,6 +61,12 @@ func (b *removeChannelsFromPushBuilder) DeviceIDForPush(
 	return b
 }
 
+// Transport sets the Transport for the RemovePushNotificationsFromChannels request.
+func (b *removeChannelsFromPushBuilder) Transport(tr http.RoundTripper) *removeChannelsFromPushBuilder {
+	b.opts.Transport = tr
+	return b
+}
+
 // QueryParam accepts a map, the keys and values of the map are passed as the query string parameters of the URL called by the API.
 func (b *removeChannelsFromPushBuilder) QueryParam(queryParam map[string]string) *removeChannelsFromPushBuilder {
 	b.opts.QueryParam = queryParam
@@ -67,6 +74,18 @@ func (b *removeChannelsFromPushBuilder) QueryParam(queryParam map[string]string)
 	return b
 }
 
+// Timeout sets the timeout for the RemovePushNotificationsFromChannels request.
+func (b *removeChannelsFromPushBuilder) Timeout(timeout time.Duration) *removeChannelsFromPushBuilder {
+	b.opts.Timeout = timeout
+
+	return b
+}
+
+// SignedURL returns the URL of the RemovePushNotificationsFromChannels request without executing it.
+func (b *removeChannelsFromPushBuilder) SignedURL() (string, error) {
+	return signedURL(b.opts)
+}
+
 // Execute runs the RemovePushNotificationsFromChannels request.
 func (b *removeChannelsFromPushBuilder) Execute() (
 	*RemoveChannelsFromPushResponse, StatusResponse, error) {
@@ -83,6 +102,7 @@ type removeChannelsFromPushOpts struct {
 
 	Channels        []string
 	QueryParam      map[string]string
+	Timeout         time.Duration
 	PushType        PNPushType
 	DeviceIDForPush string
 
@@ -133,7 +153,7 @@ func (o *removeChannelsFromPushOpts) buildPath() (string, error) {
 }
 
 func (o *removeChannelsFromPushOpts) buildQuery() (*url.Values, error) {
-	q := defaultQuery(o.pubnub.Config.UUID, o.pubnub.telemetryManager)
+	q := defaultQuery(o.pubnub.Config, o.pubnub.telemetryManager)
 	q.Set("type", o.PushType.String())
 	var channels []string
 
@@ -171,9 +191,21 @@ func (o *removeChannelsFromPushOpts) connectTimeout() int {
 }
 
 func (o *removeChannelsFromPushOpts) operationType() OperationType {
-	return PNRemoveGroupOperation
+	return PNRemovePushNotificationsFromChannelsOperation
 }
 
 func (o *removeChannelsFromPushOpts) telemetryManager() *TelemetryManager {
 	return o.pubnub.telemetryManager
 }
+
+func (o *removeChannelsFromPushOpts) tokenManager() *TokenManager {
+	return o.pubnub.tokenManager
+}
+
+func (o *removeChannelsFromPushOpts) timeout() time.Duration {
+	return o.Timeout
+}
+
+func (o *removeChannelsFromPushOpts) transport() http.RoundTripper {
+	return o.Transport
+}
@@ -157,5 +157,5 @@ func TestRemChannelsFromPushValidateSubscribeKey(t *testing.T) {
 		pubnub:          pn,
 	}
 
-	assert.Equal("pubnub/validation: pubnub: \x0e: Missing Subscribe Key", opts.validate().Error())
+	assert.Equal("pubnub/validation: pubnub: Remove Push From Channel: Missing Subscribe Key", opts.validate().Error())
 }
@@ -6,8 +6,10 @@ import (
 	"github.com/pubnub/go/pnerr"
 	"io"
 	"io/ioutil"
+	"net"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
 )
 
@@ -25,6 +27,7 @@ type StatusResponse struct {
 	Request               string
 	AffectedChannels      []string
 	AffectedChannelGroups []string
+	ETag                  string
 }
 
 // ResponseInfo is used to store the properties in the response of an request.
@@ -35,6 +38,7 @@ type ResponseInfo struct {
 	Origin           string
 	UUID             string
 	AuthKey          string
+	ETag             string
 	OriginalResponse *http.Response
 }
 
@@ -47,28 +51,83 @@ func addToJobQ(req *http.Request, client *http.Client, opts endpointOpts, j chan
 	opts.jobQueue() <- jqi
 }
 
-func buildBody(opts endpointOpts, url *url.URL) (io.Reader, error) {
+// buildBody returns the body of the request to sign and send.
+// It is built once for each request, an encrypted message with a random IV differs at each build.
+func buildBody(opts endpointOpts) ([]byte, error) {
 	b, err := opts.buildBody()
+	if m := opts.httpMethod(); m != "POST" && m != "PATCH" {
+		// nothing is sent, the signature covers the body of the builder as in buildURL
+		if err != nil {
+			return nil, nil
+		}
+		return b, nil
+	}
 	if err != nil {
-		opts.config().Log.Println("PNUnknownCategory", err, url)
+		opts.config().Log.Println("PNUnknownCategory", err)
 		return nil, err
 	}
-	opts.config().Log.Println("BODY", string(b))
+	if opts.config().LogVerbosity == PNLogRequestsAndBodies {
+		opts.config().Log.Println("BODY", string(b))
+	}
 
-	return bytes.NewReader(b), nil
+	return b, nil
 }
 
-func executeRequest(opts endpointOpts) ([]byte, StatusResponse, error) {
-	err := opts.validate()
+// validateRequest validates the request before it is built, it returns the connect timeout when the
+// request overrides Config.ConnectTimeout, 0 otherwise.
+func validateRequest(opts endpointOpts) (time.Duration, error) {
+	if err := opts.validate(); err != nil {
+		return 0, err
+	}
+
+	if !opts.config().DisableDefaultQueryParams && strings.TrimSpace(opts.config().UUID) == "" {
+		return 0, newValidationError(opts, StrMissingUUID)
+	}
 
+	if opts.timeout() < 0 {
+		return 0, newValidationError(opts, fmt.Sprintf("%s %s: must be positive", StrInvalidTimeout, opts.timeout()))
+	}
+
+	timeouts, ok := opts.(endpointTimeouts)
+	if !ok || timeouts.connectTimeout() == opts.config().ConnectTimeout {
+		return 0, nil
+	}
+
+	connectTimeout := time.Duration(timeouts.connectTimeout()) * time.Second
+	requestTimeout := opts.timeout()
+	if requestTimeout == 0 {
+		requestTimeout = time.Duration(timeouts.requestTimeout()) * time.Second
+	}
+	if connectTimeout <= 0 || connectTimeout >= requestTimeout {
+		return 0, newValidationError(opts, fmt.Sprintf("%s %s: connect timeout must be positive and less than the request timeout %s", StrInvalidTimeout, connectTimeout, requestTimeout))
+	}
+	if t, ok := opts.(endpointTransport); ok && t.transport() != nil {
+		// the connect timeout is set on the dialer of the transport, a custom transport dials by itself
+		return 0, newValidationError(opts, fmt.Sprintf("%s %s: connect timeout can't be used with a custom transport", StrInvalidTimeout, connectTimeout))
+	}
+	if _, ok := clientTransport(opts.client()).(*http.Transport); !ok {
+		return 0, newValidationError(opts, fmt.Sprintf("%s %s: connect timeout can't be used with the client transport %T", StrInvalidTimeout, connectTimeout, opts.client().Transport))
+	}
+
+	return connectTimeout, nil
+}
+
+func executeRequest(opts endpointOpts) ([]byte, StatusResponse, error) {
+	connectTimeout, err := validateRequest(opts)
 	if err != nil {
 		opts.config().Log.Println("PNUnknownCategory", err)
 		return nil,
 			createStatus(PNUnknownCategory, "", ResponseInfo{}, err),
 			err
 	}
+	overrideConnectTimeout := connectTimeout > 0
 
-	url, err := buildURL(opts)
+	body, err := buildBody(opts)
+	if err != nil {
+		return nil, createStatus(PNUnknownCategory, "", ResponseInfo{}, err), err
+	}
+
+	url, err := buildURLWithBody(opts, body)
 
 	if err != nil {
 		opts.config().Log.Println("PNUnknownCategory", err)
@@ -77,39 +136,46 @@ func executeRequest(opts endpointOpts) ([]byte, StatusResponse, error) {
 			err
 	}
 
-	opts.config().Log.Println(fmt.Sprintf("url:%s\nmethod:%s", url, opts.httpMethod()))
+	opts.config().Log.Println(fmt.Sprintf("url:%s\nmethod:%s", redactURL(url), opts.httpMethod()))
 
 	var req *http.Request
 
 	if opts.httpMethod() == "POST" {
-		body, err := buildBody(opts, url)
-		if err != nil {
-			return nil, createStatus(PNUnknownCategory, "", ResponseInfo{}, err), err
+		req, err = newRequest("POST", url, bytes.NewReader(body), opts.config().UseHTTP2)
+		if err == nil {
+			req.Header.Set("Content-Type", "application/json")
 		}
-
-		req, err = newRequest("POST", url, body, opts.config().UseHTTP2)
-		req.Header.Set("Content-Type", "application/json")
 	} else if opts.httpMethod() == "DELETE" {
 		req, err = newRequest("DELETE", url, nil, opts.config().UseHTTP2)
 	} else if opts.httpMethod() == "PATCH" {
-		body, err := buildBody(opts, url)
-		if err != nil {
-			return nil, createStatus(PNUnknownCategory, "", ResponseInfo{}, err), err
-		}
-
-		req, err = newRequest("PATCH", url, body, opts.config().UseHTTP2)
+		req, err = newRequest("PATCH", url, bytes.NewReader(body), opts.config().UseHTTP2)
 	} else {
 		req, err = newRequest("GET", url, nil, opts.config().UseHTTP2)
 	}
 
 	if err != nil {
-		opts.config().Log.Println("PNUnknownCategory", err, url)
+		opts.config().Log.Println("PNUnknownCategory", err, redactURL(url))
 		return nil,
 			createStatus(PNUnknownCategory, "", ResponseInfo{}, err),
 			err
 	}
 
+	if h, ok := opts.(endpointHeaders); ok {
+		for key, value := range h.requestHeaders() {
+			req.Header.Set(key, value)
+		}
+	}
+
 	ctx := opts.context()
+	// the shorter of the Timeout and the context deadline applies
+	if timeout := opts.timeout(); timeout > 0 {
+		if ctx == nil {
+			ctx = backgroundContext
+		}
+		timeoutCtx, cancel := contextWithTimeout(ctx, timeout)
+		defer cancel()
+		ctx = timeoutCtx
+	}
 	if ctx != nil {
 		// with !go1.7 you can't assign context directly to a request,
 		// the request.cancel is mapped to the ctx.Done() channel instead
@@ -118,6 +184,24 @@ func executeRequest(opts endpointOpts) ([]byte, StatusResponse, error) {
 	}
 
 	client := opts.client()
+	t, hasTransport := opts.(endpointTransport)
+	hasTransport = hasTransport && t.transport() != nil
+	if hasTransport || opts.timeout() > 0 || overrideConnectTimeout {
+		requestClient := *client
+		if hasTransport {
+			requestClient.Transport = t.transport()
+		} else if overrideConnectTimeout {
+			// the copy is dropped after the request, its idle connections are closed once the response is read
+			transport := withConnectTimeout(clientTransport(client).(*http.Transport), connectTimeout)
+			defer transport.CloseIdleConnections()
+			requestClient.Transport = transport
+		}
+		if timeout := opts.timeout(); timeout > 0 {
+			// overrides the Config timeouts, which may be shorter
+			requestClient.Timeout = timeout
+		}
+		client = &requestClient
+	}
 	startTimestamp := time.Now()
 
 	var res *http.Response
@@ -139,18 +223,40 @@ func executeRequest(opts endpointOpts) ([]byte, StatusResponse, error) {
 		res, err = client.Do(req)
 	}
 
+	for _, origin := range opts.config().Origins {
+		if err == nil || !isDialError(err) || (ctx != nil && ctx.Err() != nil) {
+			break
+		}
+		opts.config().Log.Printf("pubnub: failover from %s to %s: %s\n", url.Host, origin, err.Error())
+
+		url = failoverURL(url, origin)
+		var failoverReq *http.Request
+		failoverReq, err = newFailoverRequest(opts, req, url, body, ctx)
+		if err != nil {
+			break
+		}
+		req = failoverReq
+		res, err = client.Do(req)
+	}
+
+	latency := time.Since(startTimestamp)
+
 	// Host lookup failed
 	if err != nil {
+		traceRequest(opts, req, 0, latency, nil)
+		reportRequestStats(opts, 0, latency, err)
 		opts.config().Log.Println("err.Error()", err.Error())
 		e := pnerr.NewConnectionError("Failed to execute request", err)
 
-		opts.config().Log.Println("PNUnknownCategory", e.Error(), url)
+		opts.config().Log.Println("PNUnknownCategory", e.Error(), redactURL(url))
 		return nil,
 			createStatus(PNUnknownCategory, "", ResponseInfo{}, e),
 			e
 	}
 
 	val, status, err := parseResponse(res, opts)
+	traceRequest(opts, req, res.StatusCode, latency, val)
+	reportRequestStats(opts, res.StatusCode, latency, err)
 	// Already wrapped error
 	if err != nil {
 		opts.config().Log.Println("res.StatusCode, status, err.Error()", res.StatusCode, status, err.Error())
@@ -167,6 +273,7 @@ func executeRequest(opts endpointOpts) ([]byte, StatusResponse, error) {
 		OriginalResponse: res,
 		Operation:        opts.operationType(),
 		Origin:           url.Host,
+		ETag:             res.Header.Get("ETag"),
 	}
 
 	if url.Scheme == "https" {
@@ -186,6 +293,60 @@ func executeRequest(opts endpointOpts) ([]byte, StatusResponse, error) {
 	return val, status, nil
 }
 
+// clientTransport returns the Transport of the client, http.DefaultTransport when it is not set.
+func clientTransport(client *http.Client) http.RoundTripper {
+	if client.Transport == nil {
+		return http.DefaultTransport
+	}
+	return client.Transport
+}
+
+// isDialError returns true when err comes from a failed dial or host lookup,
+// only then the request didn't reach the origin and can be sent to a failover.
+func isDialError(err error) bool {
+	if urlErr, ok := err.(*url.Error); ok {
+		err = urlErr.Err
+	}
+	switch e := err.(type) {
+	case *net.OpError:
+		return e.Op == "dial"
+	case *net.DNSError:
+		return true
+	}
+
+	return false
+}
+
+// failoverURL returns a copy of u sent to origin, the signature doesn't cover the origin.
+func failoverURL(u *url.URL, origin string) *url.URL {
+	failover := *u
+	failover.Opaque = "//" + origin + strings.TrimPrefix(u.Opaque, "//"+u.Host)
+	failover.Host = origin
+
+	return &failover
+}
+
+// newFailoverRequest returns a copy of req sent to u, with a new reader of the body signed in the URL as req consumed its own.
+func newFailoverRequest(opts endpointOpts, req *http.Request, u *url.URL, body []byte, ctx Context) (*http.Request, error) {
+	var reader io.Reader
+	if req.Body != nil {
+		reader = bytes.NewReader(body)
+	}
+
+	failover, err := newRequest(req.Method, u, reader, opts.config().UseHTTP2)
+	if err != nil {
+		return nil, err
+	}
+	for key, values := range req.Header {
+		failover.Header[key] = values
+	}
+	if ctx != nil {
+		failover = setRequestContext(failover, ctx)
+	}
+
+	return failover, nil
+}
+
 func newRequest(method string, u *url.URL, body io.Reader, useHTTP2 bool) (*http.Request,
 	error) {
 
@@ -224,26 +385,34 @@ func newRequest(method string, u *url.URL, body io.Reader, useHTTP2 bool) (*http
 func parseResponse(resp *http.Response, opts endpointOpts) ([]byte, StatusResponse, error) {
 	status := StatusResponse{}
 
+	if resp.StatusCode == http.StatusNotModified {
+		// Conditional requests, the caller keeps its cached copy
+		opts.config().Log.Println("304 Not Modified")
+
+		return []byte{}, status, nil
+	}
+
 	if resp.StatusCode != 200 {
 		// Errors like 400, 403, 500
 		e := pnerr.NewServerError(resp.StatusCode, resp.Body)
+		e.Header = resp.Header
 
 		opts.config().Log.Println(e.Error())
 
 		if resp.StatusCode == 408 {
-			opts.config().Log.Println("PNTimeoutCategory: resp.StatusCode, resp.Body, resp.Request.URL", resp.StatusCode, resp.Body, resp.Request.URL)
+			opts.config().Log.Println("PNTimeoutCategory: resp.StatusCode", resp.StatusCode)
 			status = createStatus(PNTimeoutCategory, "", ResponseInfo{StatusCode: resp.StatusCode}, e)
 
 			return nil, status, e
 		}
 
 		if resp.StatusCode == 400 {
-			opts.config().Log.Println("PNBadRequestCategory: resp.StatusCode, resp.Body, resp.Request.URL", resp.StatusCode, resp.Body, resp.Request.URL)
+			opts.config().Log.Println("PNBadRequestCategory: resp.StatusCode", resp.StatusCode)
 			status = createStatus(PNBadRequestCategory, "", ResponseInfo{StatusCode: resp.StatusCode}, e)
 
 			return nil, status, e
 		}
-		opts.config().Log.Println("PNUnknownCategory: resp.StatusCode, resp.Body, resp.Request.URL", resp.StatusCode, resp.Body, resp.Request.URL)
+		opts.config().Log.Println("PNUnknownCategory: resp.StatusCode", resp.StatusCode)
 		status = createStatus(PNUnknownCategory, "", ResponseInfo{StatusCode: resp.StatusCode, Operation: opts.operationType()}, e)
 
 		return nil, status, e
@@ -252,12 +421,13 @@ func parseResponse(resp *http.Response, opts endpointOpts) ([]byte, StatusRespon
 	body, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
 		e := pnerr.NewResponseParsingError("Error reading response body", resp.Body, err)
-		opts.config().Log.Println("Read All error: resp.Body, resp.Request.URL, e", resp.StatusCode, resp.Body, resp.Request.URL, e)
+		opts.config().Log.Println("Read All error: resp.StatusCode, e", resp.StatusCode, e)
 
 		return nil, status, e
 	}
 
-	opts.config().Log.Println("200 OK: resp.StatusCode, resp.Status, resp.Body, resp.Request.URL, string(body)", resp.StatusCode, resp.Status, resp.Body, resp.Request.URL, string(body))
+	// the URL and body are logged by traceRequest, redacted and depending on the LogVerbosity
+	opts.config().Log.Println("200 OK: resp.StatusCode, resp.Status", resp.StatusCode, resp.Status)
 	return body, status, nil
 }
 
@@ -278,6 +448,7 @@ func createStatus(category StatusCategory, response string,
 	resp.Origin = responseInfo.Origin
 	resp.UUID = responseInfo.UUID
 	resp.AuthKey = responseInfo.AuthKey
+	resp.ETag = responseInfo.ETag
 	resp.Operation = responseInfo.Operation
 	resp.Category = category
 	resp.AffectedChannels = []string{}
@@ -285,3 +456,64 @@ func createStatus(category StatusCategory, response string,
 
 	return resp
 }
+
+// redactedQueryKeys are the query params masked in the request trace.
+var redactedQueryKeys = map[string]bool{
+	"auth":      true,
+	"signature": true,
+}
+
+// traceRequest logs the method, redacted URL, status code and latency of a request,
+// the response body is logged only with PNLogRequestsAndBodies verbosity.
+func traceRequest(opts endpointOpts, req *http.Request, statusCode int, latency time.Duration, body []byte) {
+	config := opts.config()
+	if config.Log == nil {
+		return
+	}
+
+	config.Log.Printf("pubnub: %s %s status=%d latency=%s\n", req.Method, redactURL(req.URL), statusCode, latency)
+
+	if config.LogVerbosity == PNLogRequestsAndBodies && body != nil {
+		config.Log.Printf("pubnub: response body=%s\n", string(body))
+	}
+}
+
+// RequestStats is passed to Config.StatsListener after each request.
+type RequestStats struct {
+	Operation  OperationType
+	LatencyMs  int64
+	StatusCode int
+	// Err is the error of the request, nil when it succeeded.
+	Err error
+}
+
+// reportRequestStats calls the Config.StatsListener in a goroutine so that it doesn't block the request.
+func reportRequestStats(opts endpointOpts, statusCode int, latency time.Duration, err error) {
+	listener := opts.config().StatsListener
+	if listener == nil {
+		return
+	}
+
+	go listener(RequestStats{
+		Operation:  opts.operationType(),
+		LatencyMs:  int64(latency / time.Millisecond),
+		StatusCode: statusCode,
+		Err:        err,
+	})
+}
+
+// redactURL returns the URL as a string with the auth and signature values masked.
+func redactURL(u *url.URL) string {
+	params := strings.Split(u.RawQuery, "&")
+	for i, param := range params {
+		kv := strings.SplitN(param, "=", 2)
+		if redactedQueryKeys[kv[0]] {
+			params[i] = kv[0] + "=REDACTED"
+		}
+	}
+
+	redacted := *u
+	redacted.RawQuery = strings.Join(params, "&")
+
+	return redacted.String()
+}
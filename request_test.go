@@ -0,0 +1,246 @@
+package pubnub
+
+import (
+	"bytes"
+	"errors"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTracedPubNub(verbosity PNLogVerbosity) (*PubNub, *bytes.Buffer) {
+	var buf bytes.Buffer
+
+	config := NewDemoConfig()
+	config.AuthKey = "myAuthKey"
+	config.Log = log.New(&buf, "", 0)
+	config.LogVerbosity = verbosity
+
+	pn := NewPubNub(config)
+	pn.SetClient(&http.Client{Transport: newStubTransport(200, `[1,"Sent","14981595400555832"]`)})
+
+	return pn, &buf
+}
+
+func TestTraceRequestRedacted(t *testing.T) {
+	assert := assert.New(t)
+	pn, buf := newTracedPubNub(PNLogRequests)
+
+	_, _, err := pn.Publish().Channel("ch").Message("hey").Execute()
+	assert.Nil(err)
+
+	out := buf.String()
+	assert.Contains(out, "pubnub: GET https://ps.pndsn.com/publish/demo/demo/0/ch/0/%22hey%22?")
+	assert.Contains(out, "auth=REDACTED")
+	assert.Contains(out, "signature=REDACTED")
+	assert.Contains(out, "status=200 latency=")
+	assert.NotContains(out, "pubnub: response body=")
+	assert.NotContains(out, "myAuthKey")
+	assert.NotContains(out, "14981595400555832")
+}
+
+func TestTraceRequestBodies(t *testing.T) {
+	assert := assert.New(t)
+	pn, buf := newTracedPubNub(PNLogRequestsAndBodies)
+
+	_, _, err := pn.Publish().Channel("ch").Message("hey").Execute()
+	assert.Nil(err)
+
+	assert.Contains(buf.String(), `pubnub: response body=[1,"Sent","14981595400555832"]`)
+}
+
+func TestStatsListener(t *testing.T) {
+	assert := assert.New(t)
+	stats := make(chan RequestStats, 1)
+
+	config := NewDemoConfig()
+	config.StatsListener = func(s RequestStats) {
+		stats <- s
+	}
+	pn := NewPubNub(config)
+	pn.SetClient(&http.Client{Transport: newTestTransport(func(req *http.Request) (*http.Response, error) {
+		time.Sleep(20 * time.Millisecond)
+		return stubResponse(req, 200, `[1,"Sent","14981595400555832"]`), nil
+	})})
+
+	_, _, err := pn.Publish().Channel("ch").Message("hey").Execute()
+	assert.Nil(err)
+
+	select {
+	case s := <-stats:
+		assert.Equal(PNPublishOperation, s.Operation)
+		assert.Equal(200, s.StatusCode)
+		assert.Nil(s.Err)
+		assert.True(s.LatencyMs >= 20 && s.LatencyMs < 5000, "latency %d", s.LatencyMs)
+	case <-time.After(time.Second):
+		assert.Fail("StatsListener not called")
+	}
+}
+
+func TestRedactURL(t *testing.T) {
+	assert := assert.New(t)
+
+	u := &url.URL{
+		Scheme:   "https",
+		Host:     "ps.pndsn.com",
+		Opaque:   "//ps.pndsn.com/time/0",
+		RawQuery: "uuid=a&auth=secret&signature=v2.sig&pnsdk=x",
+	}
+
+	assert.Equal("https://ps.pndsn.com/time/0?uuid=a&auth=REDACTED&signature=REDACTED&pnsdk=x", redactURL(u))
+}
+
+func TestExecuteRequestTransportOverride(t *testing.T) {
+	assert := assert.New(t)
+	shared := newStubTransport(200, `[[1],14991775432719844,14991868111600528]`)
+	pn := NewPubNub(NewDemoConfig())
+	pn.SetClient(&http.Client{Transport: shared})
+
+	override := newStubTransport(200, `[[1],14991775432719844,14991868111600528]`)
+	_, _, err := pn.History().Channel("ch").Transport(override).Execute()
+	assert.Nil(err)
+	assert.Equal(1, override.count())
+	assert.Equal(0, shared.count())
+
+	_, _, err = pn.History().Channel("ch").Execute()
+	assert.Nil(err)
+	assert.Equal(1, override.count())
+	assert.Equal(1, shared.count())
+	assert.Equal(shared, pn.GetClient().Transport)
+
+	override = newStubTransport(200, `[1,"Sent","14981595400555832"]`)
+	_, _, err = pn.Publish().Channel("ch").Message("hey").Transport(override).Execute()
+	assert.Nil(err)
+	assert.Equal(1, override.count())
+	assert.Equal(1, shared.count())
+}
+
+func TestExecuteRequestConnectTimeoutValidation(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+	tr := newStubTransport(200, `[[],0,0]`)
+	pn.SetClient(&http.Client{Transport: tr})
+
+	_, _, err := pn.History().Channel("ch").ConnectTimeout(-1).Execute()
+	assert.Equal("pubnub/validation: pubnub: History: Invalid Timeout -1s: connect timeout must be positive and less than the request timeout 10s", err.Error())
+
+	_, _, err = pn.History().Channel("ch").ConnectTimeout(5).RequestTimeout(5).Execute()
+	assert.Contains(err.Error(), StrInvalidTimeout)
+	assert.Equal(0, tr.count())
+
+	_, _, err = pn.History().Channel("ch").ConnectTimeout(2).RequestTimeout(5).Transport(tr).Execute()
+	assert.Equal("pubnub/validation: pubnub: History: Invalid Timeout 2s: connect timeout can't be used with a custom transport", err.Error())
+	assert.Equal(0, tr.count())
+
+	_, _, err = pn.History().Channel("ch").ConnectTimeout(2).RequestTimeout(5).Execute()
+	assert.Equal("pubnub/validation: pubnub: History: Invalid Timeout 2s: connect timeout can't be used with the client transport *pubnub.testTransport", err.Error())
+	assert.Equal(0, tr.count())
+}
+
+func TestExecuteRequestConnectTimeoutKeepsTransportSettings(t *testing.T) {
+	assert := assert.New(t)
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[[],0,0]`))
+	}))
+	defer server.Close()
+
+	config := NewDemoConfig()
+	config.Origin = strings.TrimPrefix(server.URL, "https://")
+	pn := NewPubNub(config)
+	// the client trusts the certificate of the server through its TLSClientConfig
+	pn.SetClient(server.Client())
+
+	_, _, err := pn.History().Channel("ch").ConnectTimeout(2).RequestTimeout(5).Execute()
+	assert.Nil(err)
+}
+
+func TestExecuteRequestConnectTimeout(t *testing.T) {
+	assert := assert.New(t)
+	const unroutable = "10.255.255.1"
+	if conn, err := net.DialTimeout("tcp", unroutable+":80", 200*time.Millisecond); err == nil {
+		conn.Close()
+		t.Skip("the network routes " + unroutable)
+	}
+
+	config := NewDemoConfig()
+	config.Origin = unroutable
+	config.Secure = false
+	pn := NewPubNub(config)
+
+	start := time.Now()
+	_, _, err := pn.History().Channel("ch").ConnectTimeout(1).RequestTimeout(5).Execute()
+	assert.NotNil(err)
+	assert.True(time.Since(start) < 4*time.Second)
+}
+
+func TestExecuteRequestOriginFailover(t *testing.T) {
+	assert := assert.New(t)
+
+	live := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[15000000000000000]`))
+	}))
+	defer live.Close()
+
+	dead, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(err)
+	deadOrigin := dead.Addr().String()
+	dead.Close()
+
+	var buf bytes.Buffer
+	config := NewDemoConfig()
+	config.Log = log.New(&buf, "", 0)
+	config.Secure = false
+	config.Origin = deadOrigin
+	config.Origins = []string{strings.TrimPrefix(live.URL, "http://")}
+	pn := NewPubNub(config)
+
+	res, status, err := pn.Time().Execute()
+	assert.Nil(err)
+	assert.Equal(int64(15000000000000000), res.Timetoken)
+	assert.Equal(strings.TrimPrefix(live.URL, "http://"), status.Origin)
+	assert.Contains(buf.String(), "pubnub: failover from "+deadOrigin+" to "+strings.TrimPrefix(live.URL, "http://"))
+
+	config.Origins = nil
+	_, _, err = pn.Time().Execute()
+	assert.NotNil(err)
+}
+
+func TestExecuteRequestOriginFailoverOnlyOnDialErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	failovers := 0
+	live := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		failovers++
+		w.Write([]byte(`[15000000000000000]`))
+	}))
+	defer live.Close()
+
+	// the origin accepts the connection and closes it without a response
+	broken := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, _, _ := w.(http.Hijacker).Hijack()
+		conn.Close()
+	}))
+	defer broken.Close()
+
+	config := NewDemoConfig()
+	config.Secure = false
+	config.Origin = strings.TrimPrefix(broken.URL, "http://")
+	config.Origins = []string{strings.TrimPrefix(live.URL, "http://")}
+	pn := NewPubNub(config)
+
+	_, _, err := pn.Time().Execute()
+	assert.NotNil(err)
+	assert.Equal(0, failovers)
+
+	assert.True(isDialError(&net.OpError{Op: "dial", Err: errors.New("refused")}))
+	assert.True(isDialError(&net.DNSError{Err: "no such host", Name: "ps.pndsn.com"}))
+	assert.False(isDialError(&net.OpError{Op: "read", Err: errors.New("reset")}))
+	assert.False(isDialError(errors.New("EOF")))
+}
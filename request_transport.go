@@ -0,0 +1,20 @@
+// +build go1.13
+
+package pubnub
+
+import (
+	"net"
+	"net/http"
+	"time"
+)
+
+// withConnectTimeout returns a copy of base which dials with the connect timeout, the TLS, proxy and
+// HTTP/2 settings of base are kept.
+func withConnectTimeout(base *http.Transport, connectTimeout time.Duration) *http.Transport {
+	transport := base.Clone()
+	transport.DialContext = (&net.Dialer{
+		Timeout: connectTimeout,
+	}).DialContext
+
+	return transport
+}
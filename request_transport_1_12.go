@@ -0,0 +1,29 @@
+// +build !go1.13
+
+package pubnub
+
+import (
+	"net"
+	"net/http"
+	"time"
+)
+
+// withConnectTimeout returns a copy of base which dials with the connect timeout, the TLS and proxy
+// settings of base are kept. http.Transport can't be cloned before go1.13, the settings are copied.
+func withConnectTimeout(base *http.Transport, connectTimeout time.Duration) *http.Transport {
+	return &http.Transport{
+		Dial: (&net.Dialer{
+			Timeout: connectTimeout,
+		}).Dial,
+		Proxy:                 base.Proxy,
+		DialTLS:               base.DialTLS,
+		TLSClientConfig:       base.TLSClientConfig,
+		TLSHandshakeTimeout:   base.TLSHandshakeTimeout,
+		TLSNextProto:          base.TLSNextProto,
+		DisableKeepAlives:     base.DisableKeepAlives,
+		DisableCompression:    base.DisableCompression,
+		MaxIdleConnsPerHost:   base.MaxIdleConnsPerHost,
+		ResponseHeaderTimeout: base.ResponseHeaderTimeout,
+		ExpectContinueTimeout: base.ExpectContinueTimeout,
+	}
+}
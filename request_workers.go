@@ -88,7 +88,7 @@ func (p *RequestWorkers) Start(pubnub *PubNub, ctx Context) {
 // ReadQueue reads the queue and passes on the job to the workers
 func (p *RequestWorkers) ReadQueue(pubnub *PubNub) {
 	for job := range pubnub.jobQueue {
-		pubnub.Config.Log.Println("ReadQueue: Got job for channel ", job.Req)
+		pubnub.Config.Log.Println("ReadQueue: Got job for channel ", job.Req.Method, redactURL(job.Req.URL))
 		go func(job *JobQItem) {
 			jobChannel := <-p.Workers
 			jobChannel <- job
@@ -10,10 +10,14 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"time"
 )
 
 const setStatePath = "/v2/presence/sub-key/%s/channel/%s/uuid/%s/data"
 
+// setStateMaxSize is the max size of the serialized state accepted by the server.
+const setStateMaxSize = 32 * 1024
+
 var emptySetStateResponse *SetStateResponse
 
 type setStateBuilder struct {
@@ -66,6 +70,13 @@ func (b *setStateBuilder) QueryParam(queryParam map[string]string) *setStateBuil
 	return b
 }
 
+// Timeout sets the timeout for the Set State request.
+func (b *setStateBuilder) Timeout(timeout time.Duration) *setStateBuilder {
+	b.opts.Timeout = timeout
+
+	return b
+}
+
 // UUID sets the UUID for the Set State request.
 func (b *setStateBuilder) UUID(uuid string) *setStateBuilder {
 	b.opts.UUID = uuid
@@ -73,6 +84,11 @@ func (b *setStateBuilder) UUID(uuid string) *setStateBuilder {
 	return b
 }
 
+// SignedURL returns the URL of the Set State request without executing it.
+func (b *setStateBuilder) SignedURL() (string, error) {
+	return signedURL(b.opts)
+}
+
 // Execute runs the the Set State request and returns the SetStateResponse
 func (b *setStateBuilder) Execute() (*SetStateResponse, StatusResponse, error) {
 	stateOperation := StateOperation{}
@@ -82,7 +98,20 @@ func (b *setStateBuilder) Execute() (*SetStateResponse, StatusResponse, error) {
 
 	b.opts.pubnub.subscriptionManager.adaptState(stateOperation)
 
+	uuid := b.opts.UUID
+	if uuid == "" {
+		uuid = b.opts.pubnub.Config.UUID
+	}
+	channels := b.opts.pubnub.Config.channelNames(b.opts.Channels)
+	// the states of the channels of the groups are unknown, all the states of the uuid are dropped
+	if len(b.opts.ChannelGroups) > 0 {
+		channels = nil
+	}
+	b.opts.pubnub.stateCache.invalidate(channels, uuid)
+
 	rawJSON, status, err := executeRequest(b.opts)
+	// a GetState which ran while the request was in flight may have cached the previous states
+	b.opts.pubnub.stateCache.invalidate(channels, uuid)
 	if err != nil {
 		return emptySetStateResponse, status, err
 	}
@@ -96,6 +125,7 @@ type setStateOpts struct {
 	ChannelGroups []string
 	UUID          string
 	QueryParam    map[string]string
+	Timeout       time.Duration
 	pubnub        *PubNub
 	stringState   string
 	ctx           Context
@@ -129,6 +159,9 @@ func (o *setStateOpts) validate() error {
 	if err != nil {
 		return newValidationError(o, err.Error())
 	}
+	if size := len(state); size > setStateMaxSize {
+		return newValidationError(o, fmt.Sprintf("%s: %d bytes, max %d", StrStateTooLarge, size, setStateMaxSize))
+	}
 
 	o.stringState = string(state)
 
@@ -136,7 +169,7 @@ func (o *setStateOpts) validate() error {
 }
 
 func (o *setStateOpts) buildPath() (string, error) {
-	channels := string(utils.JoinChannels(o.Channels))
+	channels := string(utils.JoinChannels(o.pubnub.Config.channelNames(o.Channels)))
 	uuid := o.UUID
 	if uuid == "" {
 		uuid = o.pubnub.Config.UUID
@@ -152,7 +185,7 @@ func (o *setStateOpts) buildPath() (string, error) {
 func (o *setStateOpts) buildQuery() (*url.Values, error) {
 	var groups []byte
 
-	q := defaultQuery(o.pubnub.Config.UUID, o.pubnub.telemetryManager)
+	q := defaultQuery(o.pubnub.Config, o.pubnub.telemetryManager)
 
 	groups = utils.JoinChannels(o.ChannelGroups)
 
@@ -163,6 +196,7 @@ func (o *setStateOpts) buildQuery() (*url.Values, error) {
 	if len(o.ChannelGroups) > 0 {
 		q.Set("channel-group", string(groups))
 	}
+	o.pubnub.tokenManager.setAuthParamForChannels(q, o.Channels, o.ChannelGroups)
 	SetQueryParam(q, o.QueryParam)
 	return q, nil
 }
@@ -199,6 +233,14 @@ func (o *setStateOpts) telemetryManager() *TelemetryManager {
 	return o.pubnub.telemetryManager
 }
 
+func (o *setStateOpts) tokenManager() *TokenManager {
+	return o.pubnub.tokenManager
+}
+
+func (o *setStateOpts) timeout() time.Duration {
+	return o.Timeout
+}
+
 func newSetStateResponse(jsonBytes []byte, status StatusResponse) (
 	*SetStateResponse, StatusResponse, error) {
 	resp := &SetStateResponse{}
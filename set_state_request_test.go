@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/url"
+	"strings"
 	"testing"
 
 	h "github.com/pubnub/go/tests/helpers"
@@ -197,7 +198,7 @@ func TestSetStateValidateSubscribeKey(t *testing.T) {
 		pubnub:        pn,
 	}
 
-	assert.Equal("pubnub/validation: pubnub: \n: Missing Subscribe Key", opts.validate().Error())
+	assert.Equal("pubnub/validation: pubnub: Set State: Missing Subscribe Key", opts.validate().Error())
 }
 
 func TestSetStateValidateCG(t *testing.T) {
@@ -207,7 +208,7 @@ func TestSetStateValidateCG(t *testing.T) {
 		pubnub: pn,
 	}
 
-	assert.Equal("pubnub/validation: pubnub: \n: Missing Channel or Channel Group", opts.validate().Error())
+	assert.Equal("pubnub/validation: pubnub: Set State: Missing Channel or Channel Group", opts.validate().Error())
 }
 
 func TestSetStateValidateState(t *testing.T) {
@@ -218,7 +219,22 @@ func TestSetStateValidateState(t *testing.T) {
 		pubnub:        pn,
 	}
 
-	assert.Equal("pubnub/validation: pubnub: \n: Missing State", opts.validate().Error())
+	assert.Equal("pubnub/validation: pubnub: Set State: Missing State", opts.validate().Error())
+}
+
+func TestSetStateValidateStateSize(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+	opts := &setStateOpts{
+		Channels: []string{"ch"},
+		State:    map[string]interface{}{"k": strings.Repeat("a", setStateMaxSize)},
+		pubnub:   pn,
+	}
+
+	assert.Equal(fmt.Sprintf("pubnub/validation: pubnub: Set State: State too large: %d bytes, max %d", setStateMaxSize+8, setStateMaxSize), opts.validate().Error())
+
+	opts.State = map[string]interface{}{"k": strings.Repeat("a", setStateMaxSize-8)}
+	assert.Nil(opts.validate())
 }
 
 func TestNewSetStateResponseErrorUnmarshalling(t *testing.T) {
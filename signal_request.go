@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"time"
 
 	"github.com/pubnub/go/pnerr"
 	"github.com/pubnub/go/utils"
@@ -79,6 +80,18 @@ func (b *signalBuilder) QueryParam(queryParam map[string]string) *signalBuilder
 	return b
 }
 
+// Timeout sets the timeout for the Signal request.
+func (b *signalBuilder) Timeout(timeout time.Duration) *signalBuilder {
+	b.opts.Timeout = timeout
+
+	return b
+}
+
+// SignedURL returns the URL of the Signal request without executing it, it returns an error with UsePost.
+func (b *signalBuilder) SignedURL() (string, error) {
+	return signedURL(b.opts)
+}
+
 // Execute runs the Signal request.
 func (b *signalBuilder) Execute() (*SignalResponse, StatusResponse, error) {
 	rawJSON, status, err := executeRequest(b.opts)
@@ -95,6 +108,7 @@ type signalOpts struct {
 	Channel    string
 	UsePost    bool
 	QueryParam map[string]string
+	Timeout    time.Duration
 	Transport  http.RoundTripper
 	ctx        Context
 }
@@ -128,7 +142,7 @@ func (o *signalOpts) buildPath() (string, error) {
 		return fmt.Sprintf(signalPostPath,
 			o.pubnub.Config.PublishKey,
 			o.pubnub.Config.SubscribeKey,
-			utils.URLEncode(o.Channel),
+			utils.URLEncode(o.pubnub.Config.channelName(o.Channel)),
 			"0"), nil
 	}
 
@@ -142,15 +156,16 @@ func (o *signalOpts) buildPath() (string, error) {
 	return fmt.Sprintf(signalGetPath,
 		o.pubnub.Config.PublishKey,
 		o.pubnub.Config.SubscribeKey,
-		utils.URLEncode(o.Channel),
+		utils.URLEncode(o.pubnub.Config.channelName(o.Channel)),
 		"0",
 		utils.URLEncode(msg),
 	), nil
 }
 
 func (o *signalOpts) buildQuery() (*url.Values, error) {
-	q := defaultQuery(o.pubnub.Config.UUID, o.pubnub.telemetryManager)
+	q := defaultQuery(o.pubnub.Config, o.pubnub.telemetryManager)
 
+	o.pubnub.tokenManager.SetAuthParan(q, o.Channel, PNChannels)
 	SetQueryParam(q, o.QueryParam)
 
 	return q, nil
@@ -199,6 +214,18 @@ func (o *signalOpts) telemetryManager() *TelemetryManager {
 	return o.pubnub.telemetryManager
 }
 
+func (o *signalOpts) tokenManager() *TokenManager {
+	return o.pubnub.tokenManager
+}
+
+func (o *signalOpts) timeout() time.Duration {
+	return o.Timeout
+}
+
+func (o *signalOpts) transport() http.RoundTripper {
+	return o.Transport
+}
+
 // SignalResponse is the response to Signal request.
 type SignalResponse struct {
 	Timestamp int64
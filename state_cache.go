@@ -0,0 +1,95 @@
+package pubnub
+
+import (
+	"sync"
+	"time"
+)
+
+type stateCacheKey struct {
+	channel string
+	uuid    string
+}
+
+type stateCacheEntry struct {
+	state   interface{}
+	expires time.Time
+}
+
+// stateCache holds the states read by GetState with UseCache, keyed by channel and uuid.
+type stateCache struct {
+	sync.RWMutex
+	entries map[stateCacheKey]stateCacheEntry
+}
+
+func newStateCache() *stateCache {
+	return &stateCache{
+		entries: make(map[stateCacheKey]stateCacheEntry),
+	}
+}
+
+// get returns the states of all the channels for the uuid, ok is false when any of them is missing or expired.
+func (c *stateCache) get(channels []string, uuid string) (map[string]interface{}, bool) {
+	c.RLock()
+	defer c.RUnlock()
+
+	now := time.Now()
+	states := make(map[string]interface{}, len(channels))
+	for _, ch := range channels {
+		entry, ok := c.entries[stateCacheKey{channel: ch, uuid: uuid}]
+		if !ok || now.After(entry.expires) {
+			return nil, false
+		}
+		states[ch] = copyState(entry.state)
+	}
+
+	return states, true
+}
+
+func (c *stateCache) set(states map[string]interface{}, uuid string, ttl time.Duration) {
+	c.Lock()
+	defer c.Unlock()
+
+	expires := time.Now().Add(ttl)
+	for ch, state := range states {
+		c.entries[stateCacheKey{channel: ch, uuid: uuid}] = stateCacheEntry{state: copyState(state), expires: expires}
+	}
+}
+
+// invalidate drops the states of the channels for the uuid, with no channels all the states of the uuid are dropped.
+func (c *stateCache) invalidate(channels []string, uuid string) {
+	c.Lock()
+	defer c.Unlock()
+
+	if len(channels) == 0 {
+		for key := range c.entries {
+			if key.uuid == uuid {
+				delete(c.entries, key)
+			}
+		}
+		return
+	}
+	for _, ch := range channels {
+		delete(c.entries, stateCacheKey{channel: ch, uuid: uuid})
+	}
+}
+
+// copyState returns a copy of a decoded JSON state, its maps and slices are copied so that
+// the states returned to the callers don't share them with the cache.
+func copyState(state interface{}) interface{} {
+	switch v := state.(type) {
+	case map[string]interface{}:
+		c := make(map[string]interface{}, len(v))
+		for key, value := range v {
+			c[key] = copyState(value)
+		}
+		return c
+	case []interface{}:
+		c := make([]interface{}, len(v))
+		for i, value := range v {
+			c[i] = copyState(value)
+		}
+		return c
+	default:
+		return v
+	}
+}
@@ -6,8 +6,16 @@ package pubnub
 // 	"golang.org/x/net/context"
 // )
 
+import (
+	"time"
+)
+
 func contextWithCancel(parent context.Context) (context.Context, context.CancelFunc) {
 	return context.WithCancel(parent)
 }
 
+func contextWithTimeout(parent context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(parent, timeout)
+}
+
 var backgroundContext = context.Background()
@@ -4,6 +4,7 @@ package pubnub
 
 import (
 	"context"
+	"time"
 )
 
 func contextWithCancel(parent context.Context) (
@@ -11,4 +12,9 @@ func contextWithCancel(parent context.Context) (
 	return context.WithCancel(parent)
 }
 
+func contextWithTimeout(parent context.Context, timeout time.Duration) (
+	context.Context, context.CancelFunc) {
+	return context.WithTimeout(parent, timeout)
+}
+
 var backgroundContext = context.Background()
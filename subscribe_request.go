@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/url"
 	"strconv"
+	"time"
 
 	"github.com/pubnub/go/utils"
 )
@@ -45,6 +46,13 @@ func newSubscribeBuilder(pubnub *PubNub) *subscribeBuilder {
 	return &builder
 }
 
+func newSubscribeBuilderWithContext(pubnub *PubNub, context Context) *subscribeBuilder {
+	builder := newSubscribeBuilder(pubnub)
+	builder.opts.ctx = context
+
+	return builder
+}
+
 // Channels sets the channels to subscribe.
 func (b *subscribeBuilder) Channels(channels []string) *subscribeBuilder {
 	b.operation.Channels = channels
@@ -93,9 +101,19 @@ func (b *subscribeBuilder) QueryParam(queryParam map[string]string) *subscribeBu
 	return b
 }
 
-// Execute runs the Subscribe operation.
+// Execute runs the Subscribe operation, the channels and groups are unsubscribed when the context of SubscribeWithContext is done.
 func (b *subscribeBuilder) Execute() {
 	b.opts.pubnub.subscriptionManager.adaptSubscribe(b.operation)
+
+	if b.opts.ctx != nil {
+		b.opts.pubnub.subscriptionManager.unsubscribeOnDone(b.opts.ctx, b.operation)
+	}
+}
+
+// isInternal is true as the subscribe requests are sent by the subscription manager, they keep the Config.UUID
+// when DisableDefaultQueryParams is set.
+func (o *subscribeOpts) isInternal() bool {
+	return true
 }
 
 func (o *subscribeOpts) config() Config {
@@ -123,6 +141,10 @@ func (o *subscribeOpts) validate() error {
 		return newValidationError(o, StrMissingChannel)
 	}
 
+	if err := validateChannelNames(o, o.Channels...); err != nil {
+		return err
+	}
+
 	if o.State != nil {
 		state, err := json.Marshal(o.State)
 		if err != nil {
@@ -135,7 +157,7 @@ func (o *subscribeOpts) validate() error {
 }
 
 func (o *subscribeOpts) buildPath() (string, error) {
-	channels := utils.JoinChannels(o.Channels)
+	channels := utils.JoinChannels(o.pubnub.Config.channelNames(o.Channels))
 
 	return fmt.Sprintf(subscribePath,
 		o.pubnub.Config.SubscribeKey,
@@ -144,7 +166,7 @@ func (o *subscribeOpts) buildPath() (string, error) {
 }
 
 func (o *subscribeOpts) buildQuery() (*url.Values, error) {
-	q := defaultQuery(o.pubnub.Config.UUID, o.pubnub.telemetryManager)
+	q := defaultQuery(o.pubnub.Config, o.pubnub.telemetryManager)
 
 	if len(o.ChannelGroups) > 0 {
 		channelGroup := utils.JoinChannels(o.ChannelGroups)
@@ -172,6 +194,7 @@ func (o *subscribeOpts) buildQuery() (*url.Values, error) {
 		q.Set("state", o.stringState)
 	}
 
+	o.pubnub.tokenManager.setAuthParamForChannels(q, o.Channels, o.ChannelGroups)
 	SetQueryParam(q, o.QueryParam)
 
 	return q, nil
@@ -208,3 +231,11 @@ func (o *subscribeOpts) operationType() OperationType {
 func (o *subscribeOpts) telemetryManager() *TelemetryManager {
 	return o.pubnub.telemetryManager
 }
+
+func (o *subscribeOpts) tokenManager() *TokenManager {
+	return o.pubnub.tokenManager
+}
+
+func (o *subscribeOpts) timeout() time.Duration {
+	return 0
+}
@@ -1,6 +1,7 @@
 package pubnub
 
 import (
+	"fmt"
 	"net/url"
 	"testing"
 
@@ -147,7 +148,7 @@ func TestSubscribeValidateSubscribeKey(t *testing.T) {
 		pubnub:           pn,
 	}
 
-	assert.Equal("pubnub/validation: pubnub: \x01: Missing Subscribe Key", opts.validate().Error())
+	assert.Equal("pubnub/validation: pubnub: Subscribe: Missing Subscribe Key", opts.validate().Error())
 }
 
 func TestSubscribeValidatePublishKey(t *testing.T) {
@@ -163,7 +164,7 @@ func TestSubscribeValidatePublishKey(t *testing.T) {
 		pubnub:           pn,
 	}
 
-	assert.Equal("pubnub/validation: pubnub: \x01: Missing Publish Key", opts.validate().Error())
+	assert.Equal("pubnub/validation: pubnub: Subscribe: Missing Publish Key", opts.validate().Error())
 }
 
 func TestSubscribeValidateCHAndCG(t *testing.T) {
@@ -176,7 +177,7 @@ func TestSubscribeValidateCHAndCG(t *testing.T) {
 		pubnub:           pn,
 	}
 
-	assert.Equal("pubnub/validation: pubnub: \x01: Missing Channel", opts.validate().Error())
+	assert.Equal("pubnub/validation: pubnub: Subscribe: Missing Channel", opts.validate().Error())
 }
 
 func TestSubscribeValidateState(t *testing.T) {
@@ -194,3 +195,24 @@ func TestSubscribeValidateState(t *testing.T) {
 
 	assert.Nil(opts.validate())
 }
+
+func TestSubscribeValidateChannelNames(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+
+	for _, c := range []string{"/", "?", "#", ","} {
+		opts := &subscribeOpts{
+			Channels: []string{"ch", "a" + c + "b"},
+			pubnub:   pn,
+		}
+
+		assert.Equal(fmt.Sprintf("pubnub/validation: pubnub: Subscribe: Invalid Channel a%sb: '%s' is not allowed", c, c), opts.validate().Error())
+	}
+
+	opts := &subscribeOpts{
+		Channels: []string{"-._~:[]@!$&'()*+;=`|"},
+		pubnub:   pn,
+	}
+
+	assert.Nil(opts.validate())
+}
@@ -3,9 +3,11 @@ package pubnub
 import (
 	"encoding/json"
 	"errors"
+	"github.com/pubnub/go/pnerr"
 	"github.com/pubnub/go/utils"
 	"net/http"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -16,6 +18,13 @@ import (
 // - ConnectedCategory - after connection established
 // - DisconnectedCategory - after subscription loop stops for any reason (no
 // channels left or error happened)
+// - ReconnectedCategory - after the connection is restored by the reconnection policy
+// - AccessDeniedCategory - on a 403, all channels and channel groups are unsubscribed
+// - TimeoutCategory - when the subscribe request times out, the loop continues
+// - NetworkIssuesCategory - when the subscribe request fails to reach the server
+// - UnknownCategory - on any other error
+// The statuses raised by a subscribe request carry the affected channels and
+// channel groups, and the error in ErrorData.
 
 // Unsubscribe.
 // When you unsubscribe from channel or channel group the following events
@@ -64,9 +73,14 @@ type SubscriptionManager struct {
 	heartbeatStopCalled          bool
 	exitSubscriptionManagerMutex sync.Mutex
 	exitSubscriptionManager      chan bool
-	queryParam                   map[string]string
-	channelsOpen                 bool
-	requestSentAt                int64
+	// closed when the message worker reading exitSubscriptionManager returns
+	subscribeWorkerDone chan struct{}
+	queryParam          map[string]string
+	channelsOpen        bool
+	requestSentAt       int64
+
+	// consecutive failed subscribe requests, counted across the restarts of the loop
+	failedSubscribeCalls int
 }
 
 // SubscribeOperation
@@ -121,11 +135,16 @@ func newSubscriptionManager(pubnub *PubNub, ctx Context) *SubscriptionManager {
 			combinedChannels := manager.stateManager.prepareChannelList(true)
 			combinedGroups := manager.stateManager.prepareGroupList(true)
 
+			manager.RLock()
+			timetoken := manager.timetoken
+			manager.RUnlock()
+
 			pnStatus := &PNStatus{
 				Error:                 false,
 				AffectedChannels:      combinedChannels,
 				AffectedChannelGroups: combinedGroups,
 				Category:              PNReconnectedCategory,
+				CurrentTimetoken:      timetoken,
 			}
 
 			pubnub.Config.Log.Println("Status: ", pnStatus)
@@ -163,16 +182,15 @@ func newSubscriptionManager(pubnub *PubNub, ctx Context) *SubscriptionManager {
 }
 
 func (m *SubscriptionManager) Destroy() {
-	if m.subscribeCancel != nil {
-		m.subscribeCancel()
-	}
+	m.Lock()
+	m.stopSubscribeLoop()
+	m.Unlock()
 	if m.channelsOpen {
 		m.RLock()
 		m.channelsOpen = false
 		m.RUnlock()
-		if m.exitSubscriptionManager != nil {
-			close(m.exitSubscriptionManager)
-		}
+		// the message workers exit when the PubNub context is cancelled, exitSubscriptionManager is not closed
+		// as a worker which is still starting sends on it to stop the previous one
 		if m.listenerManager.exitListener != nil {
 			close(m.listenerManager.exitListener)
 		}
@@ -230,8 +248,11 @@ func (m *SubscriptionManager) adaptUnsubscribe(
 	go func() {
 		announceAck := false
 		if !m.pubnub.Config.SuppressLeaveEvents {
-			_, err := m.pubnub.Leave().Channels(unsubscribeOperation.Channels).
-				ChannelGroups(unsubscribeOperation.ChannelGroups).QueryParam(unsubscribeOperation.QueryParam).Execute()
+			leaveBuilder := m.pubnub.Leave().Channels(unsubscribeOperation.Channels).
+				ChannelGroups(unsubscribeOperation.ChannelGroups).QueryParam(unsubscribeOperation.QueryParam)
+			leaveBuilder.opts.internal = true
+
+			_, err := leaveBuilder.Execute()
 
 			if err != nil {
 				pnStatus := &PNStatus{
@@ -282,12 +303,64 @@ func (m *SubscriptionManager) adaptUnsubscribe(
 	m.pubnub.Config.Log.Println("after reconnect")
 }
 
+// unsubscribeOnDone unsubscribes the channels and groups of the operation when ctx is done,
+// the subscribe loop stops or restarts with the remaining channels.
+func (m *SubscriptionManager) unsubscribeOnDone(ctx Context, operation *SubscribeOperation) {
+	done := ctx.Done()
+	if done == nil {
+		return
+	}
+
+	go func() {
+		select {
+		case <-done:
+		case <-m.pubnub.ctx.Done():
+		}
+		if m.pubnub.ctx.Err() != nil {
+			// the subscription manager is destroyed with the PubNub instance
+			return
+		}
+		m.pubnub.Config.Log.Println("subscribe context done:", ctx.Err())
+
+		channels := append([]string{}, operation.Channels...)
+		groups := append([]string{}, operation.ChannelGroups...)
+		if operation.PresenceEnabled {
+			for _, ch := range operation.Channels {
+				channels = append(channels, ch+"-pnpres")
+			}
+			for _, cg := range operation.ChannelGroups {
+				groups = append(groups, cg+"-pnpres")
+			}
+		}
+
+		m.adaptUnsubscribe(&UnsubscribeOperation{
+			Channels:      channels,
+			ChannelGroups: groups,
+			QueryParam:    operation.QueryParam,
+		})
+		m.listenerManager.announceStatus(&PNStatus{
+			Category:              PNDisconnectedCategory,
+			Operation:             PNSubscribeOperation,
+			AffectedChannels:      operation.Channels,
+			AffectedChannelGroups: operation.ChannelGroups,
+		})
+	}()
+}
+
 func (m *SubscriptionManager) startSubscribeLoop() {
 	m.pubnub.Config.Log.Println("startSubscribeLoop")
+	// the context is set before the loops start, the loops of a split subscription stop when it is cancelled
+	m.Lock()
+	if m.ctx == nil && m.subscribeCancel == nil {
+		m.ctx, m.subscribeCancel = contextWithCancel(backgroundContext)
+	}
+	loopCtx := m.ctx
+	m.Unlock()
 	go subscribeMessageWorker(m)
 
 	go m.reconnectionManager.startPolling()
 
+	split := false
 	for {
 		m.pubnub.Config.Log.Println("startSubscribeLoop looping...")
 		combinedChannels := m.stateManager.prepareChannelList(true)
@@ -305,9 +378,33 @@ func (m *SubscriptionManager) startSubscribeLoop() {
 
 		m.Lock()
 		tt := m.timetoken
+		region := m.region
 		ctx := m.ctx
+		storedTimetoken := m.storedTimetoken
 		m.Unlock()
 
+		// the loop was stopped or restarted while its last request was in flight
+		if ctx != loopCtx {
+			m.pubnub.Config.Log.Println("subscribe loop stopped")
+			break
+		}
+
+		// the channel mix doesn't change during a loop, a change restarts the loop
+		chunks := splitSubscribeChannels(combinedChannels, combinedGroups, subscribeMaxChannelsLength)
+		if len(chunks) > 1 && !split {
+			split = true
+			m.listenerManager.announceStatus(&PNStatus{
+				Category:              PNSubscribeSplitCategory,
+				Operation:             PNSubscribeOperation,
+				AffectedChannels:      combinedChannels,
+				AffectedChannelGroups: combinedGroups,
+			})
+			for _, chunk := range chunks[1:] {
+				go m.subscribeChunkLoop(ctx, chunk, tt, storedTimetoken)
+			}
+		}
+		combinedChannels, combinedGroups = chunks[0].channels, chunks[0].groups
+
 		opts := &subscribeOpts{
 			pubnub:           m.pubnub,
 			Channels:         combinedChannels,
@@ -319,6 +416,10 @@ func (m *SubscriptionManager) startSubscribeLoop() {
 			QueryParam:       m.queryParam,
 		}
 
+		if tt != 0 && region != 0 {
+			opts.Region = strconv.Itoa(int(region))
+		}
+
 		if s := m.stateManager.createStatePayload(); len(s) > 0 {
 			opts.State = s
 		}
@@ -330,75 +431,26 @@ func (m *SubscriptionManager) startSubscribeLoop() {
 		if err != nil {
 			m.pubnub.Config.Log.Println(err.Error())
 
-			if strings.Contains(err.Error(), "timeout") || strings.Contains(err.Error(), "request canceled") {
-				m.listenerManager.announceStatus(&PNStatus{
-					Category: PNTimeoutCategory,
-				})
+			if m.handleSubscribeError(err, combinedChannels, combinedGroups) {
 				m.pubnub.Config.Log.Println("continue")
 				continue
-			} else {
-
-				if strings.Contains(err.Error(), "context canceled") {
-					pnStatus := &PNStatus{
-						Category: PNCancelledCategory,
-					}
-					m.pubnub.Config.Log.Println("Status:", pnStatus)
-					m.listenerManager.announceStatus(pnStatus)
-					m.pubnub.Config.Log.Println("context canceled")
-					break
-				} else if strings.Contains(err.Error(), "Forbidden") ||
-					strings.Contains(err.Error(), "403") {
-					pnStatus := &PNStatus{
-						Category: PNAccessDeniedCategory,
-					}
-					m.pubnub.Config.Log.Println("Status:", pnStatus)
-					m.listenerManager.announceStatus(pnStatus)
-					m.unsubscribeAll()
-					break
-				} else if strings.Contains(err.Error(), "400") ||
-					strings.Contains(err.Error(), "Bad Request") {
-					pnStatus := &PNStatus{
-						Category: PNBadRequestCategory,
-					}
-					m.pubnub.Config.Log.Println("Status:", pnStatus)
-					m.listenerManager.announceStatus(pnStatus)
-					m.unsubscribeAll()
-					break
-				} else if strings.Contains(err.Error(), "530") || strings.Contains(err.Error(), "No Stub Matched") {
-					pnStatus := &PNStatus{
-						Category: PNNoStubMatchedCategory,
-					}
-					m.pubnub.Config.Log.Println("Status:", pnStatus)
-					m.listenerManager.announceStatus(pnStatus)
-					m.unsubscribeAll()
-					break
-				} else {
-					pnStatus := &PNStatus{
-						Category: PNUnknownCategory,
-					}
-					m.pubnub.Config.Log.Println("Status:", pnStatus)
-					m.listenerManager.announceStatus(pnStatus)
-
-					break
-				}
 			}
-
+			break
 		}
 
 		m.Lock()
+		m.failedSubscribeCalls = 0
 		announced := m.subscriptionStateAnnounced
 
 		if announced == false {
 
-			m.listenerManager.announceStatus(&PNStatus{
-				Category: PNConnectedCategory,
-			})
+			m.listenerManager.announceStatus(subscribeStatus(PNConnectedCategory, nil, combinedChannels, combinedGroups))
 			m.subscriptionStateAnnounced = true
 		}
 		m.Unlock()
 
 		var envelope subscribeEnvelope
-		err = json.Unmarshal(res, &envelope)
+		err = unmarshalJSON(res, &envelope, m.pubnub.Config.UseNumber)
 		if err != nil {
 			pnStatus := &PNStatus{
 				Category:              PNBadRequestCategory,
@@ -425,6 +477,7 @@ func (m *SubscriptionManager) startSubscribeLoop() {
 
 				m.listenerManager.announceStatus(pnStatus)
 			}
+			sort.Stable(subscribeMessagesByTimetoken(envelope.Messages))
 			for _, message := range envelope.Messages {
 				m.messages <- message
 			}
@@ -449,16 +502,87 @@ func (m *SubscriptionManager) startSubscribeLoop() {
 				}
 				m.pubnub.Config.Log.Println("ParseInt: err", err, pnStatus)
 				m.listenerManager.announceStatus(pnStatus)
+			} else {
+				// Keep the last good timetoken on a malformed response to not drop messages.
+				m.timetoken = tt
+				m.region = envelope.Metadata.Region
 			}
-
-			m.timetoken = tt
 		}
-
-		m.region = envelope.Metadata.Region
 		m.Unlock()
 	}
 }
 
+// handleSubscribeError announces the status of a failed subscribe request of the main loop or of a chunk loop,
+// it returns true when the request is retried and false when the loop stops.
+func (m *SubscriptionManager) handleSubscribeError(err error, channels, groups []string) bool {
+	if m.subscribeRetriesExhausted(err, channels, groups) {
+		return false
+	}
+
+	if strings.Contains(err.Error(), "timeout") || strings.Contains(err.Error(), "request canceled") {
+		m.listenerManager.announceStatus(subscribeStatus(PNTimeoutCategory, err, channels, groups))
+		return true
+	}
+
+	category := PNUnknownCategory
+	unsubscribe := false
+	if strings.Contains(err.Error(), "context canceled") {
+		category = PNCancelledCategory
+	} else if strings.Contains(err.Error(), "Forbidden") ||
+		strings.Contains(err.Error(), "403") {
+		category = PNAccessDeniedCategory
+		unsubscribe = true
+	} else if strings.Contains(err.Error(), "400") ||
+		strings.Contains(err.Error(), "Bad Request") {
+		category = PNBadRequestCategory
+		unsubscribe = true
+	} else if strings.Contains(err.Error(), "530") || strings.Contains(err.Error(), "No Stub Matched") {
+		category = PNNoStubMatchedCategory
+		unsubscribe = true
+	} else if _, ok := err.(*pnerr.ConnectionError); ok {
+		category = PNNetworkIssuesCategory
+	}
+
+	pnStatus := subscribeStatus(category, err, channels, groups)
+	m.pubnub.Config.Log.Println("Status:", pnStatus)
+	m.listenerManager.announceStatus(pnStatus)
+	if unsubscribe {
+		m.unsubscribeAll()
+	}
+
+	return false
+}
+
+// subscribeRetriesExhausted counts the failed subscribe request, it returns true when Config.SubscribeMaxReconnectionRetries
+// consecutive requests failed. The exhausted status is then announced and the loop stopped, the channels and listeners are kept.
+func (m *SubscriptionManager) subscribeRetriesExhausted(err error, channels, groups []string) bool {
+	if strings.Contains(err.Error(), "context canceled") {
+		return false
+	}
+
+	m.Lock()
+	m.failedSubscribeCalls++
+	failedCalls := m.failedSubscribeCalls
+	m.Unlock()
+
+	retries := m.pubnub.Config.SubscribeMaxReconnectionRetries
+	if retries <= 0 || failedCalls < retries {
+		return false
+	}
+
+	m.pubnub.Config.Log.Printf("subscribe retry limit (%d) exceeded\n", retries)
+	m.listenerManager.announceStatus(subscribeStatus(PNReconnectionAttemptsExhausted, err, channels, groups))
+	m.reconnectionManager.stopHeartbeatTimer()
+
+	// the count is reset and the loop stopped under the same lock, the subscribe ctx is shared with the other callers
+	m.Lock()
+	m.failedSubscribeCalls = 0
+	m.stopSubscribeLoop()
+	m.Unlock()
+
+	return true
+}
+
 type subscribeEnvelope struct {
 	Messages []subscribeMessage `json:"m"`
 	Metadata struct {
@@ -477,6 +601,7 @@ type subscribeMessage struct {
 	Payload           interface{}   `json:"d"`
 	UserMetadata      interface{}   `json:"u"`
 	MessageType       PNMessageType `json:"e"`
+	CustomMessageType string        `json:"cmt"`
 
 	PublishMetaData publishMetadata `json:"p"`
 }
@@ -494,11 +619,150 @@ type publishMetadata struct {
 	Region           int    `json:"r"`
 }
 
+// subscribeMessagesByTimetoken sorts the messages of an envelope by their publish timetoken,
+// the single message worker then dispatches the messages of each channel in timetoken order.
+// The messages without a valid timetoken sort first, the equal timetokens keep their order.
+type subscribeMessagesByTimetoken []subscribeMessage
+
+func (s subscribeMessagesByTimetoken) Len() int      { return len(s) }
+func (s subscribeMessagesByTimetoken) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s subscribeMessagesByTimetoken) Less(i, j int) bool {
+	ti, _ := strconv.ParseInt(s[i].PublishMetaData.PublishTimetoken, 10, 64)
+	tj, _ := strconv.ParseInt(s[j].PublishMetaData.PublishTimetoken, 10, 64)
+	return ti < tj
+}
+
 type originationMetadata struct {
 	Timetoken int64 `json:"t"`
 	Region    int   `json:"r"`
 }
 
+// subscribeMaxChannelsLength is the max length of the encoded channels and groups of a subscribe request,
+// above it the channels are split into several subscribe requests to keep the URL length safe.
+const subscribeMaxChannelsLength = 8000
+
+// subscribeChunk holds the channels and groups of one of the requests of a split subscription.
+type subscribeChunk struct {
+	channels []string
+	groups   []string
+}
+
+// splitSubscribeChannels splits the channels and groups into chunks whose encoded length is at most max,
+// a single chunk is returned when they fit in one request.
+// The names are sorted first, the lists come from maps and the chunks must be the same at each iteration of the loop.
+func splitSubscribeChannels(channels, groups []string, max int) []subscribeChunk {
+	channels = append([]string{}, channels...)
+	groups = append([]string{}, groups...)
+	sort.Strings(channels)
+	sort.Strings(groups)
+
+	var chunks []subscribeChunk
+	var chunk subscribeChunk
+	length := 0
+
+	add := func(name string, group bool) {
+		l := len(utils.URLEncode(name)) + 1
+		if length > 0 && length+l > max {
+			chunks = append(chunks, chunk)
+			chunk = subscribeChunk{}
+			length = 0
+		}
+		if group {
+			chunk.groups = append(chunk.groups, name)
+		} else {
+			chunk.channels = append(chunk.channels, name)
+		}
+		length += l
+	}
+
+	for _, channel := range channels {
+		add(channel, false)
+	}
+	for _, group := range groups {
+		add(group, true)
+	}
+
+	return append(chunks, chunk)
+}
+
+// subscribeChunkLoop runs the subscribe requests of an additional chunk of a split subscription with its own timetoken,
+// the messages are processed by the subscribeMessageWorker with the messages of the main loop.
+// It returns when ctx is cancelled, which happens when the channel mix changes or the client disconnects.
+// Its failed requests are handled as the ones of the main loop, an error which stops a loop stops the whole subscription.
+func (m *SubscriptionManager) subscribeChunkLoop(ctx Context, chunk subscribeChunk, tt, storedTimetoken int64) {
+	var region int8
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		opts := &subscribeOpts{
+			pubnub:           m.pubnub,
+			Channels:         chunk.channels,
+			ChannelGroups:    chunk.groups,
+			Timetoken:        tt,
+			Heartbeat:        m.pubnub.Config.PresenceTimeout,
+			FilterExpression: m.pubnub.Config.FilterExpression,
+			ctx:              ctx,
+			QueryParam:       m.queryParam,
+		}
+
+		if tt != 0 && region != 0 {
+			opts.Region = strconv.Itoa(int(region))
+		}
+
+		if s := m.stateManager.createStatePayload(); len(s) > 0 {
+			opts.State = s
+		}
+
+		res, _, err := executeRequest(opts)
+		if err != nil {
+			m.pubnub.Config.Log.Println("subscribeChunkLoop:", err.Error())
+
+			if ctx.Err() != nil {
+				// the loops were stopped or restarted, the main loop announces it
+				return
+			}
+			if m.handleSubscribeError(err, chunk.channels, chunk.groups) {
+				continue
+			}
+
+			// the main loop stops on the same errors, the whole subscription stops so that a reconnect restarts all the chunks
+			m.Lock()
+			if m.ctx == ctx {
+				m.stopSubscribeLoop()
+			}
+			m.Unlock()
+			return
+		}
+
+		m.Lock()
+		m.failedSubscribeCalls = 0
+		m.Unlock()
+
+		var envelope subscribeEnvelope
+		err = unmarshalJSON(res, &envelope, m.pubnub.Config.UseNumber)
+		if err != nil {
+			m.listenerManager.announceStatus(subscribeStatus(PNBadRequestCategory, err, chunk.channels, chunk.groups))
+		}
+		sort.Stable(subscribeMessagesByTimetoken(envelope.Messages))
+		for _, message := range envelope.Messages {
+			m.messages <- message
+		}
+
+		if storedTimetoken != -1 {
+			tt = storedTimetoken
+			storedTimetoken = -1
+		} else if t, err := strconv.ParseInt(envelope.Metadata.Timetoken, 10, 64); err == nil {
+			tt = t
+			region = envelope.Metadata.Region
+		}
+	}
+}
+
 func subscribeMessageWorker(m *SubscriptionManager) {
 	m.Lock()
 	if m.ctx == nil && m.subscribeCancel == nil {
@@ -510,15 +774,19 @@ func subscribeMessageWorker(m *SubscriptionManager) {
 	m.pubnub.Config.Log.Println("subscribeMessageWorker")
 
 	m.Unlock()
-	if m.exitSubscriptionManager != nil {
-		m.exitSubscriptionManager <- true
-		m.pubnub.Config.Log.Println("close exitSubscriptionManager")
+	if !m.stopSubscribeMessageWorker() {
+		m.pubnub.Config.Log.Println("subscribeMessageWorker pubnub destroyed")
+		return
 	}
 	m.pubnub.Config.Log.Println("acquiring lock exitSubscriptionManagerMutex")
 	m.exitSubscriptionManagerMutex.Lock()
 	m.pubnub.Config.Log.Println("make channel exitSubscriptionManager")
-	m.exitSubscriptionManager = make(chan bool)
-	for m.exitSubscriptionManager != nil {
+	exit, done := make(chan bool), make(chan struct{})
+	m.Lock()
+	m.exitSubscriptionManager, m.subscribeWorkerDone = exit, done
+	m.Unlock()
+WorkerLoop:
+	for {
 		m.pubnub.Config.Log.Println("subscribeMessageWorker looping...")
 		combinedChannels := m.stateManager.prepareChannelList(true)
 		combinedGroups := m.stateManager.prepareGroupList(true)
@@ -528,19 +796,47 @@ func subscribeMessageWorker(m *SubscriptionManager) {
 			break
 		}
 		select {
-		case <-m.exitSubscriptionManager:
+		case <-exit:
 			m.pubnub.Config.Log.Println("subscribeMessageWorker context done")
-			m.exitSubscriptionManager = nil
-			break
+			break WorkerLoop
+		case <-m.pubnub.ctx.Done():
+			m.pubnub.Config.Log.Println("subscribeMessageWorker pubnub destroyed")
+			break WorkerLoop
 		case message := <-m.messages:
 			m.pubnub.Config.Log.Println("subscribeMessageWorker messages")
 			processSubscribePayload(m, message)
 		}
 	}
 	m.pubnub.Config.Log.Println("subscribeMessageWorker after for")
+	m.Lock()
+	if m.exitSubscriptionManager == exit {
+		m.exitSubscriptionManager, m.subscribeWorkerDone = nil, nil
+	}
+	m.Unlock()
+	close(done)
 	m.exitSubscriptionManagerMutex.Unlock()
 }
 
+// stopSubscribeMessageWorker stops the running message worker, if any.
+// It returns false when the PubNub instance is destroyed first.
+func (m *SubscriptionManager) stopSubscribeMessageWorker() bool {
+	m.Lock()
+	exit, done := m.exitSubscriptionManager, m.subscribeWorkerDone
+	m.Unlock()
+	if exit == nil {
+		return true
+	}
+
+	select {
+	case exit <- true:
+		m.pubnub.Config.Log.Println("close exitSubscriptionManager")
+	case <-done:
+	case <-m.pubnub.ctx.Done():
+		return false
+	}
+	return true
+}
+
 func processSubscribePayload(m *SubscriptionManager, payload subscribeMessage) {
 	channel := payload.Channel
 	subscriptionMatch := payload.SubscriptionMatch
@@ -570,7 +866,15 @@ func processSubscribePayload(m *SubscriptionManager, payload subscribeMessage) {
 
 		action, _ = presencePayload["action"].(string)
 		uuid, _ = presencePayload["uuid"].(string)
-		occupancy, _ = presencePayload["occupancy"].(int)
+		switch o := presencePayload["occupancy"].(type) {
+		case int:
+			occupancy = o
+		case float64:
+			occupancy = int(o)
+		case json.Number:
+			n, _ := o.Int64()
+			occupancy = int(n)
+		}
 		if presencePayload["timestamp"] != nil {
 			m.pubnub.Config.Log.Println("presencePayload['timestamp'] type", reflect.TypeOf(presencePayload["timestamp"]).Kind())
 			switch presencePayload["timestamp"].(type) {
@@ -583,6 +887,9 @@ func processSubscribePayload(m *SubscriptionManager, payload subscribeMessage) {
 			case float64:
 				timestamp = int64(presencePayload["timestamp"].(float64))
 				break
+			case json.Number:
+				timestamp, _ = presencePayload["timestamp"].(json.Number).Int64()
+				break
 			}
 
 		}
@@ -620,6 +927,9 @@ func processSubscribePayload(m *SubscriptionManager, payload subscribeMessage) {
 			UUID:              uuid,
 			Timestamp:         timestamp,
 			HereNowRefresh:    hereNowRefresh,
+			Join:              parsePresenceUUIDs(presencePayload["join"]),
+			Leave:             parsePresenceUUIDs(presencePayload["leave"]),
+			Timeout:           parsePresenceUUIDs(presencePayload["timeout"]),
 		}
 		m.listenerManager.announcePresence(pnPresenceResult)
 	} else {
@@ -635,7 +945,7 @@ func processSubscribePayload(m *SubscriptionManager, payload subscribeMessage) {
 
 		switch payload.MessageType {
 		case PNMessageTypeSignal:
-			pnMessageResult := createPNMessageResult(payload.Payload, actualCh, subscribedCh, channel, subscriptionMatch, payload.IssuingClientID, payload.UserMetadata, timetoken)
+			pnMessageResult := createPNMessageResult(payload.Payload, actualCh, subscribedCh, channel, subscriptionMatch, payload.IssuingClientID, payload.CustomMessageType, payload.UserMetadata, timetoken)
 			m.pubnub.Config.Log.Println("announceSignal,", pnMessageResult)
 			m.listenerManager.announceSignal(pnMessageResult)
 		case PNMessageTypeObjects:
@@ -668,7 +978,7 @@ func processSubscribePayload(m *SubscriptionManager, payload subscribeMessage) {
 				m.listenerManager.announceStatus(pnStatus)
 
 			}
-			pnMessageResult := createPNMessageResult(messagePayload, actualCh, subscribedCh, channel, subscriptionMatch, payload.IssuingClientID, payload.UserMetadata, timetoken)
+			pnMessageResult := createPNMessageResult(messagePayload, actualCh, subscribedCh, channel, subscriptionMatch, payload.IssuingClientID, payload.CustomMessageType, payload.UserMetadata, timetoken)
 			m.pubnub.Config.Log.Println("announceMessage,", pnMessageResult)
 			m.listenerManager.announceMessage(pnMessageResult)
 		}
@@ -804,7 +1114,7 @@ func createPNObjectsResult(objPayload interface{}, m *SubscriptionManager, actua
 	return pnUserEvent, pnSpaceEvent, pnMembershipEvent, eventType
 }
 
-func createPNMessageResult(messagePayload interface{}, actualCh, subscribedCh, channel, subscriptionMatch, issuingClientID string, userMetadata interface{}, timetoken int64) *PNMessage {
+func createPNMessageResult(messagePayload interface{}, actualCh, subscribedCh, channel, subscriptionMatch, issuingClientID, customMessageType string, userMetadata interface{}, timetoken int64) *PNMessage {
 
 	pnMessageResult := &PNMessage{
 		Message:           messagePayload,
@@ -815,6 +1125,7 @@ func createPNMessageResult(messagePayload interface{}, actualCh, subscribedCh, c
 		Timetoken:         timetoken,
 		Publisher:         issuingClientID,
 		UserMetadata:      userMetadata,
+		CustomMessageType: customMessageType,
 	}
 
 	return pnMessageResult
@@ -822,7 +1133,7 @@ func createPNMessageResult(messagePayload interface{}, actualCh, subscribedCh, c
 }
 
 // parseCipherInterface handles the decryption in case a cipher key is used
-// in case of error it returns data as is.
+// in case of error it returns data as is, without the error when Config.DecryptionFallbackToRaw is set.
 //
 // parameters
 // data: the data to decrypt as interface.
@@ -830,62 +1141,20 @@ func createPNMessageResult(messagePayload interface{}, actualCh, subscribedCh, c
 //
 // returns the decrypted data as interface and error.
 func parseCipherInterface(data interface{}, pnConf *Config) (interface{}, error) {
-	if pnConf.CipherKey != "" {
-		pnConf.Log.Println("reflect.TypeOf(data).Kind()", reflect.TypeOf(data).Kind(), data)
-		switch v := data.(type) {
-		case map[string]interface{}:
-
-			if !pnConf.DisablePNOtherProcessing {
-				//decrypt pn_other only
-				msg, ok := v["pn_other"].(string)
-				if ok {
-					pnConf.Log.Println("v[pn_other]", v["pn_other"], v, msg)
-					decrypted, errDecryption := utils.DecryptString(pnConf.CipherKey, msg)
-					if errDecryption != nil {
-						pnConf.Log.Println(errDecryption, msg)
-						return v, errDecryption
-					} else {
-						var intf interface{}
-						err := json.Unmarshal([]byte(decrypted.(string)), &intf)
-						if err != nil {
-							pnConf.Log.Println("Unmarshal: err", err)
-							return intf, err
-						}
-						v["pn_other"] = intf
-
-						pnConf.Log.Println("reflect.TypeOf(v).Kind()", reflect.TypeOf(v).Kind(), v)
-						return v, nil
-					}
-				}
-				return v, nil
-			}
-			pnConf.Log.Println("return as is reflect.TypeOf(v).Kind()", reflect.TypeOf(v).Kind(), v)
-			return v, nil
-		case string:
-			var intf interface{}
-			decrypted, errDecryption := utils.DecryptString(pnConf.CipherKey, data.(string))
-			if errDecryption != nil {
-				pnConf.Log.Println(errDecryption, intf)
-				intf = data
-				return intf, errDecryption
-			}
-			pnConf.Log.Println("reflect.TypeOf(intf).Kind()", reflect.TypeOf(decrypted).Kind(), decrypted)
-
-			err := json.Unmarshal([]byte(decrypted.(string)), &intf)
-			if err != nil {
-				pnConf.Log.Println("Unmarshal: err", err)
-				return intf, err
-			}
-
-			return intf, nil
-		default:
-			pnConf.Log.Println("returning as is", reflect.TypeOf(v).Kind())
-			return v, nil
-		}
-	} else {
+	if pnConf.CipherKey == "" {
 		pnConf.Log.Println("No Cipher, returning as is ", data)
 		return data, nil
 	}
+
+	decrypted, err := utils.DecryptMessage(data, pnConf.CipherKey, pnConf.UseRandomInitializationVector, pnConf.DisablePNOtherProcessing)
+	if err != nil {
+		pnConf.Log.Println("DecryptMessage: err", err, data)
+		if pnConf.DecryptionFallbackToRaw {
+			return data, nil
+		}
+	}
+
+	return decrypted, err
 }
 
 func (m *SubscriptionManager) AddListener(listener *Listener) {
@@ -911,7 +1180,9 @@ func (m *SubscriptionManager) reconnect() {
 	m.pubnub.Config.Log.Println("reconnect")
 	m.reconnectionManager.stopHeartbeatTimer()
 	m.pubnub.Config.Log.Println("after stopHeartbeatTimer")
+	m.Lock()
 	m.stopSubscribeLoop()
+	m.Unlock()
 
 	combinedChannels := m.stateManager.prepareChannelList(true)
 	combinedGroups := m.stateManager.prepareGroupList(true)
@@ -924,20 +1195,32 @@ func (m *SubscriptionManager) reconnect() {
 	}
 }
 
+// stop stops the subscribe loop and the reconnection polling without unsubscribing,
+// the channels, the listeners and the last timetoken are kept for reconnect.
+func (m *SubscriptionManager) stop() {
+	m.pubnub.Config.Log.Println("stop")
+	m.reconnectionManager.stopHeartbeatTimer()
+
+	m.Lock()
+	defer m.Unlock()
+	m.stopSubscribeLoop()
+}
+
 func (m *SubscriptionManager) Disconnect() {
 	m.pubnub.Config.Log.Println("disconnect")
 
-	if m.exitSubscriptionManager != nil {
-		m.exitSubscriptionManager <- true
-	}
+	m.stopSubscribeMessageWorker()
 	m.reconnectionManager.stopHeartbeatTimer()
 
 	m.pubnub.heartbeatManager.stopHeartbeat(false, false)
 	m.unsubscribeAll()
+	m.Lock()
 	m.stopSubscribeLoop()
+	m.Unlock()
 
 }
 
+// stopSubscribeLoop is called with the lock held.
 func (m *SubscriptionManager) stopSubscribeLoop() {
 	m.log("loop stop")
 
@@ -971,3 +1254,34 @@ func (m *SubscriptionManager) log(message string) {
 		m.stateManager.prepareChannelList(true),
 		m.stateManager.prepareGroupList(true))
 }
+
+// parsePresenceUUIDs returns the UUIDs of the join, leave or timeout array of an interval event,
+// it is nil when the array is missing, e.g. when here_now_refresh is sent instead.
+func parsePresenceUUIDs(uuids interface{}) []string {
+	switch u := uuids.(type) {
+	case []string:
+		return u
+	case []interface{}:
+		parsed := make([]string, 0, len(u))
+		for _, uuid := range u {
+			if s, ok := uuid.(string); ok {
+				parsed = append(parsed, s)
+			}
+		}
+		return parsed
+	}
+
+	return nil
+}
+
+// subscribeStatus returns the status of a subscribe request with the affected channels and groups populated.
+func subscribeStatus(category StatusCategory, err error, channels, groups []string) *PNStatus {
+	return &PNStatus{
+		Category:              category,
+		Error:                 err != nil,
+		ErrorData:             err,
+		Operation:             PNSubscribeOperation,
+		AffectedChannels:      channels,
+		AffectedChannelGroups: groups,
+	}
+}
@@ -1,10 +1,17 @@
 package pubnub
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/stretchr/testify/assert"
+	"net/http"
 	"reflect"
+	"sort"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 )
 
 type customStruct struct {
@@ -559,3 +566,798 @@ func TestProcessSubscribePayloadCipherErr(t *testing.T) {
 	<-done
 	//pn.Destroy()
 }
+
+func TestProcessSubscribePayloadSignalAndMessage(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+	listener := NewListener()
+	pn.AddListener(listener)
+
+	processSubscribePayload(pn.subscriptionManager, subscribeMessage{
+		Shard:       "1",
+		Channel:     "channel",
+		Payload:     "signal",
+		MessageType: PNMessageTypeSignal,
+	})
+
+	select {
+	case signal := <-listener.Signal:
+		assert.Equal("signal", signal.Message)
+		assert.Equal("channel", signal.Channel)
+	case <-listener.Message:
+		assert.Fail("signal received on the Message channel")
+	case <-time.After(time.Second):
+		assert.Fail("signal not received")
+	}
+
+	processSubscribePayload(pn.subscriptionManager, subscribeMessage{
+		Shard:   "1",
+		Channel: "channel",
+		Payload: "message",
+	})
+
+	select {
+	case message := <-listener.Message:
+		assert.Equal("message", message.Message)
+		assert.Equal("channel", message.Channel)
+	case <-listener.Signal:
+		assert.Fail("message received on the Signal channel")
+	case <-time.After(time.Second):
+		assert.Fail("message not received")
+	}
+}
+
+func TestProcessSubscribePayloadDecryptsMessage(t *testing.T) {
+	assert := assert.New(t)
+	done := make(chan bool)
+	pn := NewPubNub(NewDemoConfig())
+	pn.Config.CipherKey = "enigma"
+	listener := NewListener()
+
+	go func() {
+		for {
+			select {
+			case _ = <-listener.Status:
+				assert.Fail("No status expected")
+				done <- true
+			case message := <-listener.Message:
+				assert.Equal(map[string]interface{}{"text": "hey"}, message.Message)
+				assert.Equal("ch", message.Channel)
+				assert.Equal("ch", message.SubscribedChannel)
+				assert.Equal("publisher", message.Publisher)
+				assert.Equal("chat", message.CustomMessageType)
+				assert.Equal(int64(15078947309567840), message.Timetoken)
+				done <- true
+			}
+		}
+	}()
+
+	pn.AddListener(listener)
+
+	publish := newPublishBuilder(pn)
+	publish.Message(map[string]string{"text": "hey"})
+	encrypted, err := publish.opts.encryptProcessing(pn.Config.CipherKey)
+	assert.Nil(err)
+
+	var sm subscribeMessage
+	err = json.Unmarshal([]byte(fmt.Sprintf(`{"a":"1","c":"ch","i":"publisher","cmt":"chat","d":%s,"p":{"t":"15078947309567840"}}`, encrypted)), &sm)
+	assert.Nil(err)
+
+	processSubscribePayload(pn.subscriptionManager, sm)
+	<-done
+}
+
+func TestProcessSubscribePayloadDecryptsRandomIVMessage(t *testing.T) {
+	assert := assert.New(t)
+	done := make(chan bool)
+	pn := NewPubNub(NewDemoConfig())
+	pn.Config.CipherKey = "enigma"
+	pn.Config.UseRandomInitializationVector = true
+	listener := NewListener()
+
+	go func() {
+		for {
+			select {
+			case _ = <-listener.Status:
+				assert.Fail("No status expected")
+				done <- true
+			case message := <-listener.Message:
+				assert.Equal(map[string]interface{}{"text": "hey"}, message.Message)
+				done <- true
+			}
+		}
+	}()
+
+	pn.AddListener(listener)
+
+	publish := newPublishBuilder(pn)
+	publish.Message(map[string]string{"text": "hey"})
+	encrypted, err := publish.opts.encryptProcessing(pn.Config.CipherKey)
+	assert.Nil(err)
+
+	var sm subscribeMessage
+	err = json.Unmarshal([]byte(fmt.Sprintf(`{"a":"1","c":"ch","d":%s}`, encrypted)), &sm)
+	assert.Nil(err)
+
+	processSubscribePayload(pn.subscriptionManager, sm)
+	<-done
+}
+
+func TestProcessSubscribePayloadUseNumber(t *testing.T) {
+	assert := assert.New(t)
+	done := make(chan bool)
+	pn := NewPubNub(NewDemoConfig())
+	pn.Config.UseNumber = true
+	listener := NewListener()
+
+	go func() {
+		for {
+			select {
+			case _ = <-listener.Status:
+				assert.Fail("No status expected")
+				done <- true
+			case message := <-listener.Message:
+				n, err := message.Message.(map[string]interface{})["id"].(json.Number).Int64()
+				assert.Nil(err)
+				assert.Equal(int64(1234567890123456789), n)
+				assert.Equal(int64(15078947309567840), message.Timetoken)
+				done <- true
+			}
+		}
+	}()
+
+	pn.AddListener(listener)
+
+	var envelope subscribeEnvelope
+	err := unmarshalJSON([]byte(`{"t":{"t":"15078947309567840","r":4},"m":[{"a":"1","c":"ch","i":"publisher","d":{"id":1234567890123456789},"p":{"t":"15078947309567840"}}]}`), &envelope, pn.Config.UseNumber)
+	assert.Nil(err)
+	assert.Equal(int8(4), envelope.Metadata.Region)
+
+	processSubscribePayload(pn.subscriptionManager, envelope.Messages[0])
+	<-done
+}
+
+// newReconnectTransport returns a transport serving a handshake and one message, which drops the connection on the
+// next subscribe and sends the query of the subscribe requests sent after the reconnection to reconnectURL.
+func newReconnectTransport(reconnectURL chan string) *testTransport {
+	var mutex sync.Mutex
+	dropped := false
+	timeCalls := 0
+
+	return newTestTransport(func(req *http.Request) (*http.Response, error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		body := `{"status":200,"message":"OK","service":"Presence"}`
+		switch {
+		case strings.Contains(req.URL.Opaque, "/time/0"):
+			timeCalls++
+			if !dropped || timeCalls == 1 {
+				return nil, errors.New("connection refused")
+			}
+			body = `[15000000000000000]`
+		case strings.Contains(req.URL.Opaque, "/v2/subscribe/"):
+			switch tt := req.URL.Query().Get("tt"); {
+			case tt == "":
+				body = `{"t":{"t":"14000000000000000","r":12},"m":[]}`
+			case tt == "14000000000000000":
+				body = `{"t":{"t":"15000000000000000","r":12},"m":[{"a":"1","c":"ch","i":"publisher","d":"hey","p":{"t":"15000000000000000","r":12}}]}`
+			case !dropped:
+				dropped = true
+				return nil, errors.New("connection reset by peer")
+			default:
+				reconnectURL <- req.URL.RawQuery
+				return nil, errors.New("connection reset by peer")
+			}
+		}
+
+		return stubResponse(req, 200, body), nil
+	})
+}
+
+func TestSubscribeReconnectResumesFromLastTimetoken(t *testing.T) {
+	assert := assert.New(t)
+	reconnectURL := make(chan string, 10)
+	transport := newReconnectTransport(reconnectURL)
+	config := NewDemoConfig()
+	config.PNReconnectionPolicy = PNLinearPolicy
+	config.MaximumReconnectionRetries = -1
+	config.SetReconnectionBackoff(10*time.Millisecond, 10*time.Millisecond, 0)
+	pn := NewPubNub(config)
+	pn.SetClient(&http.Client{Transport: transport})
+	pn.SetSubscribeClient(&http.Client{Transport: transport})
+	defer pn.Destroy()
+
+	listener := NewListener()
+	reconnected := make(chan *PNStatus, 1)
+	go func() {
+		for {
+			select {
+			case status := <-listener.Status:
+				if status.Category == PNReconnectedCategory {
+					reconnected <- status
+				}
+			case <-listener.Message:
+			case <-listener.Presence:
+			}
+		}
+	}()
+	pn.AddListener(listener)
+
+	pn.Subscribe().Channels([]string{"ch"}).Execute()
+
+	select {
+	case status := <-reconnected:
+		assert.Equal(int64(15000000000000000), status.CurrentTimetoken)
+	case <-time.After(5 * time.Second):
+		assert.Fail("PNReconnectedCategory not announced")
+	}
+
+	select {
+	case query := <-reconnectURL:
+		assert.Contains(query, "tt=15000000000000000")
+		assert.Contains(query, "tr=12")
+	case <-time.After(5 * time.Second):
+		assert.Fail("Subscribe not resumed")
+	}
+}
+
+func TestSubscribeAccessDeniedStatus(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+	transport := newStubTransport(403, `{"message":"Forbidden","payload":{"channels":["ch"]},"error":true,"service":"Access Manager","status":403}`)
+	pn.SetClient(&http.Client{Transport: transport})
+	pn.SetSubscribeClient(&http.Client{Transport: transport})
+	defer pn.Destroy()
+
+	listener := NewListener()
+	denied := make(chan *PNStatus, 1)
+	go func() {
+		for {
+			select {
+			case status := <-listener.Status:
+				if status.Category == PNAccessDeniedCategory {
+					denied <- status
+				}
+			case <-listener.Message:
+			case <-listener.Presence:
+			}
+		}
+	}()
+	pn.AddListener(listener)
+
+	pn.Subscribe().Channels([]string{"ch"}).ChannelGroups([]string{"cg"}).Execute()
+
+	select {
+	case status := <-denied:
+		assert.True(status.Error)
+		assert.Equal(PNSubscribeOperation, status.Operation)
+		assert.Equal([]string{"ch"}, status.AffectedChannels)
+		assert.Equal([]string{"cg"}, status.AffectedChannelGroups)
+	case <-time.After(5 * time.Second):
+		assert.Fail("PNAccessDeniedCategory not announced")
+	}
+}
+
+func TestProcessSubscribePayloadPresenceInterval(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+	listener := NewListener()
+	pn.AddListener(listener)
+
+	var envelope subscribeEnvelope
+	assert.Nil(json.Unmarshal([]byte(`{"t":{"t":"15000000000000000","r":12},"m":[{"a":"1","c":"ch-pnpres","d":{"action":"interval","timestamp":1568805412,"occupancy":3,"join":["uuid1","uuid2"],"leave":["uuid3"],"timeout":[]},"p":{"t":"15000000000000000","r":12}},{"a":"1","c":"ch-pnpres","d":{"action":"interval","timestamp":1568805422,"occupancy":300,"here_now_refresh":true},"p":{"t":"15000000000000001","r":12}}]}`), &envelope))
+
+	go processSubscribePayload(pn.subscriptionManager, envelope.Messages[0])
+	select {
+	case presence := <-listener.Presence:
+		assert.Equal("interval", presence.Event)
+		assert.Equal(3, presence.Occupancy)
+		assert.Equal([]string{"uuid1", "uuid2"}, presence.Join)
+		assert.Equal([]string{"uuid3"}, presence.Leave)
+		assert.Equal([]string{}, presence.Timeout)
+		assert.False(presence.HereNowRefresh)
+	case <-time.After(5 * time.Second):
+		assert.Fail("presence not announced")
+	}
+
+	go processSubscribePayload(pn.subscriptionManager, envelope.Messages[1])
+	select {
+	case presence := <-listener.Presence:
+		assert.Equal(300, presence.Occupancy)
+		assert.True(presence.HereNowRefresh)
+		assert.Nil(presence.Join)
+		assert.Nil(presence.Leave)
+		assert.Nil(presence.Timeout)
+	case <-time.After(5 * time.Second):
+		assert.Fail("presence not announced")
+	}
+}
+
+// subscribeTimetokens returns the tt of the subscribe requests sent through transport.
+func subscribeTimetokens(transport *testTransport) []string {
+	timetokens := []string{}
+	for _, req := range transport.sent("/v2/subscribe/") {
+		timetokens = append(timetokens, req.URL.Query().Get("tt"))
+	}
+	return timetokens
+}
+
+// newSplitSubscribeTransport returns a transport serving a message on every channel of the first subscribe after
+// the handshake, the subscribe requests of the forbidden channel after the handshake get a 403.
+func newSplitSubscribeTransport(forbidden string) *testTransport {
+	return newTestTransport(func(req *http.Request) (*http.Response, error) {
+		body := `{"status":200,"message":"OK","service":"Presence"}`
+		if strings.Contains(req.URL.Opaque, "/v2/subscribe/") {
+			switch req.URL.Query().Get("tt") {
+			case "":
+				body = `{"t":{"t":"1","r":1},"m":[]}`
+			case "1":
+				channels := strings.Split(strings.Split(req.URL.Opaque, "/")[6], ",")
+				if forbidden != "" && strings.Contains(req.URL.Opaque, forbidden) {
+					return stubResponse(req, 403, `{"status":403,"message":"Forbidden","error":true}`), nil
+				}
+				messages := make([]string, len(channels))
+				for i, ch := range channels {
+					messages[i] = fmt.Sprintf(`{"a":"1","c":"%s","d":"hey","p":{"t":"2","r":1}}`, ch)
+				}
+				body = fmt.Sprintf(`{"t":{"t":"2","r":1},"m":[%s]}`, strings.Join(messages, ","))
+			default:
+				select {
+				case <-req.Context().Done():
+					return nil, req.Context().Err()
+				case <-time.After(10 * time.Second):
+					return nil, errors.New("timeout")
+				}
+			}
+		}
+
+		return stubResponse(req, 200, body), nil
+	})
+}
+
+func TestSplitSubscribeChannels(t *testing.T) {
+	assert := assert.New(t)
+
+	chunks := splitSubscribeChannels([]string{"a", "b"}, []string{"cg"}, 100)
+	assert.Equal([]subscribeChunk{subscribeChunk{channels: []string{"a", "b"}, groups: []string{"cg"}}}, chunks)
+
+	chunks = splitSubscribeChannels([]string{"a", "b", "c d"}, []string{"cg"}, 6)
+	assert.Equal([]subscribeChunk{
+		subscribeChunk{channels: []string{"a", "b"}},
+		subscribeChunk{channels: []string{"c d"}},
+		subscribeChunk{groups: []string{"cg"}},
+	}, chunks)
+}
+
+func TestSubscribeSplitChannels(t *testing.T) {
+	assert := assert.New(t)
+	transport := newSplitSubscribeTransport("")
+	pn := NewPubNub(NewDemoConfig())
+	pn.SetClient(&http.Client{Transport: transport})
+	pn.SetSubscribeClient(&http.Client{Transport: transport})
+	defer pn.Destroy()
+
+	channels := make([]string, 200)
+	for i := range channels {
+		channels[i] = fmt.Sprintf("channel-%03d-%s", i, strings.Repeat("x", 40))
+	}
+
+	listener := NewListener()
+	split := make(chan *PNStatus, 1)
+	go func() {
+		for status := range listener.Status {
+			if status.Category == PNSubscribeSplitCategory {
+				split <- status
+			}
+		}
+	}()
+	pn.AddListener(listener)
+
+	pn.Subscribe().Channels(channels).Execute()
+
+	select {
+	case status := <-split:
+		assert.Equal(200, len(status.AffectedChannels))
+	case <-time.After(5 * time.Second):
+		assert.Fail("PNSubscribeSplitCategory not announced")
+	}
+
+	received := map[string]bool{}
+	for len(received) < len(channels) {
+		select {
+		case message := <-listener.Message:
+			received[message.Channel] = true
+		case <-time.After(5 * time.Second):
+			assert.Fail(fmt.Sprintf("%d of %d channels received a message", len(received), len(channels)))
+			return
+		}
+	}
+	for _, ch := range channels {
+		assert.True(received[ch], ch)
+	}
+
+	handshakes := 0
+	for _, tt := range subscribeTimetokens(transport) {
+		if tt == "" {
+			handshakes++
+		}
+	}
+	assert.Equal(2, handshakes)
+}
+
+func TestSubscribeSplitChannelsChunkForbidden(t *testing.T) {
+	assert := assert.New(t)
+	channels := make([]string, 200)
+	for i := range channels {
+		channels[i] = fmt.Sprintf("channel-%03d-%s", i, strings.Repeat("x", 40))
+	}
+	// the last channel is in the second chunk, the main loop only subscribes to the first one
+	transport := newSplitSubscribeTransport(channels[199])
+	pn := NewPubNub(NewDemoConfig())
+	pn.SetClient(&http.Client{Transport: transport})
+	pn.SetSubscribeClient(&http.Client{Transport: transport})
+	defer pn.Destroy()
+
+	listener := NewListener()
+	denied := make(chan *PNStatus, 1)
+	go func() {
+		for {
+			select {
+			case status := <-listener.Status:
+				if status.Category == PNAccessDeniedCategory {
+					denied <- status
+				}
+			case <-listener.Message:
+			}
+		}
+	}()
+	pn.AddListener(listener)
+
+	pn.Subscribe().Channels(channels).Execute()
+
+	select {
+	case status := <-denied:
+		assert.Contains(status.AffectedChannels, channels[199])
+		assert.NotContains(status.AffectedChannels, channels[0])
+	case <-time.After(5 * time.Second):
+		assert.Fail("PNAccessDeniedCategory not announced")
+		return
+	}
+
+	for i := 0; i < 50 && len(pn.GetSubscribedChannels()) > 0; i++ {
+		time.Sleep(20 * time.Millisecond)
+	}
+	assert.Empty(pn.GetSubscribedChannels())
+}
+
+// newLeaveRecordingTransport returns a split subscribe transport which sends the URL of the leave requests to leave.
+func newLeaveRecordingTransport(leave chan string) *testTransport {
+	split := newSplitSubscribeTransport("")
+
+	return newTestTransport(func(req *http.Request) (*http.Response, error) {
+		if strings.HasSuffix(req.URL.Opaque, "/leave") || strings.HasSuffix(req.URL.Path, "/leave") {
+			select {
+			case leave <- req.URL.String():
+			default:
+			}
+		}
+
+		return split.RoundTrip(req)
+	})
+}
+
+func TestSubscribeWithContextCancel(t *testing.T) {
+	assert := assert.New(t)
+	leave := make(chan string, 1)
+	transport := newLeaveRecordingTransport(leave)
+	pn := NewPubNub(NewDemoConfig())
+	pn.SetClient(&http.Client{Transport: transport})
+	pn.SetSubscribeClient(&http.Client{Transport: transport})
+	defer pn.Destroy()
+
+	listener := NewListener()
+	disconnected := make(chan *PNStatus, 1)
+	go func() {
+		for {
+			select {
+			case status := <-listener.Status:
+				// the loop also announces a disconnect without an operation when no channels are left
+				if status.Category == PNDisconnectedCategory && status.Operation == PNSubscribeOperation {
+					disconnected <- status
+				}
+			case <-listener.Message:
+			case <-listener.Presence:
+			}
+		}
+	}()
+	pn.AddListener(listener)
+
+	ctx, cancel := contextWithCancel(backgroundContext)
+	pn.SubscribeWithContext(ctx).Channels([]string{"ch"}).Execute()
+	assert.Equal([]string{"ch"}, pn.GetSubscribedChannels())
+
+	cancel()
+
+	select {
+	case status := <-disconnected:
+		assert.Equal([]string{"ch"}, status.AffectedChannels)
+	case <-time.After(2 * time.Second):
+		assert.Fail("PNDisconnectedCategory not announced")
+	}
+
+	select {
+	case leave := <-leave:
+		assert.Contains(leave, "/channel/ch/leave")
+	case <-time.After(2 * time.Second):
+		assert.Fail("leave not sent")
+	}
+	assert.Empty(pn.GetSubscribedChannels())
+}
+
+func TestSubscribeWithContextCancelAfterDestroy(t *testing.T) {
+	assert := assert.New(t)
+	leave := make(chan string, 1)
+	transport := newLeaveRecordingTransport(leave)
+	pn := NewPubNub(NewDemoConfig())
+	pn.SetClient(&http.Client{Transport: transport})
+	pn.SetSubscribeClient(&http.Client{Transport: transport})
+
+	ctx, cancel := contextWithCancel(backgroundContext)
+	pn.SubscribeWithContext(ctx).Channels([]string{"ch"}).Execute()
+	pn.Destroy()
+
+	cancel()
+
+	select {
+	case leave := <-leave:
+		assert.Fail("leave sent after Destroy", leave)
+	case <-time.After(500 * time.Millisecond):
+	}
+}
+
+// newOutOfOrderTransport returns a transport serving the messages of the first subscribe after the handshake out of
+// timetoken order.
+func newOutOfOrderTransport() *testTransport {
+	return newTestTransport(func(req *http.Request) (*http.Response, error) {
+		body := `{"status":200,"message":"OK","service":"Presence"}`
+		if strings.Contains(req.URL.Opaque, "/v2/subscribe/") {
+			switch req.URL.Query().Get("tt") {
+			case "":
+				body = `{"t":{"t":"1","r":1},"m":[]}`
+			case "1":
+				body = `{"t":{"t":"40","r":1},"m":[` +
+					`{"a":"1","c":"ch","d":"third","p":{"t":"30","r":1}},` +
+					`{"a":"1","c":"other","d":"other","p":{"t":"25","r":1}},` +
+					`{"a":"1","c":"ch","d":"first","p":{"t":"10","r":1}},` +
+					`{"a":"1","c":"ch","d":"second","p":{"t":"20","r":1}}]}`
+			default:
+				<-req.Context().Done()
+				return nil, req.Context().Err()
+			}
+		}
+
+		return stubResponse(req, 200, body), nil
+	})
+}
+
+func TestSubscribeDispatchOrderPerChannel(t *testing.T) {
+	assert := assert.New(t)
+	transport := newOutOfOrderTransport()
+	pn := NewPubNub(NewDemoConfig())
+	pn.SetClient(&http.Client{Transport: transport})
+	pn.SetSubscribeClient(&http.Client{Transport: transport})
+	defer pn.Destroy()
+
+	listener := NewListener()
+	go func() {
+		for range listener.Status {
+		}
+	}()
+	pn.AddListener(listener)
+
+	pn.Subscribe().Channels([]string{"ch", "other"}).Execute()
+
+	received := map[string][]interface{}{}
+	for i := 0; i < 4; i++ {
+		select {
+		case message := <-listener.Message:
+			received[message.Channel] = append(received[message.Channel], message.Message)
+		case <-time.After(5 * time.Second):
+			assert.Fail("messages not received")
+			return
+		}
+	}
+	assert.Equal([]interface{}{"first", "second", "third"}, received["ch"])
+	assert.Equal([]interface{}{"other"}, received["other"])
+}
+
+func TestSubscribeMessageFilter(t *testing.T) {
+	assert := assert.New(t)
+	transport := newOutOfOrderTransport()
+	pn := NewPubNub(NewDemoConfig())
+	pn.SetClient(&http.Client{Transport: transport})
+	pn.SetSubscribeClient(&http.Client{Transport: transport})
+	defer pn.Destroy()
+
+	pn.AddMessageFilter(func(message PNMessage) bool {
+		return message.Channel != "other"
+	})
+	pn.AddMessageFilter(func(message PNMessage) bool {
+		return message.Message != "second"
+	})
+
+	listener := NewListener()
+	go func() {
+		for range listener.Status {
+		}
+	}()
+	pn.AddListener(listener)
+
+	pn.Subscribe().Channels([]string{"ch", "other"}).Execute()
+
+	received := []interface{}{}
+	for {
+		select {
+		case message := <-listener.Message:
+			assert.Equal("ch", message.Channel)
+			received = append(received, message.Message)
+			continue
+		case <-time.After(500 * time.Millisecond):
+		}
+		break
+	}
+	assert.ElementsMatch([]interface{}{"first", "third"}, received)
+}
+
+// newFailingSubscribeTransport returns a transport serving the handshake which times out all the following
+// subscribe requests.
+func newFailingSubscribeTransport() *testTransport {
+	return newTestTransport(func(req *http.Request) (*http.Response, error) {
+		body := `{"status":200,"message":"OK","service":"Presence"}`
+		if strings.Contains(req.URL.Opaque, "/v2/subscribe/") {
+			if req.URL.Query().Get("tt") != "" {
+				return nil, errors.New("i/o timeout")
+			}
+			body = `{"t":{"t":"14000000000000000","r":12},"m":[]}`
+		}
+
+		return stubResponse(req, 200, body), nil
+	})
+}
+
+func TestSubscribeMaxReconnectionRetries(t *testing.T) {
+	assert := assert.New(t)
+	transport := newFailingSubscribeTransport()
+	config := NewDemoConfig()
+	config.SubscribeMaxReconnectionRetries = 3
+	pn := NewPubNub(config)
+	pn.SetClient(&http.Client{Transport: transport})
+	pn.SetSubscribeClient(&http.Client{Transport: transport})
+	defer pn.Destroy()
+
+	listener := NewListener()
+	exhausted := make(chan *PNStatus, 1)
+	go func() {
+		for status := range listener.Status {
+			if status.Category == PNReconnectionAttemptsExhausted {
+				exhausted <- status
+			}
+		}
+	}()
+	pn.AddListener(listener)
+
+	pn.Subscribe().Channels([]string{"ch"}).Execute()
+
+	select {
+	case status := <-exhausted:
+		assert.Equal(PNSubscribeOperation, status.Operation)
+		assert.True(status.Error)
+		assert.Equal([]string{"ch"}, status.AffectedChannels)
+	case <-time.After(5 * time.Second):
+		assert.Fail("exhausted status not received")
+		return
+	}
+
+	time.Sleep(200 * time.Millisecond)
+	assert.Equal(4, len(transport.sent("/v2/subscribe/")))
+	assert.Equal([]string{"ch"}, pn.GetSubscribedChannels())
+	assert.Equal(1, len(pn.GetListeners()))
+}
+
+// newDisconnectTransport returns a transport serving one message, which blocks the first subscribe from its
+// timetoken until it is cancelled and serves a second message to the next one.
+func newDisconnectTransport() *testTransport {
+	var transport *testTransport
+	transport = newTestTransport(func(req *http.Request) (*http.Response, error) {
+		body := `{"status":200,"message":"OK","service":"Presence"}`
+		if strings.Contains(req.URL.Opaque, "/v2/subscribe/") {
+			tt := req.URL.Query().Get("tt")
+			// the request is already recorded, the timetoken is resumed when it was sent before
+			sent := 0
+			for _, previous := range subscribeTimetokens(transport) {
+				if previous == tt {
+					sent++
+				}
+			}
+
+			switch {
+			case tt == "":
+				body = `{"t":{"t":"100","r":1},"m":[]}`
+			case tt == "100":
+				body = `{"t":{"t":"200","r":1},"m":[{"a":"1","c":"ch","d":"one","p":{"t":"200","r":1}}]}`
+			case tt == "200" && sent > 1:
+				body = `{"t":{"t":"300","r":1},"m":[{"a":"1","c":"ch","d":"two","p":{"t":"300","r":1}}]}`
+			default:
+				<-req.Context().Done()
+				return nil, req.Context().Err()
+			}
+		}
+
+		return stubResponse(req, 200, body), nil
+	})
+
+	return transport
+}
+
+func TestDisconnectReconnect(t *testing.T) {
+	assert := assert.New(t)
+	transport := newDisconnectTransport()
+	pn := NewPubNub(NewDemoConfig())
+	pn.SetClient(&http.Client{Transport: transport})
+	pn.SetSubscribeClient(&http.Client{Transport: transport})
+	defer pn.Destroy()
+
+	listener := NewListener()
+	go func() {
+		for range listener.Status {
+		}
+	}()
+	pn.AddListener(listener)
+
+	pn.Subscribe().Channels([]string{"ch"}).Execute()
+
+	select {
+	case message := <-listener.Message:
+		assert.Equal("one", message.Message)
+	case <-time.After(5 * time.Second):
+		assert.Fail("message not received")
+		return
+	}
+	for i := 0; i < 100 && len(subscribeTimetokens(transport)) < 3; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	pn.Disconnect()
+
+	select {
+	case message := <-listener.Message:
+		assert.Fail("message received after Disconnect", message.Message)
+	case <-time.After(300 * time.Millisecond):
+	}
+	assert.Equal([]string{"", "100", "200"}, subscribeTimetokens(transport))
+	assert.Equal([]string{"ch"}, pn.GetSubscribedChannels())
+
+	pn.Reconnect()
+
+	select {
+	case message := <-listener.Message:
+		assert.Equal("two", message.Message)
+	case <-time.After(5 * time.Second):
+		assert.Fail("message not received after Reconnect")
+		return
+	}
+	assert.Equal("200", subscribeTimetokens(transport)[3])
+}
+
+func TestSubscribeMessagesByTimetoken(t *testing.T) {
+	assert := assert.New(t)
+
+	message := func(channel, tt string) subscribeMessage {
+		return subscribeMessage{Channel: channel, PublishMetaData: publishMetadata{PublishTimetoken: tt}}
+	}
+	messages := []subscribeMessage{message("a", "3"), message("b", "2"), message("a", "1"), message("b", "2"), message("a", "x")}
+	sort.Stable(subscribeMessagesByTimetoken(messages))
+
+	assert.Equal([]subscribeMessage{message("a", "x"), message("a", "1"), message("b", "2"), message("b", "2"), message("a", "3")}, messages)
+}
@@ -171,6 +171,15 @@ func telemetryEndpointNameForOperation(t OperationType) string {
 	case PNRemoveGroupOperation:
 		endpoint = "cg"
 		break
+	case PNPushNotificationsEnabledChannelsOperation:
+		fallthrough
+	case PNAddPushNotificationsOnChannelsOperation:
+		fallthrough
+	case PNRemovePushNotificationsFromChannelsOperation:
+		fallthrough
+	case PNRemoveAllPushNotificationsOperation:
+		endpoint = "push"
+		break
 	case PNAccessManagerRevoke:
 		fallthrough
 	case PNAccessManagerGrant:
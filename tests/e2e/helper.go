@@ -41,15 +41,11 @@ func init() {
 }
 
 func configCopy() *pubnub.Config {
-	cfg := new(pubnub.Config)
-	*cfg = *config
-	return cfg
+	return config.Copy()
 }
 
 func pamConfigCopy() *pubnub.Config {
-	config := new(pubnub.Config)
-	*config = *pamConfig
-	return config
+	return pamConfig.Copy()
 }
 
 func randomized(prefix string) string {
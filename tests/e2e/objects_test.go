@@ -64,30 +64,16 @@ func RunGrant(pn *pubnub.PubNub, users, spaces []string, read, write, manage, de
 	}
 
 	res, _, err := pn.GrantToken().TTL(3).
-		//Channels(ch).
-		//ChannelGroups(cg).
 		Users(u).
 		Spaces(s).
+		UsersPattern(up).
+		SpacesPattern(sp).
 		Execute()
 	fmt.Println(res)
 	fmt.Println(err)
 
-	token2 := ""
-
-	if len(up) > 0 || len(sp) > 0 {
-		res2, _, err2 := pn.GrantToken().TTL(3).
-			//Channels(ch).
-			//ChannelGroups(cg).
-			UsersPattern(up).
-			SpacesPattern(sp).
-			Execute()
-		fmt.Println(res2)
-		fmt.Println(err2)
-		token2 = res2.Data.Token
-	}
-
 	if res != nil {
-		return []string{res.Data.Token, token2}
+		return []string{res.Data.Token}
 	}
 	return []string{}
 }
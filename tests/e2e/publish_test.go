@@ -190,6 +190,32 @@ func TestPublishServerError(t *testing.T) {
 	assert.Contains(err.Error(), "403")
 }
 
+func TestPublishPostLargeMessageStubbed(t *testing.T) {
+	assert := assert.New(t)
+
+	interceptor := stubs.NewInterceptor()
+	interceptor.AddStub(&stubs.Stub{
+		Method:             "POST",
+		Path:               fmt.Sprintf("/publish/%s/%s/0/ch/0", config.PublishKey, config.SubscribeKey),
+		Query:              "",
+		ResponseBody:       respSuccess,
+		IgnoreQueryKeys:    []string{"uuid", "pnsdk", "seqn"},
+		ResponseStatusCode: 200,
+	})
+
+	pn := pubnub.NewPubNub(configCopy())
+	pn.SetClient(interceptor.GetClient())
+
+	res, _, err := pn.Publish().
+		Channel("ch").
+		Message(strings.Repeat("a", 10*1024)).
+		UsePost(true).
+		Execute()
+
+	assert.Nil(err)
+	assert.Equal(int64(14981595400555832), res.Timestamp)
+}
+
 func TestPublishNetworkError(t *testing.T) {
 	assert := assert.New(t)
 
@@ -6,6 +6,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"time"
 
 	"github.com/pubnub/go/pnerr"
 )
@@ -52,6 +53,18 @@ func (b *timeBuilder) QueryParam(queryParam map[string]string) *timeBuilder {
 	return b
 }
 
+// Timeout sets the timeout for the Time request.
+func (b *timeBuilder) Timeout(timeout time.Duration) *timeBuilder {
+	b.opts.Timeout = timeout
+
+	return b
+}
+
+// SignedURL returns the URL of the Time request without executing it.
+func (b *timeBuilder) SignedURL() (string, error) {
+	return signedURL(b.opts)
+}
+
 // Execute runs the Time request and fetches the time from the server.
 func (b *timeBuilder) Execute() (*TimeResponse, StatusResponse, error) {
 	rawJSON, status, err := executeRequest(b.opts)
@@ -65,9 +78,17 @@ func (b *timeBuilder) Execute() (*TimeResponse, StatusResponse, error) {
 type timeOpts struct {
 	pubnub     *PubNub
 	QueryParam map[string]string
+	Timeout    time.Duration
 	Transport  http.RoundTripper
 
-	ctx Context
+	ctx      Context
+	internal bool
+}
+
+// isInternal is true when the time request is sent by the reconnection manager polling, it then keeps the Config.UUID
+// when DisableDefaultQueryParams is set.
+func (o *timeOpts) isInternal() bool {
+	return o.internal
 }
 
 func (o *timeOpts) config() Config {
@@ -91,7 +112,7 @@ func (o *timeOpts) buildPath() (string, error) {
 }
 
 func (o *timeOpts) buildQuery() (*url.Values, error) {
-	q := defaultQuery(o.pubnub.Config.UUID, o.pubnub.telemetryManager)
+	q := defaultQuery(o.pubnub.Config, o.pubnub.telemetryManager)
 	SetQueryParam(q, o.QueryParam)
 	return q, nil
 }
@@ -128,6 +149,18 @@ func (o *timeOpts) telemetryManager() *TelemetryManager {
 	return o.pubnub.telemetryManager
 }
 
+func (o *timeOpts) tokenManager() *TokenManager {
+	return o.pubnub.tokenManager
+}
+
+func (o *timeOpts) timeout() time.Duration {
+	return o.Timeout
+}
+
+func (o *timeOpts) transport() http.RoundTripper {
+	return o.Transport
+}
+
 // TimeResponse is the response when Time call is executed.
 type TimeResponse struct {
 	Timetoken int64
@@ -2,6 +2,7 @@ package pubnub
 
 import (
 	"net/url"
+	"regexp"
 	"sync"
 )
 
@@ -10,6 +11,9 @@ type TokenManager struct {
 	sync.RWMutex
 	Tokens              GrantResourcesWithPermissions
 	pubnub              *PubNub
+	authToken           string
+	latestToken         string
+	latestTokenTime     int64
 	lastUpdateTimestamp int
 	lastQueryTimestamp  int
 }
@@ -38,7 +42,6 @@ func newTokenManager(pubnub *PubNub, ctx Context) *TokenManager {
 // SetAuthParan sets the auth param in the requests by retrieving the corresponding tokens from the token manager
 func (m *TokenManager) SetAuthParan(q *url.Values, resourceID string, resourceType PNResourceType) {
 	authParam := "auth"
-	m.RLock()
 	token := m.GetToken(resourceID, resourceType)
 	if token != "" {
 		switch resourceType {
@@ -52,7 +55,39 @@ func (m *TokenManager) SetAuthParan(q *url.Values, resourceID string, resourceTy
 			q.Set(authParam, token)
 		}
 	}
-	m.RUnlock()
+}
+
+// setAuthParamForChannels sets the auth param using the token of the first channel, or else channel group, with a matching token
+func (m *TokenManager) setAuthParamForChannels(q *url.Values, channels, groups []string) {
+	for _, ch := range channels {
+		if token := m.GetToken(ch, PNChannels); token != "" {
+			q.Set("auth", token)
+			return
+		}
+	}
+	for _, cg := range groups {
+		if token := m.GetToken(cg, PNGroups); token != "" {
+			q.Set("auth", token)
+			return
+		}
+	}
+}
+
+// SetAuthToken sets the single active token used as the auth param for authed operations
+func (m *TokenManager) SetAuthToken(token string) {
+	m.Lock()
+	m.authToken = token
+	m.Unlock()
+}
+
+// GetAuthToken retrieves the single active token, if not set the newest stored token is returned
+func (m *TokenManager) GetAuthToken() string {
+	m.RLock()
+	defer m.RUnlock()
+	if m.authToken != "" {
+		return m.authToken
+	}
+	return m.latestToken
 }
 
 // GetAllTokens retrieves all the tokens from the token manager
@@ -114,45 +149,63 @@ func (m *TokenManager) GetTokensByResource(resourceType PNResourceType) GrantRes
 	return g
 }
 
-// GetToken first match for direct ids, if no match found use the first token from pattern match ignoring the regex (by design).
+// GetToken first match for direct ids, if no match found use the newest token from the patterns matching the id.
 func (m *TokenManager) GetToken(resourceID string, resourceType PNResourceType) string {
 	m.RLock()
+	defer m.RUnlock()
+
+	token := ""
+	timestamp := int64(-1)
 	switch resourceType {
 	case PNChannels:
 		if d, ok := m.Tokens.Channels[resourceID]; ok {
 			return d.Token
 		}
 
-		for _, v := range m.Tokens.ChannelsPattern {
-			return v.Token
+		for k, v := range m.Tokens.ChannelsPattern {
+			if v.Timestamp > timestamp && matchTokenPattern(k, resourceID) {
+				token, timestamp = v.Token, v.Timestamp
+			}
 		}
 	case PNGroups:
 		if d, ok := m.Tokens.Groups[resourceID]; ok {
 			return d.Token
 		}
 
-		for _, v := range m.Tokens.GroupsPattern {
-			return v.Token
+		for k, v := range m.Tokens.GroupsPattern {
+			if v.Timestamp > timestamp && matchTokenPattern(k, resourceID) {
+				token, timestamp = v.Token, v.Timestamp
+			}
 		}
 	case PNUsers:
 		if d, ok := m.Tokens.Users[resourceID]; ok {
 			return d.Token
 		}
 
-		for _, v := range m.Tokens.UsersPattern {
-			return v.Token
+		for k, v := range m.Tokens.UsersPattern {
+			if v.Timestamp > timestamp && matchTokenPattern(k, resourceID) {
+				token, timestamp = v.Token, v.Timestamp
+			}
 		}
 	case PNSpaces:
 		if d, ok := m.Tokens.Spaces[resourceID]; ok {
 			return d.Token
 		}
 
-		for _, v := range m.Tokens.SpacesPattern {
-			return v.Token
+		for k, v := range m.Tokens.SpacesPattern {
+			if v.Timestamp > timestamp && matchTokenPattern(k, resourceID) {
+				token, timestamp = v.Token, v.Timestamp
+			}
 		}
 	}
-	m.RUnlock()
-	return ""
+	return token
+}
+
+// matchTokenPattern reports whether the resourceID matches the pattern granted in the token.
+// Patterns which fail to compile never match.
+func matchTokenPattern(pattern, resourceID string) bool {
+	matched, err := regexp.MatchString(pattern, resourceID)
+	return err == nil && matched
 }
 
 func mergeTokensByResource(m interface{}, resource interface{}, resourceType PNResourceType) {
@@ -218,6 +271,11 @@ func (m *TokenManager) StoreToken(token string) {
 			mergeTokensByResource(m.Tokens.GroupsPattern, pat.Groups, PNGroups)
 			mergeTokensByResource(m.Tokens.SpacesPattern, pat.Spaces, PNSpaces)
 
+			if cborObject.Timestamp >= m.latestTokenTime {
+				m.latestToken = token
+				m.latestTokenTime = cborObject.Timestamp
+			}
+
 			m.pubnub.Config.Log.Println("Tokens: ", m.Tokens)
 
 			m.Unlock()
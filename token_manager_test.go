@@ -1,8 +1,10 @@
 package pubnub
 
 import (
+	"encoding/base64"
 	"testing"
 
+	cbor "github.com/brianolson/cbor_go"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -94,3 +96,79 @@ func TestTokenManager(t *testing.T) {
 	assert.Equal(t4, g9)
 
 }
+
+func TestTokenManagerSetToken(t *testing.T) {
+	assert := assert.New(t)
+
+	pn := NewPubNub(NewDemoConfig())
+	pn.Config.SecretKey = ""
+
+	token := "p0F2AkF0Gl2AX-JDdHRsCkNyZXOkRGNoYW6gQ2dycKBDdXNyoWl1LTMzNTIwNTUPQ3NwY6Fpcy0xNzA3OTgzGB9DcGF0pERjaGFuoENncnCgQ3VzcqBDc3BjoERtZXRhoENzaWdYINqGs2EyEMHPZrp6znVqTBzXNBAD_31hUH3JuUSWE2A6"
+	pn.SetToken(token)
+	assert.Equal(token, pn.GetAuthToken())
+	// the token is also stored by the resources it grants
+	assert.Equal(token, pn.GetToken("u-3352055", PNUsers))
+
+	o := newPublishBuilder(pn)
+	o.Channel("ch")
+	o.Message("hey")
+
+	u, err := buildURL(o.opts)
+	assert.Nil(err)
+	assert.Equal(token, u.Query().Get("auth"))
+
+	pn.Config.AuthKey = "myAuthKey"
+	u, err = buildURL(o.opts)
+	assert.Nil(err)
+	assert.Equal("myAuthKey", u.Query().Get("auth"))
+
+	tm := newTimeBuilder(pn)
+	pn.Config.AuthKey = ""
+	u, err = buildURL(tm.opts)
+	assert.Nil(err)
+	assert.Equal("", u.Query().Get("auth"))
+}
+
+func genTestToken(assert *assert.Assertions, timestamp int64, resources, patterns GrantResources) string {
+	tokenBytes, err := cbor.Dumps(PNGrantTokenDecoded{
+		Resources: resources,
+		Patterns:  patterns,
+		Version:   2,
+		Timestamp: timestamp,
+		TTL:       10,
+	})
+	assert.Nil(err)
+
+	return base64.URLEncoding.EncodeToString(tokenBytes)
+}
+
+func TestTokenManagerMatchResource(t *testing.T) {
+	assert := assert.New(t)
+
+	pn := NewPubNub(NewDemoConfig())
+	pn.Config.SecretKey = ""
+
+	t1 := genTestToken(assert, 1568805412, GrantResources{Channels: map[string]int64{"ch1": 3}}, GrantResources{})
+	t2 := genTestToken(assert, 1568805413, GrantResources{Channels: map[string]int64{"ch2": 3}}, GrantResources{})
+	t3 := genTestToken(assert, 1568805411, GrantResources{}, GrantResources{Channels: map[string]int64{"^pat-.*": 3}})
+	pn.SetTokens([]string{t1, t2, t3})
+
+	for ch, expected := range map[string]string{"ch1": t1, "ch2": t2, "pat-1": t3, "other": t2} {
+		o := newPublishBuilder(pn)
+		o.Channel(ch)
+		o.Message("hey")
+
+		u, err := buildURL(o.opts)
+		assert.Nil(err)
+		assert.Equal(expected, u.Query().Get("auth"), ch)
+	}
+
+	opts := &subscribeOpts{
+		Channels: []string{"other", "ch1"},
+		pubnub:   pn,
+	}
+
+	u, err := buildURL(opts)
+	assert.Nil(err)
+	assert.Equal(t1, u.Query().Get("auth"))
+}
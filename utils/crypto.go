@@ -5,9 +5,11 @@ import (
 	"crypto/aes"
 	"crypto/cipher"
 	"crypto/hmac"
+	"crypto/rand"
 	"crypto/sha256"
 	"encoding/base64"
 	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strconv"
@@ -25,15 +27,41 @@ var valIV = "0123456789012345"
 //
 // returns the base64 encoded encrypted string.
 func EncryptString(cipherKey string, message string) string {
+	// the fixed IV is not generated, the encryption can't fail
+	encrypted, _ := EncryptStringWithIV(cipherKey, message, false)
+
+	return encrypted
+}
+
+// EncryptStringWithIV creates the base64 encoded encrypted string using the
+// cipherKey.
+// It accepts the following parameters:
+// cipherKey: cipher key to use to encrypt.
+// message: to encrypted.
+// useRandomIV: when true a random IV is generated and prepended to the encrypted bytes.
+//
+// returns the base64 encoded encrypted string,
+// error if the random IV can't be generated.
+func EncryptStringWithIV(cipherKey string, message string, useRandomIV bool) (string, error) {
 	block, _ := aesCipher(cipherKey)
 	message = encodeNonASCIIChars(message)
 	value := []byte(message)
 	value = padWithPKCS7(value)
-	blockmode := cipher.NewCBCEncrypter(block, []byte(valIV))
+	iv := []byte(valIV)
+	if useRandomIV {
+		var err error
+		if iv, err = generateIV(); err != nil {
+			return "", err
+		}
+	}
+	blockmode := cipher.NewCBCEncrypter(block, iv)
 	cipherBytes := make([]byte, len(value))
 	blockmode.CryptBlocks(cipherBytes, value)
+	if useRandomIV {
+		cipherBytes = append(iv, cipherBytes...)
+	}
 
-	return base64.StdEncoding.EncodeToString(cipherBytes)
+	return base64.StdEncoding.EncodeToString(cipherBytes), nil
 }
 
 type A struct {
@@ -53,6 +81,20 @@ type B struct {
 // returns the unencoded encrypted string,
 // error if any.
 func DecryptString(cipherKey string, message string) (
+	retVal interface{}, err error) {
+	return DecryptStringWithIV(cipherKey, message, false)
+}
+
+// DecryptStringWithIV decodes encrypted string using the cipherKey
+//
+// It accepts the following parameters:
+// cipherKey: cipher key to use to decrypt.
+// message: to encrypted.
+// useRandomIV: when true the IV is read from the first 16 encrypted bytes.
+//
+// returns the unencoded encrypted string,
+// error if any.
+func DecryptStringWithIV(cipherKey string, message string, useRandomIV bool) (
 	retVal interface{}, err error) {
 	if message == "" {
 		return "**decrypt error***", errors.New("message is empty")
@@ -67,7 +109,14 @@ func DecryptString(cipherKey string, message string) (
 	if decodeErr != nil {
 		return "***decrypt error***", fmt.Errorf("decrypt error on decode: %s", decodeErr)
 	}
-	decrypter := cipher.NewCBCDecrypter(block, []byte(valIV))
+	iv := []byte(valIV)
+	if useRandomIV {
+		if len(value) < aes.BlockSize {
+			return "***decrypt error***", fmt.Errorf("decrypt error: invalid data len %d", len(value))
+		}
+		iv, value = value[:aes.BlockSize], value[aes.BlockSize:]
+	}
+	decrypter := cipher.NewCBCDecrypter(block, iv)
 	//to handle decryption errors
 	defer func() {
 		if r := recover(); r != nil {
@@ -84,6 +133,65 @@ func DecryptString(cipherKey string, message string) (
 	return fmt.Sprintf("%s", string(val)), nil
 }
 
+// DecryptMessage decrypts a received message, used by both subscribe and history.
+//
+// It accepts the following parameters:
+// data: the message, an encrypted string or a map with an encrypted pn_other.
+// cipherKey: cipher key to use to decrypt.
+// useRandomIV: when true the IV is read from the first 16 encrypted bytes.
+// disablePNOtherProcessing: when true maps are returned as is.
+//
+// returns the decrypted and unmarshalled message, the data as is for
+// unencrypted types,
+// error if any.
+func DecryptMessage(data interface{}, cipherKey string, useRandomIV, disablePNOtherProcessing bool) (interface{}, error) {
+	switch v := data.(type) {
+	case map[string]interface{}:
+		if disablePNOtherProcessing {
+			return v, nil
+		}
+		//decrypt pn_other only
+		msg, ok := v["pn_other"].(string)
+		if !ok {
+			return v, nil
+		}
+		decrypted, errDecryption := DecryptStringWithIV(cipherKey, msg, useRandomIV)
+		if errDecryption != nil {
+			return v, errDecryption
+		}
+		var intf interface{}
+		if err := json.Unmarshal([]byte(decrypted.(string)), &intf); err != nil {
+			return intf, err
+		}
+		v["pn_other"] = intf
+
+		return v, nil
+	case string:
+		decrypted, errDecryption := DecryptStringWithIV(cipherKey, v, useRandomIV)
+		if errDecryption != nil {
+			return data, errDecryption
+		}
+		var intf interface{}
+		if err := json.Unmarshal([]byte(decrypted.(string)), &intf); err != nil {
+			return intf, err
+		}
+
+		return intf, nil
+	default:
+		return v, nil
+	}
+}
+
+// generateIV returns a random 16 byte IV.
+func generateIV() ([]byte, error) {
+	iv := make([]byte, aes.BlockSize)
+	if _, err := rand.Read(iv); err != nil {
+		return nil, err
+	}
+
+	return iv, nil
+}
+
 // aesCipher returns the cipher block
 //
 // It accepts the following parameters:
@@ -155,6 +263,14 @@ func GetHmacSha256(secretKey string, input string) string {
 	return signature
 }
 
+// GetHmacSha256Base64 returns the HMAC SHA256 of the input as unpadded base64url,
+// the form of the v2 signatures.
+func GetHmacSha256Base64(secretKey string, input string) string {
+	hmacSha256 := hmac.New(sha256.New, []byte(secretKey))
+	hmacSha256.Write([]byte(input))
+	return base64.RawURLEncoding.EncodeToString(hmacSha256.Sum(nil))
+}
+
 // padWithPKCS7 pads the data as per the PKCS7 standard
 // It accepts the following parameters:
 // data: data to pad as byte array.
@@ -23,6 +23,15 @@ func TestSignSha256(t *testing.T) {
 	assert.Equal("Dq92jnwRTCikdeP2nUs1__gyJthF8NChwbs5aYy2r_I=", res)
 }
 
+func TestSignSha256Base64(t *testing.T) {
+	assert := assert.New(t)
+
+	signInput := "sub-c-7ba2ac4c-4836-11e6-85a4-0619f8945a4f\npub-c-98863562-19a6-4760-bf0b-d537d1f5c582\ngrant\nchannel=asyncio-pam-FI2FCS0A&pnsdk=PubNub-Python-Asyncio%252F4.0.0&r=1&timestamp=1468409553&uuid=a4dbf92e-e5cb-428f-b6e6-35cce03500a2&w=1"
+
+	assert.Equal("Dq92jnwRTCikdeP2nUs1__gyJthF8NChwbs5aYy2r_I", GetHmacSha256Base64("my_key", signInput))
+	assert.Equal("jYmF0Et6vTLLqjd5o9qgGeDSaaIq7BWvjnKW9wLMaMY", GetHmacSha256Base64("secret", "input"))
+}
+
 // func TestSignSha256New2(t *testing.T) {
 // 	assert := assert.New(t)
 // 	v := &url.Values{}
@@ -542,3 +551,60 @@ func CreateLoggerForTests() *log.Logger {
 	}
 	return infoLogger
 }
+
+func TestEncryptStringRandomIV(t *testing.T) {
+	assert := assert.New(t)
+
+	first, err := EncryptStringWithIV("enigma", "yay!", true)
+	assert.Nil(err)
+	second, err := EncryptStringWithIV("enigma", "yay!", true)
+	assert.Nil(err)
+	assert.NotEqual(first, second)
+	assert.NotEqual(EncryptString("enigma", "yay!"), first)
+
+	decrypted, err := DecryptStringWithIV("enigma", first, true)
+	assert.Nil(err)
+	assert.Equal("yay!", decrypted)
+
+	decrypted, err = DecryptStringWithIV("enigma", second, true)
+	assert.Nil(err)
+	assert.Equal("yay!", decrypted)
+}
+
+func TestDecryptStringRandomIVShortData(t *testing.T) {
+	assert := assert.New(t)
+
+	_, err := DecryptStringWithIV("enigma", "q/xJqqN6qbiZMXYmiQC1Fw==", true)
+	assert.NotNil(err)
+
+	_, err = DecryptStringWithIV("enigma", "YWJj", true)
+	assert.Contains(err.Error(), "invalid data len 3")
+}
+
+func TestDecryptMessage(t *testing.T) {
+	assert := assert.New(t)
+
+	fixed := EncryptString("enigma", `{"text":"hey"}`)
+	random, err := EncryptStringWithIV("enigma", `{"text":"hey"}`, true)
+	assert.Nil(err)
+
+	msg, err := DecryptMessage(fixed, "enigma", false, false)
+	assert.Nil(err)
+	assert.Equal(map[string]interface{}{"text": "hey"}, msg)
+
+	msg, err = DecryptMessage(random, "enigma", true, false)
+	assert.Nil(err)
+	assert.Equal(map[string]interface{}{"text": "hey"}, msg)
+
+	msg, err = DecryptMessage(map[string]interface{}{"pn_other": random}, "enigma", true, false)
+	assert.Nil(err)
+	assert.Equal(map[string]interface{}{"pn_other": map[string]interface{}{"text": "hey"}}, msg)
+
+	msg, err = DecryptMessage(map[string]interface{}{"pn_other": random}, "enigma", true, true)
+	assert.Nil(err)
+	assert.Equal(map[string]interface{}{"pn_other": random}, msg)
+
+	msg, err = DecryptMessage(float64(1), "enigma", true, false)
+	assert.Nil(err)
+	assert.Equal(float64(1), msg)
+}
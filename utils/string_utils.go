@@ -10,8 +10,15 @@ import (
 
 	uuid "github.com/google/uuid"
 	pnerr "github.com/pubnub/go/pnerr"
+	"golang.org/x/text/unicode/norm"
 )
 
+// NormalizeChannel returns the channel in the Unicode Normalization Form C,
+// so that the composed and decomposed forms of accented characters are encoded the same.
+func NormalizeChannel(channel string) string {
+	return norm.NFC.String(channel)
+}
+
 // JoinChannels encodes and joins channels
 func JoinChannels(channels []string) []byte {
 	if len(channels) == 0 {
@@ -27,6 +34,18 @@ func JoinChannels(channels []string) []byte {
 	return []byte(strings.Join(encodedChannels, ","))
 }
 
+// DecodeChannel returns the requested channel matching a channel name of a response,
+// the name is matched as is or in the encoded form sent by JoinChannels.
+func DecodeChannel(channel string, channels []string) string {
+	for _, value := range channels {
+		if channel == value || channel == URLEncode(value) {
+			return value
+		}
+	}
+
+	return channel
+}
+
 // encodeJSONAsPathComponent properly encodes serialized JSON
 // for placement within a URI path
 func EncodeJSONAsPathComponent(jsonBytes string) string {
@@ -48,39 +67,36 @@ func Serialize(msg interface{}) ([]byte, error) {
 }
 
 func SerializeAndEncrypt(msg interface{}, cipherKey string, serialize bool) (string, error) {
-	var encrypted string
+	return SerializeAndEncryptWithIV(msg, cipherKey, serialize, false)
+}
+
+// SerializeAndEncryptWithIV is SerializeAndEncrypt with a random IV prepended
+// to the encrypted bytes when useRandomIV is true.
+func SerializeAndEncryptWithIV(msg interface{}, cipherKey string, serialize, useRandomIV bool) (string, error) {
 	if serialize {
 		jsonSerialized, errJSONMarshal := json.Marshal(msg)
 		if errJSONMarshal != nil {
 			return "", errJSONMarshal
 		}
-		encrypted = EncryptString(cipherKey, string(jsonSerialized))
-	} else {
-		if serializedMsg, ok := msg.(string); ok {
-			encrypted = EncryptString(cipherKey, serializedMsg)
-		} else {
-			return "", pnerr.NewBuildRequestError("Message is not JSON serialized.")
-		}
+		return EncryptStringWithIV(cipherKey, string(jsonSerialized), useRandomIV)
+	}
+	if serializedMsg, ok := msg.(string); ok {
+		return EncryptStringWithIV(cipherKey, serializedMsg, useRandomIV)
 	}
 
-	return encrypted, nil
+	return "", pnerr.NewBuildRequestError("Message is not JSON serialized.")
 }
 
 func SerializeEncryptAndSerialize(msg interface{}, cipherKey string, serialize bool) (string, error) {
-	var encrypted string
+	return SerializeEncryptAndSerializeWithIV(msg, cipherKey, serialize, false)
+}
 
-	if serialize {
-		jsonSerialized, errJSONMarshal := json.Marshal(msg)
-		if errJSONMarshal != nil {
-			return "", errJSONMarshal
-		}
-		encrypted = EncryptString(cipherKey, string(jsonSerialized))
-	} else {
-		if serializedMsg, ok := msg.(string); ok {
-			encrypted = EncryptString(cipherKey, serializedMsg)
-		} else {
-			return "", pnerr.NewBuildRequestError("Message is not JSON serialized.")
-		}
+// SerializeEncryptAndSerializeWithIV is SerializeEncryptAndSerialize with a random IV
+// prepended to the encrypted bytes when useRandomIV is true.
+func SerializeEncryptAndSerializeWithIV(msg interface{}, cipherKey string, serialize, useRandomIV bool) (string, error) {
+	encrypted, err := SerializeAndEncryptWithIV(msg, cipherKey, serialize, useRandomIV)
+	if err != nil {
+		return "", err
 	}
 	jsonSerialized, errJSONMarshal := json.Marshal(encrypted)
 	if errJSONMarshal != nil {
@@ -90,10 +106,21 @@ func SerializeEncryptAndSerialize(msg interface{}, cipherKey string, serialize b
 }
 
 // PubNub - specific serializer
+// json.RawMessage and []byte values holding valid JSON are already encoded and are returned as is.
 func ValueAsString(value interface{}) ([]byte, error) {
 	switch t := value.(type) {
 	case string:
 		return []byte(fmt.Sprintf("\"%s\"", t)), nil
+	case json.RawMessage:
+		if json.Valid(t) {
+			return t, nil
+		}
+		return json.Marshal(value)
+	case []byte:
+		if json.Valid(t) {
+			return t, nil
+		}
+		return json.Marshal(value)
 	default:
 		val, err := json.Marshal(value)
 		return val, err
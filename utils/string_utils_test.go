@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -15,6 +16,38 @@ func TestStringAsString(t *testing.T) {
 	assert.Equal([]byte("\"blah\""), str)
 }
 
+func TestRawMessageAsString(t *testing.T) {
+	assert := assert.New(t)
+
+	str, err := ValueAsString(json.RawMessage(`{"a":"b"}`))
+
+	assert.Nil(err)
+	assert.Equal([]byte(`{"a":"b"}`), str)
+}
+
+func TestBytesAsString(t *testing.T) {
+	assert := assert.New(t)
+
+	str, err := ValueAsString([]byte(`["a",1]`))
+
+	assert.Nil(err)
+	assert.Equal([]byte(`["a",1]`), str)
+
+	str, err = ValueAsString([]byte("blah"))
+
+	assert.Nil(err)
+	assert.Equal([]byte("\"YmxhaA==\""), str)
+}
+
+func TestJSONStringAsString(t *testing.T) {
+	assert := assert.New(t)
+
+	str, err := ValueAsString(`{"a":"b"}`)
+
+	assert.Nil(err)
+	assert.Equal([]byte(`"{"a":"b"}"`), str)
+}
+
 func TestUUID(t *testing.T) {
 	assert := assert.New(t)
 
@@ -27,3 +60,14 @@ func TestURLEncode(t *testing.T) {
 	assert.Equal("%5B%22hey1%22%2C%20%22hey2%22%2C%20%22hey3%5D",
 		URLEncode(`["hey1", "hey2", "hey3]`))
 }
+
+func TestDecodeChannel(t *testing.T) {
+	assert := assert.New(t)
+	channels := []string{"a,b", "c"}
+
+	assert.Equal("a%2Cb,c", string(JoinChannels(channels)))
+	assert.Equal("a,b", DecodeChannel("a%2Cb", channels))
+	assert.Equal("a,b", DecodeChannel("a,b", channels))
+	assert.Equal("c", DecodeChannel("c", channels))
+	assert.Equal("d%2Ce", DecodeChannel("d%2Ce", channels))
+}
@@ -7,6 +7,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"time"
 
 	"github.com/pubnub/go/pnerr"
 )
@@ -48,6 +49,12 @@ func (b *whereNowBuilder) UUID(uuid string) *whereNowBuilder {
 	return b
 }
 
+// Transport sets the Transport for the WhereNow request.
+func (b *whereNowBuilder) Transport(tr http.RoundTripper) *whereNowBuilder {
+	b.opts.Transport = tr
+	return b
+}
+
 // QueryParam accepts a map, the keys and values of the map are passed as the query string parameters of the URL called by the API.
 func (b *whereNowBuilder) QueryParam(queryParam map[string]string) *whereNowBuilder {
 	b.opts.QueryParam = queryParam
@@ -55,6 +62,18 @@ func (b *whereNowBuilder) QueryParam(queryParam map[string]string) *whereNowBuil
 	return b
 }
 
+// Timeout sets the timeout for the WhereNow request.
+func (b *whereNowBuilder) Timeout(timeout time.Duration) *whereNowBuilder {
+	b.opts.Timeout = timeout
+
+	return b
+}
+
+// SignedURL returns the URL of the WhereNow request without executing it.
+func (b *whereNowBuilder) SignedURL() (string, error) {
+	return signedURL(b.opts)
+}
+
 // Execute runs the WhereNow request.
 func (b *whereNowBuilder) Execute() (*WhereNowResponse, StatusResponse, error) {
 	if len(b.opts.UUID) <= 0 {
@@ -74,6 +93,7 @@ type whereNowOpts struct {
 
 	UUID       string
 	QueryParam map[string]string
+	Timeout    time.Duration
 	Transport  http.RoundTripper
 
 	ctx Context
@@ -106,7 +126,8 @@ func (o *whereNowOpts) buildPath() (string, error) {
 }
 
 func (o *whereNowOpts) buildQuery() (*url.Values, error) {
-	q := defaultQuery(o.pubnub.Config.UUID, o.pubnub.telemetryManager)
+	q := defaultQuery(o.pubnub.Config, o.pubnub.telemetryManager)
+	o.pubnub.tokenManager.SetAuthParan(q, o.UUID, PNUsers)
 	SetQueryParam(q, o.QueryParam)
 	return q, nil
 }
@@ -143,6 +164,18 @@ func (o *whereNowOpts) telemetryManager() *TelemetryManager {
 	return o.pubnub.telemetryManager
 }
 
+func (o *whereNowOpts) tokenManager() *TokenManager {
+	return o.pubnub.tokenManager
+}
+
+func (o *whereNowOpts) timeout() time.Duration {
+	return o.Timeout
+}
+
+func (o *whereNowOpts) transport() http.RoundTripper {
+	return o.Transport
+}
+
 // WhereNowResponse is the response of the WhereNow request. Contains channels info.
 type WhereNowResponse struct {
 	Channels []string